@@ -0,0 +1,349 @@
+package akamai
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/spf13/cast"
+)
+
+type (
+	// ChangeFreeze holds the change freeze windows configured on the provider
+	ChangeFreeze struct {
+		Location  *time.Location
+		Recurring []RecurringFreezeWindow
+		Windows   []FreezeWindow
+	}
+
+	// RecurringFreezeWindow is a freeze window which starts every time the cron-like schedule matches and lasts for Duration
+	RecurringFreezeWindow struct {
+		Schedule    string
+		Duration    time.Duration
+		Description string
+		spec        cronSpec
+	}
+
+	// FreezeWindow is a one-off freeze window lasting from Start (inclusive) to End (exclusive)
+	FreezeWindow struct {
+		Start       time.Time
+		End         time.Time
+		Description string
+	}
+
+	// cronSpec holds the allowed values of each of the five schedule fields
+	cronSpec struct {
+		minute, hour, dom, month, dow map[int]bool
+		domAny, dowAny                bool
+	}
+)
+
+const (
+	// IgnoreChangeFreezeKey is the name of the resource attribute allowing a single resource to bypass change freeze windows
+	IgnoreChangeFreezeKey = "ignore_change_freeze"
+
+	// maxRecurringFreezeDuration caps the duration of a recurring window, it is also the look-back period when evaluating schedules
+	maxRecurringFreezeDuration = 31 * 24 * time.Hour
+)
+
+var (
+	// ErrChangeFreeze is returned when the change freeze configuration is invalid
+	ErrChangeFreeze = &Error{"invalid change freeze configuration", false}
+
+	// ErrChangeFreezeActive is returned when a production change is planned during an active change freeze window
+	ErrChangeFreezeActive = &Error{"change freeze is active", false}
+
+	// timeNow is used to evaluate the freeze windows, it can be replaced in tests
+	timeNow = time.Now
+)
+
+// changeFreezeSchema returns the schema of the provider level change_freeze block
+func changeFreezeSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"time_zone": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "UTC",
+				Description: "IANA time zone in which recurring schedules are evaluated",
+			},
+			"recurring": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Freeze windows starting every time the cron-like schedule matches",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"schedule": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Five field cron expression (minute hour day-of-month month day-of-week) marking the start of the window",
+						},
+						"duration": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "How long the window lasts, e.g. '24h' or '90m'",
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"window": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "One-off freeze windows",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"start": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Start of the window in RFC3339 format",
+						},
+						"end": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "End of the window in RFC3339 format",
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// IgnoreChangeFreezeSchema returns the schema of the per-resource change freeze override attribute
+func IgnoreChangeFreezeSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Description: "Allows the change to be planned even when a provider change freeze window is active",
+	}
+}
+
+// EnforceChangeFreeze returns a CustomizeDiffFunc which fails the plan when the resource is created or updated
+// during an active change freeze window. When networkKey is given only changes targeting the production network
+// held in this attribute are blocked, otherwise every change is considered a production change. A network which
+// isn't known yet at plan time is considered the production network.
+func EnforceChangeFreeze(networkKey string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+		if d.Id() != "" && len(d.GetChangedKeysPrefix("")) == 0 {
+			return nil
+		}
+		if networkKey != "" && d.NewValueKnown(networkKey) && !IsProductionNetwork(cast.ToString(d.Get(networkKey))) {
+			return nil
+		}
+		ignore, _ := d.Get(IgnoreChangeFreezeKey).(bool)
+		return checkChangeFreeze(m, ignore)
+	}
+}
+
+// EnforceChangeFreezeOnDelete fails the deletion of the resource during an active change freeze window. Terraform
+// doesn't customize the diff of a destroy, so the freeze is enforced when the destroy is applied instead of planned.
+// networkKey is used as in EnforceChangeFreeze.
+func EnforceChangeFreezeOnDelete(d *schema.ResourceData, m interface{}, networkKey string) error {
+	if networkKey != "" && !IsProductionNetwork(cast.ToString(d.Get(networkKey))) {
+		return nil
+	}
+	ignore, _ := d.Get(IgnoreChangeFreezeKey).(bool)
+	return checkChangeFreeze(m, ignore)
+}
+
+// checkChangeFreeze returns ErrChangeFreezeActive when a production change is made during an active freeze window
+func checkChangeFreeze(m interface{}, ignore bool) error {
+	meta, ok := m.(OperationMeta)
+	if !ok || meta.ChangeFreeze() == nil || ignore {
+		return nil
+	}
+
+	window, active := meta.ChangeFreeze().ActiveWindow(timeNow())
+	if !active {
+		return nil
+	}
+	meta.Log("EnforceChangeFreeze").Warnf("production change blocked by %s", window)
+	return fmt.Errorf("%w: production changes are not allowed during %s; set '%s = true' on the resource to override",
+		ErrChangeFreezeActive, window, IgnoreChangeFreezeKey)
+}
+
+// IsProductionNetwork returns true if the given network name refers to the production network
+func IsProductionNetwork(network string) bool {
+	switch strings.ToLower(network) {
+	case "production", "prod", "p":
+		return true
+	}
+	return false
+}
+
+// ActiveWindow returns the description of the freeze window active at the given time
+func (c *ChangeFreeze) ActiveWindow(now time.Time) (string, bool) {
+	for _, w := range c.Windows {
+		if !now.Before(w.Start) && now.Before(w.End) {
+			return w.String(), true
+		}
+	}
+	now = now.In(c.Location)
+	for _, r := range c.Recurring {
+		if r.activeAt(now) {
+			return r.String(), true
+		}
+	}
+	return "", false
+}
+
+func (w FreezeWindow) String() string {
+	s := fmt.Sprintf("change freeze window %s - %s", w.Start.Format(time.RFC3339), w.End.Format(time.RFC3339))
+	if w.Description != "" {
+		s = fmt.Sprintf("%s (%s)", s, w.Description)
+	}
+	return s
+}
+
+func (r RecurringFreezeWindow) String() string {
+	s := fmt.Sprintf("recurring change freeze window '%s' lasting %s", r.Schedule, r.Duration)
+	if r.Description != "" {
+		s = fmt.Sprintf("%s (%s)", s, r.Description)
+	}
+	return s
+}
+
+// activeAt checks every minute within the window duration before now for a schedule match
+func (r RecurringFreezeWindow) activeAt(now time.Time) bool {
+	start := now.Truncate(time.Minute)
+	for t := start; now.Sub(t) < r.Duration; t = t.Add(-time.Minute) {
+		if r.spec.matches(t) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s cronSpec) matches(t time.Time) bool {
+	if !s.minute[t.Minute()] || !s.hour[t.Hour()] || !s.month[int(t.Month())] {
+		return false
+	}
+	dom, dow := s.dom[t.Day()], s.dow[int(t.Weekday())]
+	// as in cron, when both day fields are restricted a match on either of them is enough
+	if !s.domAny && !s.dowAny {
+		return dom || dow
+	}
+	return dom && dow
+}
+
+// parseCronSpec parses a five field cron expression, supporting '*', lists, ranges and steps
+func parseCronSpec(expr string) (cronSpec, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return cronSpec{}, fmt.Errorf("schedule '%s' must have 5 fields: minute hour day-of-month month day-of-week", expr)
+	}
+	var spec cronSpec
+	var err error
+	if spec.minute, err = parseCronField(fields[0], 0, 59); err != nil {
+		return cronSpec{}, fmt.Errorf("minute: %s", err)
+	}
+	if spec.hour, err = parseCronField(fields[1], 0, 23); err != nil {
+		return cronSpec{}, fmt.Errorf("hour: %s", err)
+	}
+	if spec.dom, err = parseCronField(fields[2], 1, 31); err != nil {
+		return cronSpec{}, fmt.Errorf("day-of-month: %s", err)
+	}
+	if spec.month, err = parseCronField(fields[3], 1, 12); err != nil {
+		return cronSpec{}, fmt.Errorf("month: %s", err)
+	}
+	if spec.dow, err = parseCronField(fields[4], 0, 7); err != nil {
+		return cronSpec{}, fmt.Errorf("day-of-week: %s", err)
+	}
+	// both 0 and 7 stand for Sunday
+	if spec.dow[7] {
+		spec.dow[0] = true
+	}
+	spec.domAny = strings.HasPrefix(fields[2], "*")
+	spec.dowAny = strings.HasPrefix(fields[4], "*")
+	return spec, nil
+}
+
+func parseCronField(field string, min, max int) (map[int]bool, error) {
+	values := make(map[int]bool)
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid step in '%s'", part)
+			}
+			part = part[:i]
+		}
+		lo, hi := min, max
+		if part != "*" {
+			bounds := strings.SplitN(part, "-", 2)
+			var err error
+			if lo, err = strconv.Atoi(bounds[0]); err != nil {
+				return nil, fmt.Errorf("invalid value '%s'", part)
+			}
+			hi = lo
+			if len(bounds) == 2 {
+				if hi, err = strconv.Atoi(bounds[1]); err != nil {
+					return nil, fmt.Errorf("invalid value '%s'", part)
+				}
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return nil, fmt.Errorf("'%s' is out of range %d-%d", part, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			values[v] = true
+		}
+	}
+	return values, nil
+}
+
+// parseChangeFreeze builds the ChangeFreeze from the provider level change_freeze block
+func parseChangeFreeze(block map[string]interface{}) (*ChangeFreeze, error) {
+	location, err := time.LoadLocation(cast.ToString(block["time_zone"]))
+	if err != nil {
+		return nil, fmt.Errorf("%w: time_zone: %s", ErrChangeFreeze, err)
+	}
+	freeze := &ChangeFreeze{Location: location}
+
+	for _, r := range cast.ToSlice(block["recurring"]) {
+		recurring := cast.ToStringMap(r)
+		window := RecurringFreezeWindow{
+			Schedule:    cast.ToString(recurring["schedule"]),
+			Description: cast.ToString(recurring["description"]),
+		}
+		if window.spec, err = parseCronSpec(window.Schedule); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrChangeFreeze, err)
+		}
+		if window.Duration, err = time.ParseDuration(cast.ToString(recurring["duration"])); err != nil {
+			return nil, fmt.Errorf("%w: duration: %s", ErrChangeFreeze, err)
+		}
+		if window.Duration <= 0 || window.Duration > maxRecurringFreezeDuration {
+			return nil, fmt.Errorf("%w: duration must be positive and not longer than %s", ErrChangeFreeze, maxRecurringFreezeDuration)
+		}
+		freeze.Recurring = append(freeze.Recurring, window)
+	}
+
+	for _, w := range cast.ToSlice(block["window"]) {
+		oneOff := cast.ToStringMap(w)
+		window := FreezeWindow{Description: cast.ToString(oneOff["description"])}
+		if window.Start, err = time.Parse(time.RFC3339, cast.ToString(oneOff["start"])); err != nil {
+			return nil, fmt.Errorf("%w: start: %s", ErrChangeFreeze, err)
+		}
+		if window.End, err = time.Parse(time.RFC3339, cast.ToString(oneOff["end"])); err != nil {
+			return nil, fmt.Errorf("%w: end: %s", ErrChangeFreeze, err)
+		}
+		if !window.End.After(window.Start) {
+			return nil, fmt.Errorf("%w: window end '%s' must be after its start", ErrChangeFreeze, oneOff["end"])
+		}
+		freeze.Windows = append(freeze.Windows, window)
+	}
+
+	return freeze, nil
+}
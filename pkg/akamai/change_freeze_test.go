@@ -0,0 +1,227 @@
+package akamai
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseChangeFreeze(t *testing.T) {
+	tests := map[string]struct {
+		block     map[string]interface{}
+		withError bool
+	}{
+		"valid recurring and one-off windows": {
+			block: map[string]interface{}{
+				"time_zone": "UTC",
+				"recurring": []interface{}{
+					map[string]interface{}{"schedule": "0 18 * * 5", "duration": "62h", "description": "weekend"},
+				},
+				"window": []interface{}{
+					map[string]interface{}{"start": "2021-11-26T00:00:00Z", "end": "2021-11-30T00:00:00Z"},
+				},
+			},
+		},
+		"invalid time zone": {
+			block:     map[string]interface{}{"time_zone": "Mars/Olympus"},
+			withError: true,
+		},
+		"invalid schedule": {
+			block: map[string]interface{}{
+				"time_zone": "UTC",
+				"recurring": []interface{}{map[string]interface{}{"schedule": "0 25 * * *", "duration": "1h"}},
+			},
+			withError: true,
+		},
+		"schedule with missing fields": {
+			block: map[string]interface{}{
+				"time_zone": "UTC",
+				"recurring": []interface{}{map[string]interface{}{"schedule": "0 1 * *", "duration": "1h"}},
+			},
+			withError: true,
+		},
+		"duration too long": {
+			block: map[string]interface{}{
+				"time_zone": "UTC",
+				"recurring": []interface{}{map[string]interface{}{"schedule": "0 1 * * *", "duration": "1000h"}},
+			},
+			withError: true,
+		},
+		"window ends before it starts": {
+			block: map[string]interface{}{
+				"time_zone": "UTC",
+				"window": []interface{}{
+					map[string]interface{}{"start": "2021-11-30T00:00:00Z", "end": "2021-11-26T00:00:00Z"},
+				},
+			},
+			withError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseChangeFreeze(test.block)
+			if test.withError {
+				assert.True(t, errors.Is(err, ErrChangeFreeze), "want: %s; got: %s", ErrChangeFreeze, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestChangeFreezeActiveWindow(t *testing.T) {
+	freeze, err := parseChangeFreeze(map[string]interface{}{
+		"time_zone": "UTC",
+		"recurring": []interface{}{
+			// every Friday from 18:00 until Monday 08:00
+			map[string]interface{}{"schedule": "0 18 * * 5", "duration": "62h", "description": "weekend"},
+			// first day of every month, the whole day
+			map[string]interface{}{"schedule": "0 0 1 * *", "duration": "24h"},
+		},
+		"window": []interface{}{
+			map[string]interface{}{"start": "2021-12-24T00:00:00Z", "end": "2021-12-27T00:00:00Z", "description": "holidays"},
+		},
+	})
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		now         string
+		expectedWin string
+	}{
+		"Thursday afternoon": {
+			now: "2021-11-18T15:00:00Z",
+		},
+		"Friday evening": {
+			now:         "2021-11-19T18:00:00Z",
+			expectedWin: "recurring change freeze window '0 18 * * 5' lasting 62h0m0s (weekend)",
+		},
+		"Sunday": {
+			now:         "2021-11-21T12:30:00Z",
+			expectedWin: "recurring change freeze window '0 18 * * 5' lasting 62h0m0s (weekend)",
+		},
+		"Monday after the window": {
+			now: "2021-11-22T08:00:00Z",
+		},
+		"first day of the month": {
+			now:         "2021-12-01T23:59:00Z",
+			expectedWin: "recurring change freeze window '0 0 1 * *' lasting 24h0m0s",
+		},
+		"one-off window": {
+			now:         "2021-12-24T10:00:00+02:00",
+			expectedWin: "change freeze window 2021-12-24T00:00:00Z - 2021-12-27T00:00:00Z (holidays)",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			now, err := time.Parse(time.RFC3339, test.now)
+			require.NoError(t, err)
+			window, active := freeze.ActiveWindow(now)
+			assert.Equal(t, test.expectedWin != "", active)
+			assert.Equal(t, test.expectedWin, window)
+		})
+	}
+}
+
+func TestEnforceChangeFreezeOnDelete(t *testing.T) {
+	freeze, err := parseChangeFreeze(map[string]interface{}{
+		"time_zone": "UTC",
+		"window": []interface{}{
+			map[string]interface{}{"start": "2021-12-24T00:00:00Z", "end": "2021-12-27T00:00:00Z", "description": "holidays"},
+		},
+	})
+	require.NoError(t, err)
+	m := &meta{log: hclog.NewNullLogger(), changeFreeze: freeze}
+	resourceSchema := map[string]*schema.Schema{
+		"network":             {Type: schema.TypeString, Optional: true},
+		IgnoreChangeFreezeKey: IgnoreChangeFreezeSchema(),
+	}
+
+	tests := map[string]struct {
+		now        string
+		networkKey string
+		raw        map[string]interface{}
+		withError  bool
+	}{
+		"production deactivation during the window": {
+			now:        "2021-12-25T00:00:00Z",
+			networkKey: "network",
+			raw:        map[string]interface{}{"network": "PRODUCTION"},
+			withError:  true,
+		},
+		"staging deactivation during the window": {
+			now:        "2021-12-25T00:00:00Z",
+			networkKey: "network",
+			raw:        map[string]interface{}{"network": "STAGING"},
+		},
+		"delete without network during the window": {
+			now:       "2021-12-25T00:00:00Z",
+			raw:       map[string]interface{}{},
+			withError: true,
+		},
+		"delete with override during the window": {
+			now: "2021-12-25T00:00:00Z",
+			raw: map[string]interface{}{IgnoreChangeFreezeKey: true},
+		},
+		"delete after the window": {
+			now: "2021-12-27T00:00:00Z",
+			raw: map[string]interface{}{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			now, err := time.Parse(time.RFC3339, test.now)
+			require.NoError(t, err)
+			timeNow = func() time.Time { return now }
+			defer func() { timeNow = time.Now }()
+
+			d := schema.TestResourceDataRaw(t, resourceSchema, test.raw)
+			err = EnforceChangeFreezeOnDelete(d, m, test.networkKey)
+			if test.withError {
+				assert.True(t, errors.Is(err, ErrChangeFreezeActive), "want: %s; got: %s", ErrChangeFreezeActive, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestParseCronSpec(t *testing.T) {
+	spec, err := parseCronSpec("*/15 9-17 * 1,6-7 1-5")
+	require.NoError(t, err)
+	assert.Len(t, spec.minute, 4)
+	assert.Len(t, spec.hour, 9)
+	assert.Len(t, spec.month, 3)
+	assert.True(t, spec.domAny)
+	assert.False(t, spec.dowAny)
+
+	monday := time.Date(2021, time.June, 7, 9, 45, 0, 0, time.UTC)
+	assert.True(t, spec.matches(monday))
+	assert.False(t, spec.matches(monday.Add(time.Minute)))
+	assert.False(t, spec.matches(monday.AddDate(0, 0, 5)))
+
+	sunday, err := parseCronSpec("0 0 * * 7")
+	require.NoError(t, err)
+	assert.True(t, sunday.matches(time.Date(2021, time.June, 6, 0, 0, 0, 0, time.UTC)))
+
+	for _, expr := range []string{"60 * * * *", "* * 0 * *", "* * * * */0", "a * * * *", "5-1 * * * *"} {
+		_, err := parseCronSpec(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestIsProductionNetwork(t *testing.T) {
+	for _, network := range []string{"PRODUCTION", "production", "prod", "P"} {
+		assert.True(t, IsProductionNetwork(network), network)
+	}
+	for _, network := range []string{"STAGING", "staging", "s", ""} {
+		assert.False(t, IsProductionNetwork(network), network)
+	}
+}
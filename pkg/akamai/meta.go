@@ -28,6 +28,9 @@ type (
 
 		// CacheSet sets a value in the cache
 		CacheSet(prov Subprovider, key string, val interface{}) error
+
+		// ChangeFreeze returns the change freeze windows configured on the provider or nil if there are none
+		ChangeFreeze() *ChangeFreeze
 	}
 
 	meta struct {
@@ -35,6 +38,7 @@ type (
 		log          hclog.Logger
 		sess         session.Session
 		cacheEnabled bool
+		changeFreeze *ChangeFreeze
 	}
 )
 
@@ -58,6 +62,11 @@ func (m *meta) Session() session.Session {
 	return m.sess
 }
 
+// ChangeFreeze returns the change freeze configured on the provider
+func (m *meta) ChangeFreeze() *ChangeFreeze {
+	return m.changeFreeze
+}
+
 func (m *meta) CacheSet(prov Subprovider, key string, val interface{}) error {
 	log := m.Log("meta", "CacheSet")
 
@@ -100,6 +100,13 @@ func Provider(provs ...Subprovider) plugin.ProviderFunc {
 						Default:  true,
 						Type:     schema.TypeBool,
 					},
+					"change_freeze": {
+						Description: "Windows during which production changes are rejected at plan time",
+						Optional:    true,
+						Type:        schema.TypeList,
+						Elem:        changeFreezeSchema(),
+						MaxItems:    1,
+					},
 				},
 				ResourcesMap:       make(map[string]*schema.Resource),
 				DataSourcesMap:     make(map[string]*schema.Resource),
@@ -166,6 +173,18 @@ func configureContext(ctx context.Context, d *schema.ResourceData) (interface{},
 		return nil, diag.FromErr(err)
 	}
 
+	var changeFreeze *ChangeFreeze
+	freezeBlock, err := tools.GetListValue("change_freeze", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return nil, diag.FromErr(err)
+	}
+	if len(freezeBlock) > 0 && freezeBlock[0] != nil {
+		changeFreeze, err = parseChangeFreeze(freezeBlock[0].(map[string]interface{}))
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+	}
+
 	edgercOps := []edgegrid.Option{edgegrid.WithEnv(true)}
 
 	edgercPath, err := tools.GetStringValue("edgerc", d)
@@ -223,6 +242,7 @@ func configureContext(ctx context.Context, d *schema.ResourceData) (interface{},
 		operationID:  opid,
 		sess:         sess,
 		cacheEnabled: cacheEnabled,
+		changeFreeze: changeFreeze,
 	}
 
 	return meta, nil
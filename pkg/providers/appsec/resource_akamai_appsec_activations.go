@@ -29,6 +29,7 @@ func resourceActivations() *schema.Resource {
 		DeleteContext: resourceActivationsDelete,
 		CustomizeDiff: customdiff.All(
 			VerifyIDUnchanged,
+			akamai.EnforceChangeFreeze("network"),
 		),
 		Schema: map[string]*schema.Schema{
 			"config_id": {
@@ -58,6 +59,7 @@ func resourceActivations() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			akamai.IgnoreChangeFreezeKey: akamai.IgnoreChangeFreezeSchema(),
 		},
 	}
 }
@@ -266,6 +268,9 @@ func resourceActivationsUpdate(ctx context.Context, d *schema.ResourceData, m in
 }
 
 func resourceActivationsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := akamai.EnforceChangeFreezeOnDelete(d, m, "network"); err != nil {
+		return diag.FromErr(err)
+	}
 	meta := akamai.Meta(m)
 	client := inst.Client(meta)
 	logger := meta.Log("APPSEC", "resourceActivationsRemove")
@@ -24,6 +24,7 @@ func resourceCloudletsApplicationLoadBalancerActivation() *schema.Resource {
 		UpdateContext: resourceApplicationLoadBalancerActivationUpdate,
 		DeleteContext: resourceApplicationLoadBalancerActivationDelete,
 		Schema:        resourceCloudletsApplicationLoadBalancerActivationSchema(),
+		CustomizeDiff: akamai.EnforceChangeFreeze("network"),
 		Timeouts: &schema.ResourceTimeout{
 			Default: &ApplicationLoadBalancerActivationResourceTimeout,
 		},
@@ -56,6 +57,7 @@ func resourceCloudletsApplicationLoadBalancerActivationSchema() map[string]*sche
 			Computed:    true,
 			Description: "Activation status for this application load balancer",
 		},
+		akamai.IgnoreChangeFreezeKey: akamai.IgnoreChangeFreezeSchema(),
 	}
 }
 
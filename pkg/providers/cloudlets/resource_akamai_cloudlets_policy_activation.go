@@ -26,6 +26,7 @@ func resourceCloudletsPolicyActivation() *schema.Resource {
 		UpdateContext: resourcePolicyActivationUpdate,
 		DeleteContext: resourcePolicyActivationDelete,
 		Schema:        resourceCloudletsPolicyActivationSchema(),
+		CustomizeDiff: akamai.EnforceChangeFreeze("network"),
 		Timeouts: &schema.ResourceTimeout{
 			Default: &PolicyActivationResourceTimeout,
 		},
@@ -64,6 +65,7 @@ func resourceCloudletsPolicyActivationSchema() map[string]*schema.Schema {
 			Elem:        &schema.Schema{Type: schema.TypeString},
 			Description: "Set of property IDs to link to this Cloudlets policy",
 		},
+		akamai.IgnoreChangeFreezeKey: akamai.IgnoreChangeFreezeSchema(),
 	}
 }
 
@@ -82,6 +84,9 @@ var (
 )
 
 func resourcePolicyActivationDelete(ctx context.Context, rd *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := akamai.EnforceChangeFreezeOnDelete(rd, m, "network"); err != nil {
+		return diag.FromErr(err)
+	}
 	meta := akamai.Meta(m)
 	logger := meta.Log("Cloudlets", "resourcePolicyActivationDelete")
 	logger.Debug("Deleting cloudlets policy activation")
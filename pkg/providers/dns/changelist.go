@@ -0,0 +1,256 @@
+package dns
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	dns "github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/configdns"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// ChangeLists contains the Edge DNS change list operations used to stage recordset changes and submit them at once,
+	// the configdns client can only create, fetch and submit change lists
+	// See: https://developer.akamai.com/api/cloud_security/edge_dns_zone_management/v2.html#changelists
+	ChangeLists interface {
+		// CreateChangeList opens a change list holding the current recordsets of the zone
+		//
+		// See: https://developer.akamai.com/api/cloud_security/edge_dns_zone_management/v2.html#postchangelists
+		CreateChangeList(context.Context, CreateChangeListRequest) error
+
+		// GetChangeListRecordsets lists the recordsets of the zone with the staged changes applied
+		//
+		// See: https://developer.akamai.com/api/cloud_security/edge_dns_zone_management/v2.html#getchangelistrecordsets
+		GetChangeListRecordsets(context.Context, string) (*dns.RecordSetResponse, error)
+
+		// AppendChangeListChange stages a recordset change in the change list of the zone
+		//
+		// See: https://developer.akamai.com/api/cloud_security/edge_dns_zone_management/v2.html#postchangelistrecordsetaddchange
+		AppendChangeListChange(context.Context, AppendChangeListChangeRequest) error
+
+		// DeleteChangeList discards the change list of the zone
+		//
+		// See: https://developer.akamai.com/api/cloud_security/edge_dns_zone_management/v2.html#deletechangelist
+		DeleteChangeList(context.Context, string) error
+	}
+
+	changeListClient struct {
+		session.Session
+	}
+
+	// CreateChangeListRequest contains the zone of a new change list, and whether an existing change list is replaced
+	CreateChangeListRequest struct {
+		Zone      string
+		Overwrite ChangeListOverwrite
+	}
+
+	// AppendChangeListChangeRequest contains a recordset change to stage in the change list of the zone
+	AppendChangeListChangeRequest struct {
+		Zone   string
+		Change RecordsetChange
+	}
+
+	// RecordsetChange is a single recordset change of a change list
+	RecordsetChange struct {
+		Name  string              `json:"name"`
+		Type  string              `json:"type"`
+		Op    RecordsetChangeType `json:"op"`
+		TTL   int                 `json:"ttl,omitempty"`
+		Rdata []string            `json:"rdata,omitempty"`
+	}
+
+	// ChangeListOverwrite defines which existing change list is replaced by a new one
+	ChangeListOverwrite string
+
+	// RecordsetChangeType is the operation of a recordset change
+	RecordsetChangeType string
+)
+
+const (
+	// ChangeListOverwriteNone fails when the zone already has a change list
+	ChangeListOverwriteNone ChangeListOverwrite = ""
+	// ChangeListOverwriteStale replaces the change list of the zone only when it is stale
+	ChangeListOverwriteStale ChangeListOverwrite = "stale"
+	// ChangeListOverwriteAny replaces any change list of the zone
+	ChangeListOverwriteAny ChangeListOverwrite = "any"
+
+	// RecordsetChangeAdd adds a recordset
+	RecordsetChangeAdd RecordsetChangeType = "ADD"
+	// RecordsetChangeEdit replaces a recordset
+	RecordsetChangeEdit RecordsetChangeType = "EDIT"
+	// RecordsetChangeDelete removes a recordset
+	RecordsetChangeDelete RecordsetChangeType = "DELETE"
+)
+
+var (
+	// ErrCreateChangeList represents error when opening a change list fails
+	ErrCreateChangeList = errors.New("creating change list")
+	// ErrGetChangeListRecordsets represents error when listing the recordsets of a change list fails
+	ErrGetChangeListRecordsets = errors.New("listing change list recordsets")
+	// ErrAppendChangeListChange represents error when staging a change in a change list fails
+	ErrAppendChangeListChange = errors.New("staging change list change")
+	// ErrDeleteChangeList represents error when discarding a change list fails
+	ErrDeleteChangeList = errors.New("deleting change list")
+)
+
+// NewChangeListClient returns a ChangeLists client using the given session
+func NewChangeListClient(sess session.Session) ChangeLists {
+	return &changeListClient{Session: sess}
+}
+
+// Validate validates CreateChangeListRequest
+func (r CreateChangeListRequest) Validate() error {
+	return validation.Errors{
+		"Zone": validation.Validate(r.Zone, validation.Required),
+		"Overwrite": validation.Validate(r.Overwrite, validation.In(
+			ChangeListOverwriteNone, ChangeListOverwriteStale, ChangeListOverwriteAny)),
+	}.Filter()
+}
+
+// Validate validates AppendChangeListChangeRequest
+func (r AppendChangeListChangeRequest) Validate() error {
+	return validation.Errors{
+		"Zone":        validation.Validate(r.Zone, validation.Required),
+		"Change.Name": validation.Validate(r.Change.Name, validation.Required),
+		"Change.Type": validation.Validate(r.Change.Type, validation.Required),
+		"Change.Op": validation.Validate(r.Change.Op, validation.Required, validation.In(
+			RecordsetChangeAdd, RecordsetChangeEdit, RecordsetChangeDelete)),
+		"Change.Rdata": validation.Validate(r.Change.Rdata, validation.When(r.Change.Op != RecordsetChangeDelete, validation.Required)),
+	}.Filter()
+}
+
+func (c *changeListClient) CreateChangeList(ctx context.Context, params CreateChangeListRequest) error {
+	if err := params.Validate(); err != nil {
+		return fmt.Errorf("%s: %w: %s", ErrCreateChangeList, dns.ErrStructValidation, err)
+	}
+
+	logger := c.Log(ctx)
+	logger.Debug("CreateChangeList")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/config-dns/v2/changelists", nil)
+	if err != nil {
+		return fmt.Errorf("%w: failed to create request: %s", ErrCreateChangeList, err)
+	}
+
+	q := req.URL.Query()
+	q.Add("zone", params.Zone)
+	if params.Overwrite != ChangeListOverwriteNone {
+		q.Add("overwrite", string(params.Overwrite))
+	}
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := c.Exec(req, nil)
+	if err != nil {
+		return fmt.Errorf("%w: request failed: %s", ErrCreateChangeList, err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("%s: %w", ErrCreateChangeList, parseDNSError(c.Session, resp))
+	}
+
+	return nil
+}
+
+func (c *changeListClient) GetChangeListRecordsets(ctx context.Context, zone string) (*dns.RecordSetResponse, error) {
+	logger := c.Log(ctx)
+	logger.Debug("GetChangeListRecordsets")
+
+	uri := fmt.Sprintf("/config-dns/v2/changelists/%s/recordsets", zone)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetChangeListRecordsets, err)
+	}
+
+	q := req.URL.Query()
+	q.Add("showAll", "true")
+	req.URL.RawQuery = q.Encode()
+
+	var rval dns.RecordSetResponse
+	resp, err := c.Exec(req, &rval)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetChangeListRecordsets, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetChangeListRecordsets, parseDNSError(c.Session, resp))
+	}
+
+	return &rval, nil
+}
+
+func (c *changeListClient) AppendChangeListChange(ctx context.Context, params AppendChangeListChangeRequest) error {
+	if err := params.Validate(); err != nil {
+		return fmt.Errorf("%s: %w: %s", ErrAppendChangeListChange, dns.ErrStructValidation, err)
+	}
+
+	logger := c.Log(ctx)
+	logger.Debug("AppendChangeListChange")
+
+	uri := fmt.Sprintf("/config-dns/v2/changelists/%s/recordsets/add-change", params.Zone)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, nil)
+	if err != nil {
+		return fmt.Errorf("%w: failed to create request: %s", ErrAppendChangeListChange, err)
+	}
+
+	resp, err := c.Exec(req, nil, params.Change)
+	if err != nil {
+		return fmt.Errorf("%w: request failed: %s", ErrAppendChangeListChange, err)
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("%s: %w", ErrAppendChangeListChange, parseDNSError(c.Session, resp))
+	}
+
+	return nil
+}
+
+func (c *changeListClient) DeleteChangeList(ctx context.Context, zone string) error {
+	logger := c.Log(ctx)
+	logger.Debug("DeleteChangeList")
+
+	uri := fmt.Sprintf("/config-dns/v2/changelists/%s", zone)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, uri, nil)
+	if err != nil {
+		return fmt.Errorf("%w: failed to create request: %s", ErrDeleteChangeList, err)
+	}
+
+	resp, err := c.Exec(req, nil)
+	if err != nil {
+		return fmt.Errorf("%w: request failed: %s", ErrDeleteChangeList, err)
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("%s: %w", ErrDeleteChangeList, parseDNSError(c.Session, resp))
+	}
+
+	return nil
+}
+
+// parseDNSError parses an Edge DNS error from the response, as the configdns client does
+func parseDNSError(sess session.Session, r *http.Response) error {
+	var e dns.Error
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		sess.Log(r.Request.Context()).Errorf("reading error response body: %s", err)
+		e.StatusCode = r.StatusCode
+		e.Title = "Failed to read error body"
+		e.Detail = err.Error()
+		return &e
+	}
+
+	if err := json.Unmarshal(body, &e); err != nil {
+		sess.Log(r.Request.Context()).Errorf("could not unmarshal API error: %s", err)
+		e.Title = "Failed to unmarshal error body"
+		e.Detail = err.Error()
+	}
+
+	e.StatusCode = r.StatusCode
+
+	return &e
+}
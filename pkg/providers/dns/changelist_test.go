@@ -0,0 +1,234 @@
+package dns
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	dns "github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/configdns"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/edgegrid"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockchangelists struct {
+	mock.Mock
+}
+
+func (c *mockchangelists) CreateChangeList(ctx context.Context, r CreateChangeListRequest) error {
+	args := c.Called(ctx, r)
+
+	return args.Error(0)
+}
+
+func (c *mockchangelists) GetChangeListRecordsets(ctx context.Context, zone string) (*dns.RecordSetResponse, error) {
+	args := c.Called(ctx, zone)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*dns.RecordSetResponse), args.Error(1)
+}
+
+func (c *mockchangelists) AppendChangeListChange(ctx context.Context, r AppendChangeListChangeRequest) error {
+	args := c.Called(ctx, r)
+
+	return args.Error(0)
+}
+
+func (c *mockchangelists) DeleteChangeList(ctx context.Context, zone string) error {
+	args := c.Called(ctx, zone)
+
+	return args.Error(0)
+}
+
+// mockAPISession returns a session sending requests to the given test server
+func mockAPISession(t *testing.T, mockServer *httptest.Server) session.Session {
+	serverURL, err := url.Parse(mockServer.URL)
+	require.NoError(t, err)
+	certPool := x509.NewCertPool()
+	certPool.AddCert(mockServer.Certificate())
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs: certPool,
+			},
+		},
+	}
+	s, err := session.New(session.WithClient(httpClient), session.WithSigner(&edgegrid.Config{Host: serverURL.Host}))
+	require.NoError(t, err)
+	return s
+}
+
+func TestCreateChangeList(t *testing.T) {
+	tests := map[string]struct {
+		request        CreateChangeListRequest
+		responseStatus int
+		responseBody   string
+		expectedPath   string
+		withError      func(*testing.T, error)
+	}{
+		"201 created": {
+			request:        CreateChangeListRequest{Zone: "example.com"},
+			responseStatus: http.StatusCreated,
+			responseBody:   `{"zone": "example.com", "changeTag": "tag", "stale": false}`,
+			expectedPath:   "/config-dns/v2/changelists?zone=example.com",
+		},
+		"201 created overwriting": {
+			request:        CreateChangeListRequest{Zone: "example.com", Overwrite: ChangeListOverwriteAny},
+			responseStatus: http.StatusCreated,
+			responseBody:   `{"zone": "example.com", "changeTag": "tag", "stale": false}`,
+			expectedPath:   "/config-dns/v2/changelists?overwrite=any&zone=example.com",
+		},
+		"validation error": {
+			request: CreateChangeListRequest{Overwrite: "always"},
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, dns.ErrStructValidation), "want: %s; got: %s", dns.ErrStructValidation, err)
+			},
+		},
+		"409 conflict": {
+			request:        CreateChangeListRequest{Zone: "example.com"},
+			responseStatus: http.StatusConflict,
+			responseBody:   `{"type": "conflict", "title": "Conflict", "status": 409}`,
+			expectedPath:   "/config-dns/v2/changelists?zone=example.com",
+			withError: func(t *testing.T, err error) {
+				want := &dns.Error{Type: "conflict", Title: "Conflict", StatusCode: http.StatusConflict}
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+				assert.Contains(t, err.Error(), ErrCreateChangeList.Error())
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := NewChangeListClient(mockAPISession(t, mockServer))
+			err := client.CreateChangeList(context.Background(), test.request)
+			if test.withError != nil {
+				test.withError(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestGetChangeListRecordsets(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/config-dns/v2/changelists/example.com/recordsets?showAll=true", r.URL.String())
+		assert.Equal(t, http.MethodGet, r.Method)
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"recordsets": [{"name": "www.example.com", "type": "A", "ttl": 300, "rdata": ["192.0.2.1"]}]}`))
+		assert.NoError(t, err)
+	}))
+	defer mockServer.Close()
+	client := NewChangeListClient(mockAPISession(t, mockServer))
+	result, err := client.GetChangeListRecordsets(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []dns.Recordset{{Name: "www.example.com", Type: "A", TTL: 300, Rdata: []string{"192.0.2.1"}}}, result.Recordsets)
+}
+
+func TestAppendChangeListChange(t *testing.T) {
+	tests := map[string]struct {
+		request        AppendChangeListChangeRequest
+		responseStatus int
+		responseBody   string
+		expectedBody   string
+		withError      func(*testing.T, error)
+	}{
+		"204 no content": {
+			request: AppendChangeListChangeRequest{
+				Zone:   "example.com",
+				Change: RecordsetChange{Name: "www.example.com", Type: "A", Op: RecordsetChangeAdd, TTL: 300, Rdata: []string{"192.0.2.1"}},
+			},
+			responseStatus: http.StatusNoContent,
+			expectedBody:   `{"name": "www.example.com", "type": "A", "op": "ADD", "ttl": 300, "rdata": ["192.0.2.1"]}`,
+		},
+		"204 no content delete": {
+			request: AppendChangeListChangeRequest{
+				Zone:   "example.com",
+				Change: RecordsetChange{Name: "www.example.com", Type: "A", Op: RecordsetChangeDelete},
+			},
+			responseStatus: http.StatusNoContent,
+			expectedBody:   `{"name": "www.example.com", "type": "A", "op": "DELETE"}`,
+		},
+		"validation error": {
+			request: AppendChangeListChangeRequest{
+				Zone:   "example.com",
+				Change: RecordsetChange{Name: "www.example.com", Type: "A", Op: RecordsetChangeEdit},
+			},
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, dns.ErrStructValidation), "want: %s; got: %s", dns.ErrStructValidation, err)
+			},
+		},
+		"400 bad request": {
+			request: AppendChangeListChangeRequest{
+				Zone:   "example.com",
+				Change: RecordsetChange{Name: "www.example.com", Type: "A", Op: RecordsetChangeAdd, TTL: 300, Rdata: []string{"invalid"}},
+			},
+			responseStatus: http.StatusBadRequest,
+			responseBody:   `{"type": "bad-request", "title": "Bad Request", "status": 400}`,
+			expectedBody:   `{"name": "www.example.com", "type": "A", "op": "ADD", "ttl": 300, "rdata": ["invalid"]}`,
+			withError: func(t *testing.T, err error) {
+				want := &dns.Error{Type: "bad-request", Title: "Bad Request", StatusCode: http.StatusBadRequest}
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/config-dns/v2/changelists/example.com/recordsets/add-change", r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				require.NoError(t, err)
+				assert.JSONEq(t, test.expectedBody, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := NewChangeListClient(mockAPISession(t, mockServer))
+			err := client.AppendChangeListChange(context.Background(), test.request)
+			if test.withError != nil {
+				test.withError(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDeleteChangeList(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/config-dns/v2/changelists/example.com", r.URL.String())
+		assert.Equal(t, http.MethodDelete, r.Method)
+		w.WriteHeader(http.StatusNotFound)
+		_, err := w.Write([]byte(`{"type": "not-found", "title": "Not Found", "status": 404}`))
+		assert.NoError(t, err)
+	}))
+	defer mockServer.Close()
+	client := NewChangeListClient(mockAPISession(t, mockServer))
+	err := client.DeleteChangeList(context.Background(), "example.com")
+	var apiError *dns.Error
+	require.True(t, errors.As(err, &apiError), "want: *dns.Error; got: %s", err)
+	assert.Equal(t, http.StatusNotFound, apiError.StatusCode)
+	assert.Contains(t, err.Error(), ErrDeleteChangeList.Error())
+}
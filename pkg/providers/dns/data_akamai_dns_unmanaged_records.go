@@ -0,0 +1,129 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	dns "github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/configdns"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceDNSUnmanagedRecords() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDNSUnmanagedRecordsRead,
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"managed": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Recordsets of the zone managed by Terraform",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+			"records": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Live recordsets of the zone not in the managed list",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ttl": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"rdata": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDNSUnmanagedRecordsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("AkamaiDNS", "dataSourceDNSUnmanagedRecordsRead")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	zone, err := tools.GetStringValue("zone", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	managed := make(map[string]bool)
+	for _, v := range d.Get("managed").([]interface{}) {
+		if v == nil {
+			continue
+		}
+		rs := v.(map[string]interface{})
+		managed[unmanagedRecordsKey(rs["name"].(string), rs["type"].(string))] = true
+	}
+
+	logger.WithField("zone", zone).Debug("Listing unmanaged records")
+	var diags diag.Diagnostics
+	resp, err := inst.Client(meta).GetRecordsets(ctx, zone, dns.RecordsetQueryArgs{ShowAll: true})
+	if err != nil {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed retrieving recordsets of zone: %s", zone),
+			Detail:   err.Error(),
+		})
+	}
+	// the apex SOA and NS recordsets are managed by the zone itself
+	unmanaged := make([]dns.Recordset, 0)
+	for _, rs := range resp.Recordsets {
+		if recordsetInScope(zone, "", rs) && !managed[unmanagedRecordsKey(rs.Name, rs.Type)] {
+			unmanaged = append(unmanaged, rs)
+		}
+	}
+	sort.Slice(unmanaged, func(i, j int) bool {
+		return unmanagedRecordsKey(unmanaged[i].Name, unmanaged[i].Type) < unmanagedRecordsKey(unmanaged[j].Name, unmanaged[j].Type)
+	})
+
+	records := make([]map[string]interface{}, 0, len(unmanaged))
+	for _, rs := range unmanaged {
+		records = append(records, flattenRecordset(rs))
+	}
+	if err := d.Set("records", records); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+	d.SetId(zone)
+	return nil
+}
+
+// unmanagedRecordsKey identifies a recordset by its name and type, regardless of case and trailing dot
+func unmanagedRecordsKey(name, recordType string) string {
+	return fmt.Sprintf("%s %s", normalizeRecordsetName(name), strings.ToUpper(recordType))
+}
@@ -0,0 +1,53 @@
+package dns
+
+import (
+	"testing"
+
+	dns "github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/configdns"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDataSourceDNSUnmanagedRecords(t *testing.T) {
+	t.Run("basic", func(t *testing.T) {
+		client := &mockdns{}
+
+		client.On("GetRecordsets",
+			mock.Anything, // ctx is irrelevant for this test
+			"exampleterraform.io",
+			[]dns.RecordsetQueryArgs{{ShowAll: true}},
+		).Return(&dns.RecordSetResponse{Recordsets: []dns.Recordset{
+			{Name: "exampleterraform.io", Type: "SOA", TTL: 86400, Rdata: []string{"ns1.example.net. hostmaster.exampleterraform.io. 2021010101 14400 7200 604800 1200"}},
+			{Name: "exampleterraform.io", Type: "NS", TTL: 86400, Rdata: []string{"ns1.example.net."}},
+			{Name: "www.exampleterraform.io", Type: "A", TTL: 300, Rdata: []string{"192.0.2.10"}},
+			{Name: "www.exampleterraform.io", Type: "AAAA", TTL: 300, Rdata: []string{"2001:db8::10"}},
+			{Name: "mail.exampleterraform.io", Type: "MX", TTL: 300, Rdata: []string{"10 mx.exampleterraform.io."}},
+			{Name: "ftp.exampleterraform.io", Type: "CNAME", TTL: 600, Rdata: []string{"www.exampleterraform.io."}},
+		}}, nil)
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:  func() { testAccPreCheck(t) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestDataDnsUnmanagedRecords/basic.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr("data.akamai_dns_unmanaged_records.test", "id", "exampleterraform.io"),
+							resource.TestCheckResourceAttr("data.akamai_dns_unmanaged_records.test", "records.#", "2"),
+							resource.TestCheckResourceAttr("data.akamai_dns_unmanaged_records.test", "records.0.name", "ftp.exampleterraform.io"),
+							resource.TestCheckResourceAttr("data.akamai_dns_unmanaged_records.test", "records.0.type", "CNAME"),
+							resource.TestCheckResourceAttr("data.akamai_dns_unmanaged_records.test", "records.0.ttl", "600"),
+							resource.TestCheckResourceAttr("data.akamai_dns_unmanaged_records.test", "records.0.rdata.0", "www.exampleterraform.io."),
+							resource.TestCheckResourceAttr("data.akamai_dns_unmanaged_records.test", "records.1.name", "www.exampleterraform.io"),
+							resource.TestCheckResourceAttr("data.akamai_dns_unmanaged_records.test", "records.1.type", "AAAA"),
+							resource.TestCheckResourceAttr("data.akamai_dns_unmanaged_records.test", "records.1.rdata.0", "2001:db8::10"),
+						),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+}
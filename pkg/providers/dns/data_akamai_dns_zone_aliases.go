@@ -0,0 +1,79 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	dns "github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/configdns"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceDNSZoneAliases() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDNSZoneAliasesRead,
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"contract": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list the aliases of this contract",
+			},
+			"aliases": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Alias zones whose target is the zone",
+			},
+		},
+	}
+}
+
+func dataSourceDNSZoneAliasesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("AkamaiDNS", "dataSourceDNSZoneAliasesRead")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	zone, err := tools.GetStringValue("zone", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	contract := strings.TrimPrefix(d.Get("contract").(string), "ctr_")
+
+	logger.WithField("zone", zone).Debug("Listing zone aliases")
+	var diags diag.Diagnostics
+	// Edge DNS only returns the alias count of a zone, the aliases are the alias zones targeting it
+	list, err := inst.Client(meta).ListZones(ctx, dns.ZoneListQueryArgs{ContractIDs: contract, ShowAll: true, Types: "ALIAS"})
+	if err != nil {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed listing aliases of zone: %s", zone),
+			Detail:   err.Error(),
+		})
+	}
+	aliases := make([]string, 0)
+	for _, alias := range list.Zones {
+		if normalizeRecordsetName(alias.Target) == normalizeRecordsetName(zone) {
+			aliases = append(aliases, alias.Zone)
+		}
+	}
+	sort.Strings(aliases)
+
+	if err := d.Set("aliases", aliases); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+	d.SetId(zone)
+	return nil
+}
@@ -0,0 +1,44 @@
+package dns
+
+import (
+	"testing"
+
+	dns "github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/configdns"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDataSourceDNSZoneAliases(t *testing.T) {
+	t.Run("basic", func(t *testing.T) {
+		client := &mockdns{}
+
+		client.On("ListZones",
+			mock.Anything, // ctx is irrelevant for this test
+			dns.ZoneListQueryArgs{ContractIDs: "1-3CV382", ShowAll: true, Types: "ALIAS"},
+		).Return(&dns.ZoneListResponse{Zones: []*dns.ZoneResponse{
+			{Zone: "b.exampleterraform.io", Type: "ALIAS", Target: "exampleterraform.io"},
+			{Zone: "other.exampleterraform.io", Type: "ALIAS", Target: "other.io"},
+			{Zone: "a.exampleterraform.io", Type: "ALIAS", Target: "ExampleTerraform.io."},
+		}}, nil)
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:  func() { testAccPreCheck(t) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestDataDnsZoneAliases/basic.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr("data.akamai_dns_zone_aliases.test", "id", "exampleterraform.io"),
+							resource.TestCheckResourceAttr("data.akamai_dns_zone_aliases.test", "aliases.#", "2"),
+							resource.TestCheckResourceAttr("data.akamai_dns_zone_aliases.test", "aliases.0", "a.exampleterraform.io"),
+							resource.TestCheckResourceAttr("data.akamai_dns_zone_aliases.test", "aliases.1", "b.exampleterraform.io"),
+						),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,292 @@
+package dns
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// dnskeyFlagSEP is the Secure Entry Point flag of a DNSKEY, set on key signing keys
+	dnskeyFlagSEP = 1
+)
+
+func dataSourceDNSZoneDNSSec() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDNSZoneDNSSecRead,
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"algorithm": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Sign and Serve algorithm of the zone",
+			},
+			"alerts": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"rotation_in_progress": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether new keys are published for a key rotation",
+			},
+			"last_modified_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Date the current keys were last rotated",
+			},
+			"ds_records": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "DS records of the current keys, as published by Edge DNS",
+			},
+			"keys": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "current, or new while a key rotation is in progress",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "KSK or ZSK",
+						},
+						"flags": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"algorithm": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"key_tag": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"public_key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ttl": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"ds_sha256": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "SHA-256 digest of the DS record of a KSK",
+						},
+						"ds_sha384": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "SHA-384 digest of the DS record of a KSK",
+						},
+						"last_modified_date": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDNSZoneDNSSecRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("AkamaiDNS", "dataSourceDNSZoneDNSSecRead")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	zone, err := tools.GetStringValue("zone", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	logger.WithField("zone", zone).Debug("Fetching DNSSEC keys")
+	var diags diag.Diagnostics
+	zoneResp, err := inst.Client(meta).GetZone(ctx, zone)
+	if err != nil {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed retrieving zone: %s", zone),
+			Detail:   err.Error(),
+		})
+	}
+	if !zoneResp.SignAndServe {
+		return diag.Errorf("sign_and_serve is not enabled on zone %s", zone)
+	}
+
+	statuses, err := inst.DNSSecClient(meta).GetZonesDNSSecStatus(ctx, GetZonesDNSSecStatusRequest{Zones: []string{zone}})
+	if err != nil {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed retrieving DNSSEC status: %s", zone),
+			Detail:   err.Error(),
+		})
+	}
+	var status *SecStatus
+	for i := range statuses.DNSSecStatuses {
+		if normalizeRecordsetName(statuses.DNSSecStatuses[i].Zone) == normalizeRecordsetName(zone) {
+			status = &statuses.DNSSecStatuses[i]
+		}
+	}
+	if status == nil {
+		return diag.Errorf("no DNSSEC status returned for zone %s", zone)
+	}
+
+	keys, err := dnsSecKeys(zone, "current", status.CurrentRecords)
+	if err != nil {
+		return diag.Errorf("invalid DNSSEC records of zone %s: %s", zone, err)
+	}
+	if status.NewRecords != nil {
+		newKeys, err := dnsSecKeys(zone, "new", *status.NewRecords)
+		if err != nil {
+			return diag.Errorf("invalid DNSSEC records of zone %s: %s", zone, err)
+		}
+		keys = append(keys, newKeys...)
+	}
+	dsRecords, err := parseZoneFile(zone, status.CurrentRecords.DSRecord)
+	if err != nil {
+		return diag.Errorf("invalid DNSSEC records of zone %s: %s", zone, err)
+	}
+	ds := make([]string, 0, len(dsRecords))
+	for _, r := range dsRecords {
+		ds = append(ds, r.String())
+	}
+
+	alerts := status.Alerts
+	if alerts == nil {
+		alerts = []string{}
+	}
+	attrs := map[string]interface{}{
+		"algorithm":            zoneResp.SignAndServeAlgorithm,
+		"alerts":               alerts,
+		"rotation_in_progress": status.NewRecords != nil,
+		"last_modified_date":   status.CurrentRecords.LastModifiedDate,
+		"ds_records":           ds,
+		"keys":                 keys,
+	}
+	for key, value := range attrs {
+		if err := d.Set(key, value); err != nil {
+			return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+		}
+	}
+	d.SetId(zone)
+	return nil
+}
+
+// dnsSecKeys returns the DNSKEY records of the zone with their key tags and DS digests
+func dnsSecKeys(zone, status string, records SecRecords) ([]interface{}, error) {
+	dnskeys, err := parseZoneFile(zone, records.DNSKeyRecord)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]interface{}, 0, len(dnskeys))
+	for _, r := range dnskeys {
+		if r.Type != "DNSKEY" {
+			continue
+		}
+		fields := strings.Fields(r.Rdata)
+		if len(fields) < 4 {
+			return nil, fmt.Errorf("DNSKEY record %s has no public key", r.Name)
+		}
+		flags, err := strconv.ParseUint(fields[0], 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DNSKEY flags of %s: %s", r.Name, fields[0])
+		}
+		protocol, err := strconv.ParseUint(fields[1], 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DNSKEY protocol of %s: %s", r.Name, fields[1])
+		}
+		algorithm, err := strconv.ParseUint(fields[2], 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DNSKEY algorithm of %s: %s", r.Name, fields[2])
+		}
+		publicKey := strings.Join(fields[3:], "")
+		keyData, err := base64.StdEncoding.DecodeString(publicKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DNSKEY public key of %s: %s", r.Name, err)
+		}
+
+		// RFC 4034 section 2.2 wire format of the DNSKEY rdata
+		rdata := make([]byte, 4, 4+len(keyData))
+		binary.BigEndian.PutUint16(rdata, uint16(flags))
+		rdata[2], rdata[3] = byte(protocol), byte(algorithm)
+		rdata = append(rdata, keyData...)
+
+		// only key signing keys are referenced by DS records in the parent zone
+		keyType, sha256Digest, sha384Digest := "ZSK", "", ""
+		if flags&dnskeyFlagSEP != 0 {
+			keyType = "KSK"
+			sha256Digest, sha384Digest = dsDigests(r.Name, rdata)
+		}
+		keys = append(keys, map[string]interface{}{
+			"status":             status,
+			"type":               keyType,
+			"flags":              int(flags),
+			"algorithm":          int(algorithm),
+			"key_tag":            int(dnskeyTag(rdata)),
+			"public_key":         publicKey,
+			"ttl":                r.TTL,
+			"ds_sha256":          sha256Digest,
+			"ds_sha384":          sha384Digest,
+			"last_modified_date": records.LastModifiedDate,
+		})
+	}
+	return keys, nil
+}
+
+// dnskeyTag computes the key tag of the DNSKEY rdata, as defined in RFC 4034 appendix B
+func dnskeyTag(rdata []byte) uint16 {
+	var ac uint32
+	for i, b := range rdata {
+		if i&1 == 0 {
+			ac += uint32(b) << 8
+		} else {
+			ac += uint32(b)
+		}
+	}
+	ac += ac >> 16 & 0xFFFF
+	return uint16(ac & 0xFFFF)
+}
+
+// dsDigests computes the SHA-256 and SHA-384 DS digests of the DNSKEY rdata owned by name, as defined in RFC 4034
+// section 5.1.4
+func dsDigests(name string, rdata []byte) (string, string) {
+	var data []byte
+	for _, label := range strings.Split(strings.TrimSuffix(strings.ToLower(name), "."), ".") {
+		data = append(data, byte(len(label)))
+		data = append(data, label...)
+	}
+	data = append(data, 0)
+	data = append(data, rdata...)
+
+	sha256Sum := sha256.Sum256(data)
+	sha384Sum := sha512.Sum384(data)
+	return strings.ToUpper(hex.EncodeToString(sha256Sum[:])), strings.ToUpper(hex.EncodeToString(sha384Sum[:]))
+}
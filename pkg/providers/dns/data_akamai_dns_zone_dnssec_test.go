@@ -0,0 +1,126 @@
+package dns
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"regexp"
+	"testing"
+
+	dns "github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/configdns"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// publicKey is the DNSKEY of the RFC 4034 section 5.4 example
+const publicKey = "AQOeiiR0GOMYkDshWoSKz9XzfwJr1AYtsmx3TGkJaNXVbfi/2pHm822aJ5iI9BMzNXxeYCmZDRD99WYwYqUSdjMmmAphXdvxegXd/M5+X7OrzKBaMbCVdFLUUh6DhweJBjEVv5f2wwjM9XzcnOf+EPbtG9DMBmADjFDc2w/rljwvFw=="
+
+func TestDataSourceDNSZoneDNSSec(t *testing.T) {
+	zone := "exampleterraform.io"
+	status := &GetZonesDNSSecStatusResponse{DNSSecStatuses: []SecStatus{{
+		Zone: zone,
+		CurrentRecords: SecRecords{
+			DNSKeyRecord: "exampleterraform.io. 7200 IN DNSKEY 257 3 13 ( AQOeiiR0GOMYkDshWoSKz9XzfwJr1AYtsmx3TGkJaNXVbfi/\n" +
+				"2pHm822aJ5iI9BMzNXxeYCmZDRD99WYwYqUSdjMmmAphXdvxegXd/M5+X7OrzKBaMbCVdFLUUh6DhweJBjEVv5f2wwjM9XzcnOf+EPbtG9DMBmADjFDc2w/rljwvFw== )\n" +
+				"exampleterraform.io. 7200 IN DNSKEY 256 3 13 " + publicKey + "\n",
+			DSRecord:         "exampleterraform.io. 86400 IN DS 60494 13 2 DED9F8D73810BCBA569DBFADA5053E7FF532A1870919193E8E9F4CA46123230D\n",
+			ExpectedTTL:      86400,
+			LastModifiedDate: "2021-01-01T00:00:00Z",
+		},
+	}}}
+
+	t.Run("basic", func(t *testing.T) {
+		client := &mockdns{}
+		dnsSec := &mockdnssec{}
+
+		client.On("GetZone",
+			mock.Anything, // ctx is irrelevant for this test
+			zone,
+		).Return(&dns.ZoneResponse{Zone: zone, Type: "PRIMARY", SignAndServe: true, SignAndServeAlgorithm: "ECDSA_P256_SHA256"}, nil)
+
+		dnsSec.On("GetZonesDNSSecStatus",
+			mock.Anything, // ctx is irrelevant for this test
+			GetZonesDNSSecStatusRequest{Zones: []string{zone}},
+		).Return(status, nil)
+
+		useClient(client, func() {
+			useDNSSecClient(dnsSec, func() {
+				resource.UnitTest(t, resource.TestCase{
+					PreCheck:  func() { testAccPreCheck(t) },
+					Providers: testAccProviders,
+					Steps: []resource.TestStep{
+						{
+							Config: loadFixtureString("testdata/TestDataDnsZoneDnssec/basic.tf"),
+							Check: resource.ComposeTestCheckFunc(
+								resource.TestCheckResourceAttr("data.akamai_dns_zone_dnssec.test", "id", zone),
+								resource.TestCheckResourceAttr("data.akamai_dns_zone_dnssec.test", "algorithm", "ECDSA_P256_SHA256"),
+								resource.TestCheckResourceAttr("data.akamai_dns_zone_dnssec.test", "rotation_in_progress", "false"),
+								resource.TestCheckResourceAttr("data.akamai_dns_zone_dnssec.test", "last_modified_date", "2021-01-01T00:00:00Z"),
+								resource.TestCheckResourceAttr("data.akamai_dns_zone_dnssec.test", "ds_records.#", "1"),
+								resource.TestCheckResourceAttr("data.akamai_dns_zone_dnssec.test", "keys.#", "2"),
+								resource.TestCheckResourceAttr("data.akamai_dns_zone_dnssec.test", "keys.0.type", "KSK"),
+								resource.TestCheckResourceAttr("data.akamai_dns_zone_dnssec.test", "keys.0.status", "current"),
+								resource.TestCheckResourceAttr("data.akamai_dns_zone_dnssec.test", "keys.0.algorithm", "13"),
+								resource.TestCheckResourceAttr("data.akamai_dns_zone_dnssec.test", "keys.0.key_tag", "60494"),
+								resource.TestCheckResourceAttr("data.akamai_dns_zone_dnssec.test", "keys.0.public_key", publicKey),
+								resource.TestCheckResourceAttr("data.akamai_dns_zone_dnssec.test", "keys.0.ds_sha256", "DED9F8D73810BCBA569DBFADA5053E7FF532A1870919193E8E9F4CA46123230D"),
+								resource.TestCheckResourceAttr("data.akamai_dns_zone_dnssec.test", "keys.0.ds_sha384", "99DA3967E75079F25D242D5D9B90229075A2F9A27927CC73B285CD5DD2B9EC716BF8058BE98401160E9C8169D85CB60A"),
+								resource.TestCheckResourceAttr("data.akamai_dns_zone_dnssec.test", "keys.1.type", "ZSK"),
+								resource.TestCheckResourceAttr("data.akamai_dns_zone_dnssec.test", "keys.1.key_tag", "60493"),
+								resource.TestCheckResourceAttr("data.akamai_dns_zone_dnssec.test", "keys.1.ds_sha256", ""),
+							),
+						},
+					},
+				})
+			})
+		})
+
+		client.AssertExpectations(t)
+		dnsSec.AssertExpectations(t)
+	})
+
+	t.Run("sign and serve disabled", func(t *testing.T) {
+		client := &mockdns{}
+		dnsSec := &mockdnssec{}
+
+		client.On("GetZone",
+			mock.Anything, // ctx is irrelevant for this test
+			zone,
+		).Return(&dns.ZoneResponse{Zone: zone, Type: "PRIMARY"}, nil)
+
+		useClient(client, func() {
+			useDNSSecClient(dnsSec, func() {
+				resource.UnitTest(t, resource.TestCase{
+					PreCheck:  func() { testAccPreCheck(t) },
+					Providers: testAccProviders,
+					Steps: []resource.TestStep{
+						{
+							Config:      loadFixtureString("testdata/TestDataDnsZoneDnssec/basic.tf"),
+							ExpectError: regexp.MustCompile(`sign_and_serve is not enabled on zone exampleterraform.io`),
+						},
+					},
+				})
+			})
+		})
+
+		client.AssertExpectations(t)
+		dnsSec.AssertExpectations(t)
+	})
+}
+
+func TestDNSKeyTag(t *testing.T) {
+	keyData, err := base64.StdEncoding.DecodeString(publicKey)
+	require.NoError(t, err)
+	rdata := make([]byte, 4)
+	binary.BigEndian.PutUint16(rdata, 256)
+	rdata[2], rdata[3] = 3, 5
+	rdata = append(rdata, keyData...)
+
+	// key id of the RFC 4034 section 5.4 example
+	assert.Equal(t, uint16(60485), dnskeyTag(rdata))
+
+	sha256Digest, sha384Digest := dsDigests("DSKEY.example.com.", rdata)
+	assert.Equal(t, "D4B7D520E7BB5F0F67674A0CCEB1E3E0614B93C4F9E99B8383F6A1E4469DA50A", sha256Digest)
+	assert.Equal(t, "AB64DBEBE13C0B6BAE558B78CCAB93B836F8ADA4CBED2D4484A8715A819DE7B9E846315E70EA5D884B377394BDAF16A3", sha384Digest)
+}
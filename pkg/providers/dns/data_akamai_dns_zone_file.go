@@ -0,0 +1,72 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceDNSZoneFile() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDNSZoneFileRead,
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"zone_file": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "RFC 1035 master file with all records of the zone",
+			},
+			"record_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceDNSZoneFileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("AkamaiDNS", "dataSourceDNSZoneFileRead")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	zone, err := tools.GetStringValue("zone", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	logger.WithField("zone", zone).Debug("Exporting zone file")
+	var diags diag.Diagnostics
+	zoneFile, err := inst.Client(meta).GetMasterZoneFile(ctx, zone)
+	if err != nil {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed retrieving zone file: %s", zone),
+			Detail:   err.Error(),
+		})
+	}
+	records, err := parseZoneFile(zone, zoneFile)
+	if err != nil {
+		return diag.Errorf("invalid zone file of zone %s: %s", zone, err)
+	}
+
+	if err := d.Set("zone_file", zoneFile); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+	if err := d.Set("record_count", len(records)); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+	d.SetId(zone)
+	return nil
+}
@@ -0,0 +1,69 @@
+package dns
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDataSourceDNSZoneFile(t *testing.T) {
+	zoneFile := `exampleterraform.io.	86400	IN	SOA	a1-1.akam.net. hostmaster.exampleterraform.io. 2021010101 14400 7200 604800 1200
+exampleterraform.io.	86400	IN	NS	a1-1.akam.net.
+www.exampleterraform.io.	300	IN	A	10.1.0.1
+`
+
+	t.Run("basic", func(t *testing.T) {
+		client := &mockdns{}
+
+		client.On("GetMasterZoneFile",
+			mock.Anything, // ctx is irrelevant for this test
+			"exampleterraform.io",
+		).Return(zoneFile, nil)
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:  func() { testAccPreCheck(t) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestDataDnsZoneFile/basic.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr("data.akamai_dns_zone_file.test", "id", "exampleterraform.io"),
+							resource.TestCheckResourceAttr("data.akamai_dns_zone_file.test", "zone_file", zoneFile),
+							resource.TestCheckResourceAttr("data.akamai_dns_zone_file.test", "record_count", "3"),
+						),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		client := &mockdns{}
+
+		client.On("GetMasterZoneFile",
+			mock.Anything, // ctx is irrelevant for this test
+			"exampleterraform.io",
+		).Return("", errors.New("zone not found"))
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:  func() { testAccPreCheck(t) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config:      loadFixtureString("testdata/TestDataDnsZoneFile/basic.tf"),
+						ExpectError: regexp.MustCompile(`zone not found`),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,150 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	dns "github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/configdns"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceDNSZoneTransferStatus() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDNSZoneTransferStatusRead,
+		Schema: map[string]*schema.Schema{
+			"zones": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Secondary zones whose transfer status is returned, all the secondary zones when not set",
+			},
+			"contract": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"zones"},
+				Description:   "Only return the secondary zones of this contract",
+			},
+			"transfers": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"zone": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"masters": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"serial": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "SOA serial of the zone transferred last",
+						},
+						"success": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the last transfer attempt succeeded",
+						},
+						"error_message": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Error of the last transfer attempt, when it failed",
+						},
+						"last_transfer_attempt_date": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"last_transfer_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Date of the last successful transfer",
+						},
+						"last_notify_date": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDNSZoneTransferStatusRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("AkamaiDNS", "dataSourceDNSZoneTransferStatusRead")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	var diags diag.Diagnostics
+	zones := make([]string, 0)
+	for _, zone := range d.Get("zones").([]interface{}) {
+		zones = append(zones, zone.(string))
+	}
+	if len(zones) == 0 {
+		contract := strings.TrimPrefix(d.Get("contract").(string), "ctr_")
+		logger.WithField("contract", contract).Debug("Listing secondary zones")
+		list, err := inst.Client(meta).ListZones(ctx, dns.ZoneListQueryArgs{ContractIDs: contract, ShowAll: true, Types: "SECONDARY"})
+		if err != nil {
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Failed listing secondary zones",
+				Detail:   err.Error(),
+			})
+		}
+		for _, zone := range list.Zones {
+			zones = append(zones, zone.Zone)
+		}
+	}
+	sort.Strings(zones)
+
+	transfers := make([]map[string]interface{}, 0, len(zones))
+	if len(zones) > 0 {
+		logger.WithField("zones", zones).Debug("Fetching zones transfer status")
+		statuses, err := inst.ZoneTransferClient(meta).GetZonesTransferStatus(ctx, GetZonesTransferStatusRequest{Zones: zones})
+		if err != nil {
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Failed retrieving zones transfer status",
+				Detail:   err.Error(),
+			})
+		}
+		sort.Slice(statuses.Zones, func(i, j int) bool {
+			return statuses.Zones[i].Zone < statuses.Zones[j].Zone
+		})
+		for _, status := range statuses.Zones {
+			masters := status.MasterServers
+			if masters == nil {
+				masters = []string{}
+			}
+			transfers = append(transfers, map[string]interface{}{
+				"zone":                       status.Zone,
+				"masters":                    masters,
+				"serial":                     status.ZoneSerial,
+				"success":                    status.LastTransferResult,
+				"error_message":              status.LastTransferError,
+				"last_transfer_attempt_date": status.LastTransferAttemptTime,
+				"last_transfer_date":         status.LastTransferTime,
+				"last_notify_date":           status.LastNotifyTime,
+			})
+		}
+	}
+
+	if err := d.Set("transfers", transfers); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+	d.SetId(tools.GetSHAString(strings.Join(zones, ",")))
+	return nil
+}
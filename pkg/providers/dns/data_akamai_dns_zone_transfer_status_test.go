@@ -0,0 +1,104 @@
+package dns
+
+import (
+	"testing"
+
+	dns "github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/configdns"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDataSourceDNSZoneTransferStatus(t *testing.T) {
+	statuses := &GetZonesTransferStatusResponse{Zones: []TransferStatus{
+		{
+			Zone:                    "secondaryexampleterraform.io",
+			MasterServers:           []string{"1.2.3.4"},
+			ZoneSerial:              2021010101,
+			LastTransferResult:      true,
+			LastTransferTime:        "2021-01-01T00:00:00Z",
+			LastTransferAttemptTime: "2021-01-01T00:00:00Z",
+		},
+		{
+			Zone:                    "otherexampleterraform.io",
+			MasterServers:           []string{"1.2.3.5"},
+			LastTransferResult:      false,
+			LastTransferError:       "connection refused",
+			LastTransferAttemptTime: "2021-01-02T00:00:00Z",
+		},
+	}}
+
+	t.Run("zones", func(t *testing.T) {
+		transferClient := &mockzonetransfer{}
+
+		transferClient.On("GetZonesTransferStatus",
+			mock.Anything, // ctx is irrelevant for this test
+			GetZonesTransferStatusRequest{Zones: []string{"otherexampleterraform.io", "secondaryexampleterraform.io"}},
+		).Return(statuses, nil)
+
+		useZoneTransferClient(transferClient, func() {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:  func() { testAccPreCheck(t) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestDataDnsZoneTransferStatus/zones.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr("data.akamai_dns_zone_transfer_status.test", "transfers.#", "2"),
+							resource.TestCheckResourceAttr("data.akamai_dns_zone_transfer_status.test", "transfers.0.zone", "otherexampleterraform.io"),
+							resource.TestCheckResourceAttr("data.akamai_dns_zone_transfer_status.test", "transfers.0.success", "false"),
+							resource.TestCheckResourceAttr("data.akamai_dns_zone_transfer_status.test", "transfers.0.error_message", "connection refused"),
+							resource.TestCheckResourceAttr("data.akamai_dns_zone_transfer_status.test", "transfers.0.last_transfer_attempt_date", "2021-01-02T00:00:00Z"),
+							resource.TestCheckResourceAttr("data.akamai_dns_zone_transfer_status.test", "transfers.1.zone", "secondaryexampleterraform.io"),
+							resource.TestCheckResourceAttr("data.akamai_dns_zone_transfer_status.test", "transfers.1.success", "true"),
+							resource.TestCheckResourceAttr("data.akamai_dns_zone_transfer_status.test", "transfers.1.serial", "2021010101"),
+							resource.TestCheckResourceAttr("data.akamai_dns_zone_transfer_status.test", "transfers.1.masters.0", "1.2.3.4"),
+							resource.TestCheckResourceAttr("data.akamai_dns_zone_transfer_status.test", "transfers.1.last_transfer_date", "2021-01-01T00:00:00Z"),
+						),
+					},
+				},
+			})
+		})
+
+		transferClient.AssertExpectations(t)
+	})
+
+	t.Run("secondary zones of contract", func(t *testing.T) {
+		client := &mockdns{}
+		transferClient := &mockzonetransfer{}
+
+		client.On("ListZones",
+			mock.Anything, // ctx is irrelevant for this test
+			dns.ZoneListQueryArgs{ContractIDs: "1-3CV382", ShowAll: true, Types: "SECONDARY"},
+		).Return(&dns.ZoneListResponse{Zones: []*dns.ZoneResponse{
+			{Zone: "secondaryexampleterraform.io", Type: "SECONDARY"},
+			{Zone: "otherexampleterraform.io", Type: "SECONDARY"},
+		}}, nil)
+
+		transferClient.On("GetZonesTransferStatus",
+			mock.Anything, // ctx is irrelevant for this test
+			GetZonesTransferStatusRequest{Zones: []string{"otherexampleterraform.io", "secondaryexampleterraform.io"}},
+		).Return(statuses, nil)
+
+		useClient(client, func() {
+			useZoneTransferClient(transferClient, func() {
+				resource.UnitTest(t, resource.TestCase{
+					PreCheck:  func() { testAccPreCheck(t) },
+					Providers: testAccProviders,
+					Steps: []resource.TestStep{
+						{
+							Config: loadFixtureString("testdata/TestDataDnsZoneTransferStatus/contract.tf"),
+							Check: resource.ComposeTestCheckFunc(
+								resource.TestCheckResourceAttr("data.akamai_dns_zone_transfer_status.test", "transfers.#", "2"),
+								resource.TestCheckResourceAttr("data.akamai_dns_zone_transfer_status.test", "transfers.0.zone", "otherexampleterraform.io"),
+								resource.TestCheckResourceAttr("data.akamai_dns_zone_transfer_status.test", "transfers.1.zone", "secondaryexampleterraform.io"),
+							),
+						},
+					},
+				})
+			})
+		})
+
+		client.AssertExpectations(t)
+		transferClient.AssertExpectations(t)
+	})
+}
@@ -414,25 +414,74 @@ func (d *mockdns) UpdateRecordsets(ctx context.Context, param *dns.Recordsets, p
 	return args.Error(0)
 }
 
-// Mocked out following endpoints to make tests compile due to PR in edgegrid that added these resources.
-func (d *mockdns) PostMasterZoneFile(_ context.Context, _ string, _ string) error {
-	return nil
+func (d *mockdns) PostMasterZoneFile(ctx context.Context, param string, param2 string) error {
+	args := d.Called(ctx, param, param2)
+
+	return args.Error(0)
 }
-func (d *mockdns) CreateBulkZones(_ context.Context, _ *dns.BulkZonesCreate, _ dns.ZoneQueryString) (*dns.BulkZonesResponse, error) {
-	return nil, nil
+
+func (d *mockdns) CreateBulkZones(ctx context.Context, param1 *dns.BulkZonesCreate, param2 dns.ZoneQueryString) (*dns.BulkZonesResponse, error) {
+	args := d.Called(ctx, param1, param2)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*dns.BulkZonesResponse), args.Error(1)
 }
-func (d *mockdns) DeleteBulkZones(_ context.Context, _ *dns.ZoneNameListResponse, _ ...bool) (*dns.BulkZonesResponse, error) {
-	return nil, nil
+
+func (d *mockdns) DeleteBulkZones(ctx context.Context, param1 *dns.ZoneNameListResponse, param2 ...bool) (*dns.BulkZonesResponse, error) {
+	var args mock.Arguments
+
+	if len(param2) > 0 {
+		args = d.Called(ctx, param1, param2)
+	} else {
+		args = d.Called(ctx, param1)
+	}
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*dns.BulkZonesResponse), args.Error(1)
 }
-func (d *mockdns) GetBulkZoneCreateStatus(_ context.Context, _ string) (*dns.BulkStatusResponse, error) {
-	return nil, nil
+
+func (d *mockdns) GetBulkZoneCreateStatus(ctx context.Context, param string) (*dns.BulkStatusResponse, error) {
+	args := d.Called(ctx, param)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*dns.BulkStatusResponse), args.Error(1)
 }
-func (d *mockdns) GetBulkZoneDeleteStatus(_ context.Context, _ string) (*dns.BulkStatusResponse, error) {
-	return nil, nil
+
+func (d *mockdns) GetBulkZoneDeleteStatus(ctx context.Context, param string) (*dns.BulkStatusResponse, error) {
+	args := d.Called(ctx, param)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*dns.BulkStatusResponse), args.Error(1)
 }
-func (d *mockdns) GetBulkZoneCreateResult(_ context.Context, _ string) (*dns.BulkCreateResultResponse, error) {
-	return nil, nil
+
+func (d *mockdns) GetBulkZoneCreateResult(ctx context.Context, param string) (*dns.BulkCreateResultResponse, error) {
+	args := d.Called(ctx, param)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*dns.BulkCreateResultResponse), args.Error(1)
 }
-func (d *mockdns) GetBulkZoneDeleteResult(_ context.Context, _ string) (*dns.BulkDeleteResultResponse, error) {
-	return nil, nil
+
+func (d *mockdns) GetBulkZoneDeleteResult(ctx context.Context, param string) (*dns.BulkDeleteResultResponse, error) {
+	args := d.Called(ctx, param)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*dns.BulkDeleteResultResponse), args.Error(1)
 }
@@ -0,0 +1,98 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	dns "github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/configdns"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// DNSSec contains the Edge DNS operations on the DNSSEC keys of Sign and Serve zones, which the configdns client
+	// doesn't provide
+	// See: https://developer.akamai.com/api/cloud_security/edge_dns_zone_management/v2.html#dnssec
+	DNSSec interface {
+		// GetZonesDNSSecStatus returns the current DNSKEY and DS records of the zones, and the new ones while a key
+		// rotation is in progress
+		//
+		// See: https://developer.akamai.com/api/cloud_security/edge_dns_zone_management/v2.html#postzonesdnssecstatus
+		GetZonesDNSSecStatus(context.Context, GetZonesDNSSecStatusRequest) (*GetZonesDNSSecStatusResponse, error)
+	}
+
+	dnsSecClient struct {
+		session.Session
+	}
+
+	// GetZonesDNSSecStatusRequest contains the zones whose DNSSEC status is returned
+	GetZonesDNSSecStatusRequest struct {
+		Zones []string `json:"zones"`
+	}
+
+	// GetZonesDNSSecStatusResponse contains the DNSSEC status of the requested zones
+	GetZonesDNSSecStatusResponse struct {
+		DNSSecStatuses []SecStatus `json:"dnsSecStatuses"`
+	}
+
+	// SecStatus is the DNSSEC status of a zone
+	SecStatus struct {
+		Zone           string      `json:"zone"`
+		Alerts         []string    `json:"alerts"`
+		CurrentRecords SecRecords  `json:"currentRecords"`
+		NewRecords     *SecRecords `json:"newRecords,omitempty"`
+	}
+
+	// SecRecords contains the DNSKEY and DS records of a zone in master file format
+	SecRecords struct {
+		DNSKeyRecord     string `json:"dnskeyRecord"`
+		DSRecord         string `json:"dsRecord"`
+		ExpectedTTL      int    `json:"expectedTtl"`
+		LastModifiedDate string `json:"lastModifiedDate"`
+	}
+)
+
+var (
+	// ErrGetZonesDNSSecStatus represents error when fetching the DNSSEC status of zones fails
+	ErrGetZonesDNSSecStatus = errors.New("fetching zones DNSSEC status")
+)
+
+// NewDNSSecClient returns a DNSSec client using the given session
+func NewDNSSecClient(sess session.Session) DNSSec {
+	return &dnsSecClient{Session: sess}
+}
+
+// Validate validates GetZonesDNSSecStatusRequest
+func (r GetZonesDNSSecStatusRequest) Validate() error {
+	return validation.Errors{
+		"Zones": validation.Validate(r.Zones, validation.Required),
+	}.Filter()
+}
+
+func (c *dnsSecClient) GetZonesDNSSecStatus(ctx context.Context, params GetZonesDNSSecStatusRequest) (*GetZonesDNSSecStatusResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetZonesDNSSecStatus, dns.ErrStructValidation, err)
+	}
+
+	logger := c.Log(ctx)
+	logger.Debug("GetZonesDNSSecStatus")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/config-dns/v2/zones/dns-sec-status", nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetZonesDNSSecStatus, err)
+	}
+
+	var rval GetZonesDNSSecStatusResponse
+	resp, err := c.Exec(req, &rval, params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetZonesDNSSecStatus, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetZonesDNSSecStatus, parseDNSError(c.Session, resp))
+	}
+
+	return &rval, nil
+}
@@ -0,0 +1,103 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	dns "github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/configdns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockdnssec struct {
+	mock.Mock
+}
+
+func (c *mockdnssec) GetZonesDNSSecStatus(ctx context.Context, r GetZonesDNSSecStatusRequest) (*GetZonesDNSSecStatusResponse, error) {
+	args := c.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*GetZonesDNSSecStatusResponse), args.Error(1)
+}
+
+func TestGetZonesDNSSecStatus(t *testing.T) {
+	tests := map[string]struct {
+		request          GetZonesDNSSecStatusRequest
+		responseStatus   int
+		responseBody     string
+		expectedResponse *GetZonesDNSSecStatusResponse
+		withError        func(*testing.T, error)
+	}{
+		"200 OK": {
+			request:        GetZonesDNSSecStatusRequest{Zones: []string{"example.com"}},
+			responseStatus: http.StatusOK,
+			responseBody: `{"dnsSecStatuses": [{
+				"zone": "example.com",
+				"alerts": [],
+				"currentRecords": {
+					"dnskeyRecord": "example.com. 7200 IN DNSKEY 257 3 13 a2V5",
+					"dsRecord": "example.com. 86400 IN DS 1 13 2 AB",
+					"expectedTtl": 86400,
+					"lastModifiedDate": "2021-01-01T00:00:00Z"
+				}
+			}]}`,
+			expectedResponse: &GetZonesDNSSecStatusResponse{DNSSecStatuses: []SecStatus{{
+				Zone:   "example.com",
+				Alerts: []string{},
+				CurrentRecords: SecRecords{
+					DNSKeyRecord:     "example.com. 7200 IN DNSKEY 257 3 13 a2V5",
+					DSRecord:         "example.com. 86400 IN DS 1 13 2 AB",
+					ExpectedTTL:      86400,
+					LastModifiedDate: "2021-01-01T00:00:00Z",
+				},
+			}}},
+		},
+		"validation error": {
+			request: GetZonesDNSSecStatusRequest{},
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, dns.ErrStructValidation), "want: %s; got: %s", dns.ErrStructValidation, err)
+			},
+		},
+		"404 not found": {
+			request:        GetZonesDNSSecStatusRequest{Zones: []string{"example.com"}},
+			responseStatus: http.StatusNotFound,
+			responseBody:   `{"type": "not-found", "title": "Not Found", "status": 404}`,
+			withError: func(t *testing.T, err error) {
+				want := &dns.Error{Type: "not-found", Title: "Not Found", StatusCode: http.StatusNotFound}
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/config-dns/v2/zones/dns-sec-status", r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				require.NoError(t, err)
+				assert.JSONEq(t, `{"zones": ["example.com"]}`, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := NewDNSSecClient(mockAPISession(t, mockServer))
+			result, err := client.GetZonesDNSSecStatus(context.Background(), test.request)
+			if test.withError != nil {
+				test.withError(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
@@ -18,7 +18,10 @@ type (
 	provider struct {
 		*schema.Provider
 
-		client dns.DNS
+		client             dns.DNS
+		changeListClient   ChangeLists
+		dnsSecClient       DNSSec
+		zoneTransferClient ZoneTransfers
 	}
 
 	// Option is a dns provider option
@@ -60,12 +63,20 @@ func Provider() *schema.Provider {
 			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"akamai_authorities_set": dataSourceAuthoritiesSet(),
-			"akamai_dns_record_set":  dataSourceDNSRecordSet(),
+			"akamai_authorities_set":          dataSourceAuthoritiesSet(),
+			"akamai_dns_record_set":           dataSourceDNSRecordSet(),
+			"akamai_dns_zone_file":            dataSourceDNSZoneFile(),
+			"akamai_dns_zone_dnssec":          dataSourceDNSZoneDNSSec(),
+			"akamai_dns_zone_aliases":         dataSourceDNSZoneAliases(),
+			"akamai_dns_zone_transfer_status": dataSourceDNSZoneTransferStatus(),
+			"akamai_dns_unmanaged_records":    dataSourceDNSUnmanagedRecords(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"akamai_dns_zone":   resourceDNSv2Zone(),
-			"akamai_dns_record": resourceDNSv2Record(),
+			"akamai_dns_zone":              resourceDNSv2Zone(),
+			"akamai_dns_record":            resourceDNSv2Record(),
+			"akamai_dns_recordsets":        resourceDNSv2Recordsets(),
+			"akamai_dns_changelist_submit": resourceDNSChangeListSubmit(),
+			"akamai_dns_zones_bulk":        resourceDNSZonesBulk(),
 		},
 	}
 	return provider
@@ -86,6 +97,51 @@ func (p *provider) Client(meta akamai.OperationMeta) dns.DNS {
 	return dns.Client(meta.Session())
 }
 
+// WithChangeListClient sets the change list client interface, used for mocking and testing
+func WithChangeListClient(c ChangeLists) Option {
+	return func(p *provider) {
+		p.changeListClient = c
+	}
+}
+
+// ChangeListClient returns the ChangeLists interface
+func (p *provider) ChangeListClient(meta akamai.OperationMeta) ChangeLists {
+	if p.changeListClient != nil {
+		return p.changeListClient
+	}
+	return NewChangeListClient(meta.Session())
+}
+
+// WithDNSSecClient sets the DNSSEC client interface, used for mocking and testing
+func WithDNSSecClient(c DNSSec) Option {
+	return func(p *provider) {
+		p.dnsSecClient = c
+	}
+}
+
+// DNSSecClient returns the DNSSec interface
+func (p *provider) DNSSecClient(meta akamai.OperationMeta) DNSSec {
+	if p.dnsSecClient != nil {
+		return p.dnsSecClient
+	}
+	return NewDNSSecClient(meta.Session())
+}
+
+// WithZoneTransferClient sets the zone transfer client interface, used for mocking and testing
+func WithZoneTransferClient(c ZoneTransfers) Option {
+	return func(p *provider) {
+		p.zoneTransferClient = c
+	}
+}
+
+// ZoneTransferClient returns the ZoneTransfers interface
+func (p *provider) ZoneTransferClient(meta akamai.OperationMeta) ZoneTransfers {
+	if p.zoneTransferClient != nil {
+		return p.zoneTransferClient
+	}
+	return NewZoneTransferClient(meta.Session())
+}
+
 func getConfigDNSV2Service(d *schema.ResourceData) error {
 	var inlineConfig *schema.Set
 	for _, key := range []string{"dns", "config"} {
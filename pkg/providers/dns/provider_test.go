@@ -50,6 +50,40 @@ func useClient(client dns.DNS, f func()) {
 	f()
 }
 
+// Only allow one test at a time to patch the change list client via useChangeListClient()
+var changeListClientLock sync.Mutex
+
+// useChangeListClient swaps out the change list client on the global instance for the duration of the given func
+func useChangeListClient(client ChangeLists, f func()) {
+	changeListClientLock.Lock()
+	orig := inst.changeListClient
+	inst.changeListClient = client
+
+	defer func() {
+		inst.changeListClient = orig
+		changeListClientLock.Unlock()
+	}()
+
+	f()
+}
+
+// Only allow one test at a time to patch the DNSSEC client via useDNSSecClient()
+var dnsSecClientLock sync.Mutex
+
+// useDNSSecClient swaps out the DNSSEC client on the global instance for the duration of the given func
+func useDNSSecClient(client DNSSec, f func()) {
+	dnsSecClientLock.Lock()
+	orig := inst.dnsSecClient
+	inst.dnsSecClient = client
+
+	defer func() {
+		inst.dnsSecClient = orig
+		dnsSecClientLock.Unlock()
+	}()
+
+	f()
+}
+
 func TestProvider(t *testing.T) {
 	if err := inst.Provider.InternalValidate(); err != nil {
 		t.Fatalf("err: %s", err)
@@ -117,3 +151,20 @@ func loadFixtureBytes(path string) []byte {
 func loadFixtureString(path string) string {
 	return string(loadFixtureBytes(path))
 }
+
+// Only allow one test at a time to patch the zone transfer client via useZoneTransferClient()
+var zoneTransferClientLock sync.Mutex
+
+// useZoneTransferClient swaps out the zone transfer client on the global instance for the duration of the given func
+func useZoneTransferClient(client ZoneTransfers, f func()) {
+	zoneTransferClientLock.Lock()
+	orig := inst.zoneTransferClient
+	inst.zoneTransferClient = client
+
+	defer func() {
+		inst.zoneTransferClient = orig
+		zoneTransferClientLock.Unlock()
+	}()
+
+	f()
+}
@@ -0,0 +1,129 @@
+package dns
+
+import (
+	"sync"
+)
+
+// dnsLocks serializes the changes made to the same records by parallel resources. Changes to the records of a zone
+// owner name are serialized, while changes to other owner names, or other zones, run in parallel. Changes to a whole
+// zone wait for, and block, all record changes of the zone.
+type dnsLocks struct {
+	mu    sync.Mutex
+	zones map[string]*zoneLock
+}
+
+type zoneLock struct {
+	sync.RWMutex
+	refs   int
+	owners map[string]*ownerLock
+}
+
+type ownerLock struct {
+	sync.Mutex
+	refs int
+}
+
+// soaSerials keeps the last SOA serial submitted for each zone, so parallel SOA changes never submit the same serial
+type soaSerials struct {
+	mu   sync.Mutex
+	last map[string]uint32
+}
+
+var (
+	recordLocks = newDNSLocks()
+	zoneSerials = newSOASerials()
+)
+
+func newDNSLocks() *dnsLocks {
+	return &dnsLocks{zones: make(map[string]*zoneLock)}
+}
+
+func newSOASerials() *soaSerials {
+	return &soaSerials{last: make(map[string]uint32)}
+}
+
+// lockRecord locks the records of the zone owner name and returns the function releasing the lock
+func (l *dnsLocks) lockRecord(zone, owner string) func() {
+	zone = normalizeRecordsetName(zone)
+	owner = normalizeRecordsetName(owner)
+
+	l.mu.Lock()
+	z := l.acquireZone(zone)
+	o, ok := z.owners[owner]
+	if !ok {
+		o = &ownerLock{}
+		z.owners[owner] = o
+	}
+	o.refs++
+	l.mu.Unlock()
+
+	z.RLock()
+	o.Lock()
+	return func() {
+		o.Unlock()
+		z.RUnlock()
+
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if o.refs--; o.refs == 0 {
+			delete(z.owners, owner)
+		}
+		l.releaseZone(zone, z)
+	}
+}
+
+// lockZone locks all records of the zone and returns the function releasing the lock
+func (l *dnsLocks) lockZone(zone string) func() {
+	zone = normalizeRecordsetName(zone)
+
+	l.mu.Lock()
+	z := l.acquireZone(zone)
+	l.mu.Unlock()
+
+	z.Lock()
+	return func() {
+		z.Unlock()
+
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.releaseZone(zone, z)
+	}
+}
+
+// acquireZone returns the lock of the zone, l.mu must be held
+func (l *dnsLocks) acquireZone(zone string) *zoneLock {
+	z, ok := l.zones[zone]
+	if !ok {
+		z = &zoneLock{owners: make(map[string]*ownerLock)}
+		l.zones[zone] = z
+	}
+	z.refs++
+	return z
+}
+
+// releaseZone drops the lock of the zone once unused, l.mu must be held
+func (l *dnsLocks) releaseZone(zone string, z *zoneLock) {
+	if z.refs--; z.refs == 0 {
+		delete(l.zones, zone)
+	}
+}
+
+// next returns the serial following both the current serial of the zone and the last serial submitted for it.
+// Serials are compared using RFC 1982 serial number arithmetic.
+func (s *soaSerials) next(zone string, current uint32) uint32 {
+	zone = normalizeRecordsetName(zone)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if last, ok := s.last[zone]; ok && serialAfter(last, current) {
+		current = last
+	}
+	current++
+	s.last[zone] = current
+	return current
+}
+
+// serialAfter returns true when serial a follows serial b
+func serialAfter(a, b uint32) bool {
+	return a != b && a-b < 1<<31
+}
@@ -0,0 +1,170 @@
+package dns
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockRecord(t *testing.T) {
+	t.Run("independent records locked in parallel", func(t *testing.T) {
+		locks := newDNSLocks()
+		owners := []struct{ zone, owner string }{
+			{"example.com", "www.example.com"},
+			{"example.com", "api.example.com"},
+			{"example.net", "www.example.net"},
+		}
+
+		// every goroutine keeps its lock until all of them hold theirs, which only completes when locking is parallel
+		var held sync.WaitGroup
+		held.Add(len(owners))
+		done := make(chan struct{})
+		go func() {
+			var wg sync.WaitGroup
+			for _, o := range owners {
+				wg.Add(1)
+				go func(zone, owner string) {
+					defer wg.Done()
+					unlock := locks.lockRecord(zone, owner)
+					held.Done()
+					held.Wait()
+					unlock()
+				}(o.zone, o.owner)
+			}
+			wg.Wait()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("independent records were not locked in parallel")
+		}
+		assert.Empty(t, locks.zones)
+	})
+
+	t.Run("same owner serialized", func(t *testing.T) {
+		locks := newDNSLocks()
+		var inside, maxInside int32
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				// owner names are case insensitive and may be fully qualified
+				owner := "www.example.com"
+				if i%2 == 0 {
+					owner = "WWW.example.com."
+				}
+				defer locks.lockRecord("example.com", owner)()
+				n := atomic.AddInt32(&inside, 1)
+				for {
+					m := atomic.LoadInt32(&maxInside)
+					if n <= m || atomic.CompareAndSwapInt32(&maxInside, m, n) {
+						break
+					}
+				}
+				time.Sleep(time.Millisecond)
+				atomic.AddInt32(&inside, -1)
+			}(i)
+		}
+		wg.Wait()
+		assert.Equal(t, int32(1), maxInside)
+		assert.Empty(t, locks.zones)
+	})
+
+	t.Run("zone lock excludes record locks", func(t *testing.T) {
+		locks := newDNSLocks()
+		unlockZone := locks.lockZone("example.com")
+
+		locked := make(chan struct{})
+		go func() {
+			defer locks.lockRecord("example.com", "www.example.com")()
+			close(locked)
+		}()
+		select {
+		case <-locked:
+			t.Fatal("record locked while the zone is locked")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		// other zones are not affected
+		locks.lockRecord("example.net", "www.example.net")()
+
+		unlockZone()
+		select {
+		case <-locked:
+		case <-time.After(5 * time.Second):
+			t.Fatal("record not locked once the zone was unlocked")
+		}
+	})
+}
+
+func TestSOASerialsNext(t *testing.T) {
+	t.Run("parallel changes get distinct serials", func(t *testing.T) {
+		serials := newSOASerials()
+		var mu sync.Mutex
+		seen := make(map[uint32]struct{})
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				// every writer read the same serial from the zone
+				serial := serials.next("example.com", 2021010101)
+				mu.Lock()
+				defer mu.Unlock()
+				seen[serial] = struct{}{}
+			}()
+		}
+		wg.Wait()
+		assert.Len(t, seen, 50)
+		assert.Equal(t, uint32(2021010152), serials.next("EXAMPLE.com.", 2021010101))
+	})
+
+	t.Run("newer zone serial wins", func(t *testing.T) {
+		serials := newSOASerials()
+		require.Equal(t, uint32(11), serials.next("example.com", 10))
+		assert.Equal(t, uint32(101), serials.next("example.com", 100))
+		assert.Equal(t, uint32(2), serials.next("example.net", 1))
+	})
+
+	t.Run("serial wraps around", func(t *testing.T) {
+		serials := newSOASerials()
+		require.Equal(t, uint32(0), serials.next("example.com", 1<<32-1))
+		assert.Equal(t, uint32(1), serials.next("example.com", 1<<32-1))
+	})
+}
+
+// benchmarkLockRecord runs short changes of the records returned by owner in parallel, the change waits like an API
+// call so changes overlap regardless of the number of CPUs
+func benchmarkLockRecord(b *testing.B, owner func(i int64) string) {
+	locks := newDNSLocks()
+	var counter int64
+	b.SetParallelism(16)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			name := owner(atomic.AddInt64(&counter, 1))
+			unlock := locks.lockRecord("example.com", name)
+			time.Sleep(50 * time.Microsecond)
+			unlock()
+		}
+	})
+}
+
+func BenchmarkLockRecordIndependentOwners(b *testing.B) {
+	benchmarkLockRecord(b, func(i int64) string {
+		return fmt.Sprintf("host%d.example.com", i)
+	})
+}
+
+func BenchmarkLockRecordSameOwner(b *testing.B) {
+	benchmarkLockRecord(b, func(int64) string {
+		return "www.example.com"
+	})
+}
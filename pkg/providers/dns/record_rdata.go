@@ -0,0 +1,865 @@
+package dns
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+)
+
+var (
+	// hostLabelRegexp matches the labels of host names, RFC 1123 section 2.1, and the underscore labels of services
+	hostLabelRegexp = regexp.MustCompile(`^[A-Za-z0-9_](?:[A-Za-z0-9_-]*[A-Za-z0-9_])?$`)
+	// caaTagRegexp matches the property tags of RFC 8659 section 4.1
+	caaTagRegexp = regexp.MustCompile(`^[A-Za-z0-9]{1,15}$`)
+	// caaParameterRegexp matches the issuer parameters of RFC 8659 section 4.2
+	caaParameterRegexp = regexp.MustCompile(`^[A-Za-z0-9]+=[\x21-\x3a\x3c-\x7e]*$`)
+	// naptrFlagsRegexp matches the NAPTR flags of RFC 3403 section 4.1
+	naptrFlagsRegexp    = regexp.MustCompile(`^[A-Za-z0-9]*$`)
+	locSecondsRegexp    = regexp.MustCompile(`^[0-9]+(\.[0-9]{1,3})?$`)
+	locAltitudeRegexp   = regexp.MustCompile(`^-?[0-9]+(\.[0-9]{1,2})?m?$`)
+	locPrecisionRegexp  = regexp.MustCompile(`^[0-9]+(\.[0-9]{1,2})?m?$`)
+	signatureTimeRegexp = regexp.MustCompile(`^[0-9]{14}$`)
+	typeNumberRegexp    = regexp.MustCompile(`^TYPE([0-9]{1,5})$`)
+	svcKeyNumberRegexp  = regexp.MustCompile(`^key([0-9]{1,5})$`)
+
+	base32HexNoPadding = base32.HexEncoding.WithPadding(base32.NoPadding)
+)
+
+// digest sizes in bytes by DS digest type, SSHFP fingerprint type and TLSA matching type
+var (
+	dsDigestSizes    = map[int]int{1: 20, 2: 32, 3: 32, 4: 48}
+	sshfpDigestSizes = map[int]int{1: 20, 2: 32}
+	tlsaDigestSizes  = map[int]int{1: 32, 2: 64}
+)
+
+// rrTypeMnemonics are the record types of the IANA DNS parameters registry, as listed by NSEC3 type bitmaps and
+// covered by RRSIG records
+var rrTypeMnemonics = map[string]struct{}{
+	"A": {}, "NS": {}, "MD": {}, "MF": {}, "CNAME": {}, "SOA": {}, "MB": {}, "MG": {}, "MR": {}, "NULL": {},
+	"WKS": {}, "PTR": {}, "HINFO": {}, "MINFO": {}, "MX": {}, "TXT": {}, "RP": {}, "AFSDB": {}, "X25": {},
+	"ISDN": {}, "RT": {}, "NSAP": {}, "NSAP-PTR": {}, "SIG": {}, "KEY": {}, "PX": {}, "GPOS": {}, "AAAA": {},
+	"LOC": {}, "NXT": {}, "EID": {}, "NIMLOC": {}, "SRV": {}, "ATMA": {}, "NAPTR": {}, "KX": {}, "CERT": {},
+	"A6": {}, "DNAME": {}, "SINK": {}, "APL": {}, "DS": {}, "SSHFP": {}, "IPSECKEY": {}, "RRSIG": {},
+	"NSEC": {}, "DNSKEY": {}, "DHCID": {}, "NSEC3": {}, "NSEC3PARAM": {}, "TLSA": {}, "SMIMEA": {}, "HIP": {},
+	"NINFO": {}, "RKEY": {}, "TALINK": {}, "CDS": {}, "CDNSKEY": {}, "OPENPGPKEY": {}, "CSYNC": {},
+	"ZONEMD": {}, "SVCB": {}, "HTTPS": {}, "SPF": {}, "UINFO": {}, "UID": {}, "GID": {}, "UNSPEC": {},
+	"NID": {}, "L32": {}, "L64": {}, "LP": {}, "EUI48": {}, "EUI64": {}, "URI": {}, "CAA": {}, "AVC": {},
+	"DOA": {}, "AMTRELAY": {}, "TA": {}, "DLV": {},
+}
+
+// rdataFields reads the record arguments checked by checkRdata, keeping the first error
+type rdataFields struct {
+	d   tools.ResourceDataFetcher
+	err error
+}
+
+func (f *rdataFields) keep(err error) {
+	if err != nil && !errors.Is(err, tools.ErrNotFound) && f.err == nil {
+		f.err = err
+	}
+}
+
+func (f *rdataFields) getString(key string) string {
+	value, err := tools.GetStringValue(key, f.d)
+	f.keep(err)
+	return value
+}
+
+func (f *rdataFields) getInt(key string) int {
+	value, err := tools.GetIntValue(key, f.d)
+	f.keep(err)
+	return value
+}
+
+func (f *rdataFields) getList(key string) []string {
+	values, err := tools.GetListValue(key, f.d)
+	f.keep(err)
+	list := make([]string, 0, len(values))
+	for _, value := range values {
+		str, ok := value.(string)
+		if !ok {
+			f.keep(fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, key, "string"))
+			continue
+		}
+		list = append(list, str)
+	}
+	return list
+}
+
+// rdataError reports the record argument rejected by the offline rdata checks and the reason
+func rdataError(key, recordType, format string, args ...interface{}) error {
+	return fmt.Errorf("configuration argument %s is invalid for %s: %s", key, recordType, fmt.Sprintf(format, args...))
+}
+
+// firstRdataError returns the first error of the checks of a record
+func firstRdataError(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkRdata parses the record data of recordType offline, reporting the argument that isn't valid and why.
+// Arguments that aren't set are left to the check*Record functions.
+func checkRdata(recordType string, d tools.ResourceDataFetcher) error {
+	f := &rdataFields{d: d}
+	var err error
+	switch recordType {
+	case RRTypeA:
+		err = checkRdataTargets(f, recordType, checkIPv4Address)
+	case RRTypeAaaa:
+		err = checkRdataTargets(f, recordType, checkIPv6Address)
+	case RRTypeAkamaiCdn, RRTypeCname, RRTypeNs, RRTypePtr:
+		err = checkRdataTargets(f, recordType, checkDomainName)
+	case RRTypeLoc:
+		err = checkRdataTargets(f, recordType, checkLocation)
+	case RRTypeSpf, RRTypeTxt:
+		err = checkRdataTargets(f, recordType, checkCharacterStrings)
+	case RRTypeCaa:
+		err = checkRdataTargets(f, recordType, checkCaaProperty)
+	case RRTypeAfsdb:
+		err = firstRdataError(
+			checkRdataRange(f, recordType, "subtype", 0, 65535),
+			checkRdataTargets(f, recordType, checkDomainName),
+		)
+	case RRTypeDnskey:
+		err = firstRdataError(
+			checkRdataRange(f, recordType, "protocol", 3, 3),
+			checkRdataRange(f, recordType, "algorithm", 1, 255),
+			checkRdataField(f, recordType, "key", checkBase64),
+		)
+	case RRTypeDs:
+		digestType := f.getInt("digest_type")
+		err = firstRdataError(
+			checkRdataRange(f, recordType, "keytag", 0, 65535),
+			checkRdataRange(f, recordType, "algorithm", 1, 255),
+			checkRdataRange(f, recordType, "digest_type", 1, 255),
+			checkRdataField(f, recordType, "digest", checkDigest(dsDigestSizes[digestType])),
+		)
+	case RRTypeHinfo:
+		err = firstRdataError(
+			checkRdataField(f, recordType, "hardware", checkCharacterString),
+			checkRdataField(f, recordType, "software", checkCharacterString),
+		)
+	case RRTypeMx:
+		err = firstRdataError(
+			checkRdataRange(f, recordType, "priority", 0, 65535),
+			checkRdataRange(f, recordType, "priority_increment", 0, 65535),
+			checkRdataTargets(f, recordType, checkMxTarget),
+		)
+	case RRTypeNaptr:
+		err = checkNaptrRdata(f, recordType)
+	case RRTypeNsec3:
+		err = firstRdataError(
+			checkRdataRange(f, recordType, "flags", 0, 1),
+			checkRdataRange(f, recordType, "iterations", 0, 65535),
+			checkRdataField(f, recordType, "salt", checkSalt),
+			checkRdataField(f, recordType, "next_hashed_owner_name", checkNextHashedOwnerName),
+			checkRdataField(f, recordType, "type_bitmaps", checkTypeBitmaps),
+		)
+	case RRTypeNsec3Param:
+		err = firstRdataError(
+			checkRdataRange(f, recordType, "flags", 0, 0),
+			checkRdataRange(f, recordType, "iterations", 0, 65535),
+			checkRdataField(f, recordType, "salt", checkSalt),
+		)
+	case RRTypeRp:
+		err = firstRdataError(
+			checkRdataField(f, recordType, "mailbox", checkDomainNameOrRoot),
+			checkRdataField(f, recordType, "txt", checkDomainNameOrRoot),
+		)
+	case RRTypeRrsig:
+		err = checkRrsigRdata(f, recordType)
+	case RRTypeSrv:
+		err = firstRdataError(
+			checkRdataRange(f, recordType, "priority", 0, 65535),
+			checkRdataRange(f, recordType, "weight", 0, 65535),
+			checkRdataRange(f, recordType, "port", 0, 65535),
+			checkRdataTargets(f, recordType, checkDomainNameOrRoot),
+		)
+	case RRTypeSshfp:
+		fingerprintType := f.getInt("fingerprint_type")
+		err = firstRdataError(
+			checkRdataRange(f, recordType, "algorithm", 1, 255),
+			checkRdataRange(f, recordType, "fingerprint_type", 1, 255),
+			checkRdataField(f, recordType, "fingerprint", checkDigest(sshfpDigestSizes[fingerprintType])),
+		)
+	case RRTypeSoa:
+		err = firstRdataError(
+			checkRdataField(f, recordType, "name_server", checkDomainName),
+			checkRdataField(f, recordType, "email_address", checkDomainName),
+			checkRdataRange(f, recordType, "refresh", 0, 2147483647),
+			checkRdataRange(f, recordType, "retry", 0, 2147483647),
+			checkRdataRange(f, recordType, "expiry", 0, 2147483647),
+			checkRdataRange(f, recordType, "nxdomain_ttl", 0, 2147483647),
+		)
+	case RRTypeCert:
+		err = firstRdataError(
+			checkRdataField(f, recordType, "type_mnemonic", checkCertType),
+			checkRdataRange(f, recordType, "type_value", 0, 65535),
+			checkRdataRange(f, recordType, "keytag", 0, 65535),
+			checkRdataRange(f, recordType, "algorithm", 0, 255),
+			checkRdataField(f, recordType, "certificate", checkBase64),
+		)
+	case RRTypeTlsa:
+		matchType := f.getInt("match_type")
+		err = firstRdataError(
+			checkRdataRange(f, recordType, "usage", 0, 3),
+			checkRdataRange(f, recordType, "selector", 0, 1),
+			checkRdataRange(f, recordType, "match_type", 0, 2),
+			checkRdataField(f, recordType, "certificate", checkDigest(tlsaDigestSizes[matchType])),
+		)
+	case RRTypeSvcb, RRTypeHTTPS:
+		err = firstRdataError(
+			checkRdataRange(f, recordType, "svc_priority", 0, 65535),
+			checkRdataField(f, recordType, "target_name", checkDomainNameOrRoot),
+			checkRdataField(f, recordType, "svc_params", checkSvcParams),
+		)
+	}
+	if f.err != nil {
+		return f.err
+	}
+	return err
+}
+
+// checkRdataTargets checks every target of the record with check
+func checkRdataTargets(f *rdataFields, recordType string, check func(string) error) error {
+	for i, target := range f.getList("target") {
+		if err := check(target); err != nil {
+			return rdataError(fmt.Sprintf("target.%d", i), recordType, "%q: %s", target, err)
+		}
+	}
+	return nil
+}
+
+// checkRdataField checks the string argument key of the record with check, when it's set
+func checkRdataField(f *rdataFields, recordType, key string, check func(string) error) error {
+	value := f.getString(key)
+	if value == "" {
+		return nil
+	}
+	if err := check(value); err != nil {
+		return rdataError(key, recordType, "%q: %s", value, err)
+	}
+	return nil
+}
+
+// checkRdataRange checks the numeric argument key of the record is between min and max
+func checkRdataRange(f *rdataFields, recordType, key string, min, max int) error {
+	value := f.getInt(key)
+	if value >= min && value <= max {
+		return nil
+	}
+	if min == max {
+		return rdataError(key, recordType, "%d must be %d", value, min)
+	}
+	return rdataError(key, recordType, "%d must be between %d and %d", value, min, max)
+}
+
+func checkIPv4Address(value string) error {
+	if ip := net.ParseIP(value); ip == nil || ip.To4() == nil || strings.Contains(value, ":") {
+		return errors.New("not an IPv4 address")
+	}
+	return nil
+}
+
+func checkIPv6Address(value string) error {
+	if ip := net.ParseIP(value); ip == nil || !strings.Contains(value, ":") {
+		return errors.New("not an IPv6 address")
+	}
+	return nil
+}
+
+// checkDomainName checks name is a domain name of host name labels, with or without the trailing dot
+func checkDomainName(name string) error {
+	fqdn := strings.TrimSuffix(name, ".")
+	if fqdn == "" {
+		return errors.New("not a domain name")
+	}
+	if len(fqdn) > 253 {
+		return errors.New("domain name is longer than 253 characters")
+	}
+	for _, label := range strings.Split(fqdn, ".") {
+		if label == "" {
+			return errors.New("domain name has an empty label")
+		}
+		if len(label) > 63 {
+			return fmt.Errorf("label %q is longer than 63 characters", label)
+		}
+		if !hostLabelRegexp.MatchString(label) {
+			return fmt.Errorf("label %q must only contain letters, digits, hyphens and underscores, and must not start or end with a hyphen", label)
+		}
+	}
+	return nil
+}
+
+// checkDomainNameOrRoot checks name is a domain name or the root domain, which RFC 2782, 3403, 7505 and 9460 use
+// for no target
+func checkDomainNameOrRoot(name string) error {
+	if name == "." {
+		return nil
+	}
+	return checkDomainName(name)
+}
+
+// splitCharacterStrings splits the quoted character strings of RFC 1035 section 5.1 in value, escapes included.
+// A value that isn't quoted is a single string.
+func splitCharacterStrings(value string) ([]string, error) {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, `\"`) {
+		value = `"` + strings.Trim(value, `\"`) + `"`
+	}
+	if len(value) < 2 || !strings.HasPrefix(value, `"`) || !strings.HasSuffix(value, `"`) {
+		return []string{value}, nil
+	}
+	var characterStrings []string
+	for value != "" {
+		if value[0] != '"' {
+			return nil, fmt.Errorf("text %q is outside of the quoted strings", value)
+		}
+		end := 1
+		for ; end < len(value) && value[end] != '"'; end++ {
+			if value[end] == '\\' {
+				end++
+			}
+		}
+		if end >= len(value) {
+			return nil, errors.New("quoted string isn't terminated")
+		}
+		characterStrings = append(characterStrings, value[1:end])
+		value = strings.TrimLeft(value[end+1:], " \t")
+	}
+	return characterStrings, nil
+}
+
+// characterStringLength returns the length in octets of a character string with escapes
+func characterStringLength(value string) int {
+	length := 0
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' && i+3 < len(value) && isDigits(value[i+1:i+4]) {
+			i += 3
+		} else if value[i] == '\\' {
+			i++
+		}
+		length++
+	}
+	return length
+}
+
+func isDigits(value string) bool {
+	for _, c := range value {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return value != ""
+}
+
+// checkCharacterStrings checks the quoted strings of TXT and SPF records don't exceed the 255 octets of a
+// character string. Values that aren't quoted are sent as a single string.
+func checkCharacterStrings(value string) error {
+	characterStrings, err := splitCharacterStrings(value)
+	if err != nil {
+		return err
+	}
+	if len(characterStrings) == 1 && !strings.HasSuffix(strings.TrimSpace(value), `"`) {
+		return nil
+	}
+	for i, str := range characterStrings {
+		if length := characterStringLength(str); length > 255 {
+			return fmt.Errorf("string %d is %d characters long, character strings are limited to 255", i+1, length)
+		}
+	}
+	return nil
+}
+
+// checkCharacterString checks value is a single character string, which must be quoted to contain whitespace
+func checkCharacterString(value string) error {
+	characterStrings, err := splitCharacterStrings(value)
+	if err != nil {
+		return err
+	}
+	if len(characterStrings) != 1 {
+		return errors.New("must be a single character string")
+	}
+	if str := characterStrings[0]; str == strings.TrimSpace(value) && strings.ContainsAny(str, " \t") {
+		return errors.New("must be quoted to contain spaces")
+	}
+	if length := characterStringLength(characterStrings[0]); length > 255 {
+		return fmt.Errorf("is %d characters long, character strings are limited to 255", length)
+	}
+	return nil
+}
+
+// checkLocation checks a location of RFC 1876 section 3:
+// d1 [m1 [s1]] {"N"|"S"} d2 [m2 [s2]] {"E"|"W"} alt["m"] [siz["m"] [hp["m"] [vp["m"]]]]
+func checkLocation(value string) error {
+	fields, err := checkLocationCoordinate(strings.Fields(value), "latitude", 90, "N", "S")
+	if err != nil {
+		return err
+	}
+	if fields, err = checkLocationCoordinate(fields, "longitude", 180, "E", "W"); err != nil {
+		return err
+	}
+	if len(fields) == 0 {
+		return errors.New("altitude is missing")
+	}
+	if len(fields) > 4 {
+		return fmt.Errorf("text %q follows the vertical precision", strings.Join(fields[4:], " "))
+	}
+	altitude := fields[0]
+	meters, err := strconv.ParseFloat(strings.TrimSuffix(altitude, "m"), 64)
+	if err != nil || !locAltitudeRegexp.MatchString(altitude) || meters < -100000 || meters > 42849672.95 {
+		return fmt.Errorf("altitude %q must be between -100000.00 and 42849672.95 meters", altitude)
+	}
+	for i, precision := range fields[1:] {
+		name := []string{"size", "horizontal precision", "vertical precision"}[i]
+		meters, err := strconv.ParseFloat(strings.TrimSuffix(precision, "m"), 64)
+		if err != nil || !locPrecisionRegexp.MatchString(precision) || meters > 90000000 {
+			return fmt.Errorf("%s %q must be between 0 and 90000000.00 meters", name, precision)
+		}
+	}
+	return nil
+}
+
+// checkLocationCoordinate checks the degrees, minutes and seconds of a coordinate followed by its hemisphere,
+// returning the fields following the coordinate
+func checkLocationCoordinate(fields []string, name string, maxDegrees int, hemispheres ...string) ([]string, error) {
+	n := 0
+	for n < len(fields) && fields[n] != hemispheres[0] && fields[n] != hemispheres[1] {
+		n++
+	}
+	if n == len(fields) {
+		return nil, fmt.Errorf("%s must be followed by %s or %s", name, hemispheres[0], hemispheres[1])
+	}
+	if n == 0 || n > 3 {
+		return nil, fmt.Errorf("%s must be degrees with optional minutes and seconds", name)
+	}
+	degrees, err := strconv.Atoi(fields[0])
+	if err != nil || degrees < 0 || degrees > maxDegrees {
+		return nil, fmt.Errorf("%s degrees %q must be between 0 and %d", name, fields[0], maxDegrees)
+	}
+	var minutes int
+	if n > 1 {
+		if minutes, err = strconv.Atoi(fields[1]); err != nil || minutes < 0 || minutes > 59 {
+			return nil, fmt.Errorf("%s minutes %q must be between 0 and 59", name, fields[1])
+		}
+	}
+	var seconds float64
+	if n > 2 {
+		if seconds, err = strconv.ParseFloat(fields[2], 64); err != nil || !locSecondsRegexp.MatchString(fields[2]) || seconds >= 60 {
+			return nil, fmt.Errorf("%s seconds %q must be between 0 and 59.999", name, fields[2])
+		}
+	}
+	if degrees == maxDegrees && (minutes > 0 || seconds > 0) {
+		return nil, fmt.Errorf("%s must not exceed %d degrees", name, maxDegrees)
+	}
+	return fields[n+1:], nil
+}
+
+// checkCaaProperty checks a CAA property of RFC 8659 section 4.1: flags, tag and value
+func checkCaaProperty(value string) error {
+	parts := strings.SplitN(strings.TrimSpace(value), " ", 3)
+	if len(parts) != 3 {
+		return errors.New("must be flags, tag and value separated by spaces")
+	}
+	if flags, err := strconv.Atoi(parts[0]); err != nil || flags < 0 || flags > 255 {
+		return fmt.Errorf("flags %q must be between 0 and 255", parts[0])
+	}
+	tag := parts[1]
+	if !caaTagRegexp.MatchString(tag) {
+		return fmt.Errorf("tag %q must be 1 to 15 letters or digits", tag)
+	}
+	if err := checkCharacterString(parts[2]); err != nil {
+		return fmt.Errorf("value %s", err)
+	}
+	propertyValue := strings.Trim(parts[2], `\"`)
+	switch strings.ToLower(tag) {
+	case "issue", "issuewild":
+		return checkCaaIssuerValue(propertyValue)
+	case "iodef":
+		if u, err := url.Parse(propertyValue); err != nil || (u.Scheme != "mailto" && u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("iodef %q must be a mailto:, http: or https: URL", propertyValue)
+		}
+	}
+	return nil
+}
+
+// checkCaaIssuerValue checks an issue or issuewild value of RFC 8659 section 4.2, an optional issuer domain name
+// followed by parameters separated by semicolons
+func checkCaaIssuerValue(value string) error {
+	parts := strings.Split(value, ";")
+	if issuer := strings.TrimSpace(parts[0]); issuer != "" {
+		if err := checkDomainName(issuer); err != nil {
+			return fmt.Errorf("issuer %q: %s", issuer, err)
+		}
+	}
+	for _, parameter := range parts[1:] {
+		parameter = strings.TrimSpace(parameter)
+		if parameter != "" && !caaParameterRegexp.MatchString(parameter) {
+			return fmt.Errorf("issuer parameter %q must be tag=value", parameter)
+		}
+	}
+	return nil
+}
+
+// checkMxTarget checks a mail exchange host name with an optional embedded priority. The null MX of RFC 7505 is .
+func checkMxTarget(value string) error {
+	fields := strings.Fields(value)
+	switch len(fields) {
+	case 1:
+	case 2:
+		if priority, err := strconv.Atoi(fields[0]); err != nil || priority < 0 || priority > 65535 {
+			return fmt.Errorf("priority %q must be between 0 and 65535", fields[0])
+		}
+	default:
+		return errors.New("must be a host name with an optional priority")
+	}
+	return checkDomainNameOrRoot(fields[len(fields)-1])
+}
+
+// checkNaptrRdata checks the fields of RFC 3403 section 4.1
+func checkNaptrRdata(f *rdataFields, recordType string) error {
+	regexpField := strings.Trim(f.getString("regexp"), `\"`)
+	replacement := f.getString("replacement")
+	if err := firstRdataError(
+		checkRdataRange(f, recordType, "order", 0, 65535),
+		checkRdataRange(f, recordType, "preference", 0, 65535),
+		checkRdataField(f, recordType, "flagsnaptr", checkNaptrFlags),
+		checkRdataField(f, recordType, "service", checkCharacterString),
+		checkRdataField(f, recordType, "regexp", checkNaptrRegexp),
+		checkRdataField(f, recordType, "replacement", checkDomainNameOrRoot),
+	); err != nil {
+		return err
+	}
+	if regexpField != "" && replacement != "" && replacement != "." {
+		return rdataError("replacement", recordType, "%q: must be . when regexp is set", replacement)
+	}
+	return nil
+}
+
+func checkNaptrFlags(value string) error {
+	if !naptrFlagsRegexp.MatchString(strings.Trim(value, `\"`)) {
+		return errors.New("flags must be letters or digits")
+	}
+	return nil
+}
+
+// checkNaptrRegexp checks a substitution expression of RFC 3402 section 3.2:
+// delimiter, POSIX extended regular expression, delimiter, replacement, delimiter and an optional i flag
+func checkNaptrRegexp(value string) error {
+	if err := checkCharacterString(value); err != nil {
+		return err
+	}
+	expr := strings.Trim(value, `\"`)
+	if expr == "" {
+		return nil
+	}
+	delim := expr[0]
+	if delim == '\\' || delim == 'i' || (delim >= '0' && delim <= '9') {
+		return fmt.Errorf("delimiter %q isn't allowed", string(delim))
+	}
+	var parts []string
+	start := 1
+	for i := 1; i < len(expr); i++ {
+		if expr[i] == '\\' {
+			i++
+			continue
+		}
+		if expr[i] == delim {
+			parts = append(parts, expr[start:i])
+			start = i + 1
+		}
+	}
+	if len(parts) != 2 {
+		return fmt.Errorf("must be %[1]sregexp%[1]sreplacement%[1]s with optional flags", string(delim))
+	}
+	if flags := expr[start:]; flags != "" && flags != "i" {
+		return fmt.Errorf("flags %q must be empty or i", flags)
+	}
+	re, err := regexp.CompilePOSIX(parts[0])
+	if err != nil {
+		return fmt.Errorf("regular expression: %s", err)
+	}
+	for i := 0; i < len(parts[1])-1; i++ {
+		if parts[1][i] != '\\' {
+			continue
+		}
+		if ref := parts[1][i+1]; ref >= '1' && ref <= '9' && int(ref-'0') > re.NumSubexp() {
+			return fmt.Errorf("back reference \\%c has no matching subexpression", ref)
+		}
+		i++
+	}
+	return nil
+}
+
+// checkRrsigRdata checks the fields of RFC 4034 section 3.2
+func checkRrsigRdata(f *rdataFields, recordType string) error {
+	if err := firstRdataError(
+		checkRdataField(f, recordType, "type_covered", checkTypeMnemonic),
+		checkRdataRange(f, recordType, "algorithm", 1, 255),
+		checkRdataRange(f, recordType, "labels", 0, 127),
+		checkRdataRange(f, recordType, "original_ttl", 0, 2147483647),
+		checkRdataField(f, recordType, "expiration", checkSignatureTime),
+		checkRdataField(f, recordType, "inception", checkSignatureTime),
+		checkRdataRange(f, recordType, "keytag", 0, 65535),
+		checkRdataField(f, recordType, "signer", checkDomainNameOrRoot),
+		checkRdataField(f, recordType, "signature", checkBase64),
+	); err != nil {
+		return err
+	}
+	expiration, inception := f.getString("expiration"), f.getString("inception")
+	if expiration == "" || inception == "" {
+		return nil
+	}
+	expires, _ := parseSignatureTime(expiration)
+	incepts, _ := parseSignatureTime(inception)
+	if expires <= incepts {
+		return rdataError("expiration", recordType, "%q: must be after inception %q", expiration, inception)
+	}
+	return nil
+}
+
+// parseSignatureTime parses an RRSIG time, YYYYMMDDHHmmSS or the seconds since 1 January 1970
+func parseSignatureTime(value string) (int64, error) {
+	if signatureTimeRegexp.MatchString(value) {
+		t, err := time.Parse("20060102150405", value)
+		if err != nil {
+			return 0, errors.New("not a valid YYYYMMDDHHmmSS time")
+		}
+		return t.Unix(), nil
+	}
+	seconds, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, errors.New("must be a YYYYMMDDHHmmSS time or a number of seconds")
+	}
+	return int64(seconds), nil
+}
+
+func checkSignatureTime(value string) error {
+	_, err := parseSignatureTime(value)
+	return err
+}
+
+// checkTypeMnemonic checks value is a record type mnemonic, or the TYPEnnn of RFC 3597 section 5
+func checkTypeMnemonic(value string) error {
+	if _, ok := rrTypeMnemonics[strings.ToUpper(value)]; ok {
+		return nil
+	}
+	if match := typeNumberRegexp.FindStringSubmatch(strings.ToUpper(value)); match != nil {
+		if number, _ := strconv.Atoi(match[1]); number <= 65535 {
+			return nil
+		}
+	}
+	return errors.New("not a record type")
+}
+
+func checkTypeBitmaps(value string) error {
+	for _, mnemonic := range strings.Fields(value) {
+		if err := checkTypeMnemonic(mnemonic); err != nil {
+			return fmt.Errorf("type %q is %s", mnemonic, err)
+		}
+	}
+	return nil
+}
+
+// checkSalt checks an NSEC3 salt, - for no salt or up to 255 hexadecimal octets
+func checkSalt(value string) error {
+	if value == "-" {
+		return nil
+	}
+	salt, err := hex.DecodeString(value)
+	if err != nil {
+		return errors.New("must be - or hexadecimal")
+	}
+	if len(salt) > 255 {
+		return errors.New("must not be longer than 255 octets")
+	}
+	return nil
+}
+
+// checkNextHashedOwnerName checks the base32hex SHA-1 hash of RFC 5155 section 3.3
+func checkNextHashedOwnerName(value string) error {
+	hash, err := base32HexNoPadding.DecodeString(strings.ToUpper(value))
+	if err != nil {
+		return errors.New("must be base32hex encoded without padding")
+	}
+	if len(hash) != 20 {
+		return fmt.Errorf("must be a 20 octet SHA-1 hash, not %d octets", len(hash))
+	}
+	return nil
+}
+
+func checkBase64(value string) error {
+	if _, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(value), "")); err != nil {
+		return errors.New("must be base64 encoded")
+	}
+	return nil
+}
+
+// checkDigest returns a check of hexadecimal digests of size octets, of any size when size is zero
+func checkDigest(size int) func(string) error {
+	return func(value string) error {
+		digest, err := hex.DecodeString(strings.Join(strings.Fields(value), ""))
+		if err != nil {
+			return errors.New("must be hexadecimal")
+		}
+		if size > 0 && len(digest) != size {
+			return fmt.Errorf("must be %d hexadecimal digits", 2*size)
+		}
+		return nil
+	}
+}
+
+func checkCertType(value string) error {
+	if _, ok := certTypes[value]; ok {
+		return nil
+	}
+	mnemonics := make([]string, 0, len(certTypes))
+	for mnemonic := range certTypes {
+		mnemonics = append(mnemonics, mnemonic)
+	}
+	sort.Strings(mnemonics)
+	return fmt.Errorf("must be one of %s", strings.Join(mnemonics, ", "))
+}
+
+// checkSvcParams checks the service parameters of RFC 9460 section 7
+func checkSvcParams(value string) error {
+	params := make(map[string]string)
+	for _, param := range strings.Fields(value) {
+		key, paramValue := param, ""
+		hasValue := false
+		if i := strings.Index(param, "="); i >= 0 {
+			key, paramValue, hasValue = param[:i], strings.Trim(param[i+1:], `"`), true
+		}
+		if _, ok := params[key]; ok {
+			return fmt.Errorf("parameter %s is repeated", key)
+		}
+		params[key] = paramValue
+		if err := checkSvcParam(key, paramValue, hasValue); err != nil {
+			return fmt.Errorf("parameter %s: %s", key, err)
+		}
+	}
+	if mandatory, ok := params["mandatory"]; ok {
+		for _, key := range strings.Split(mandatory, ",") {
+			if _, ok := params[key]; !ok {
+				return fmt.Errorf("mandatory parameter %s is missing", key)
+			}
+		}
+	}
+	if _, ok := params["no-default-alpn"]; ok {
+		if _, ok := params["alpn"]; !ok {
+			return errors.New("parameter no-default-alpn requires alpn")
+		}
+	}
+	return nil
+}
+
+func checkSvcParam(key, value string, hasValue bool) error {
+	switch key {
+	case "mandatory":
+		seen := make(map[string]struct{})
+		for _, mandatory := range strings.Split(value, ",") {
+			if mandatory == "" || mandatory == "mandatory" {
+				return fmt.Errorf("%q can't be mandatory", mandatory)
+			}
+			if _, ok := seen[mandatory]; ok {
+				return fmt.Errorf("%s is repeated", mandatory)
+			}
+			seen[mandatory] = struct{}{}
+		}
+	case "alpn":
+		for _, protocol := range strings.Split(value, ",") {
+			if protocol == "" || len(protocol) > 255 {
+				return errors.New("protocol identifiers must be 1 to 255 characters")
+			}
+		}
+	case "no-default-alpn":
+		if hasValue {
+			return errors.New("must not have a value")
+		}
+	case "port":
+		if port, err := strconv.Atoi(value); err != nil || port < 0 || port > 65535 {
+			return fmt.Errorf("%q must be between 0 and 65535", value)
+		}
+	case "ipv4hint":
+		for _, address := range strings.Split(value, ",") {
+			if err := checkIPv4Address(address); err != nil {
+				return fmt.Errorf("%q is %s", address, err)
+			}
+		}
+	case "ipv6hint":
+		for _, address := range strings.Split(value, ",") {
+			if err := checkIPv6Address(address); err != nil {
+				return fmt.Errorf("%q is %s", address, err)
+			}
+		}
+	case "ech":
+		if value == "" {
+			return errors.New("must be base64 encoded")
+		}
+		return checkBase64(value)
+	default:
+		match := svcKeyNumberRegexp.FindStringSubmatch(key)
+		if match == nil {
+			return errors.New("unknown parameter, use keyNNNNN for parameters without a name")
+		}
+		if number, _ := strconv.Atoi(match[1]); number > 65534 {
+			return errors.New("key numbers must be between 0 and 65534")
+		}
+	}
+	return nil
+}
+
+// normalizeRecordTarget returns the canonical presentation of a target of akamai_dns_record, so that equivalent
+// targets compare equal
+func normalizeRecordTarget(recordType, value string) string {
+	value = strings.TrimSpace(value)
+	switch recordType {
+	case RRTypeA:
+		if ip := net.ParseIP(value); ip != nil {
+			return ip.String()
+		}
+	case RRTypeAaaa:
+		if ip := net.ParseIP(value); ip != nil {
+			return FullIPv6(ip)
+		}
+	case RRTypeAfsdb, RRTypeAkamaiCdn, RRTypeCname, RRTypeNs, RRTypePtr, RRTypeSrv:
+		return normalizeRecordsetName(value)
+	case RRTypeMx:
+		fields := strings.Fields(value)
+		if len(fields) == 2 {
+			if priority, err := strconv.Atoi(fields[0]); err == nil {
+				return strconv.Itoa(priority) + " " + normalizeRecordsetName(fields[1])
+			}
+		}
+		return normalizeRecordsetName(value)
+	case RRTypeCaa:
+		parts := strings.SplitN(value, " ", 3)
+		if len(parts) == 3 {
+			if flags, err := strconv.Atoi(parts[0]); err == nil {
+				return strconv.Itoa(flags) + " " + strings.ToLower(parts[1]) + " " + strings.ReplaceAll(parts[2], `"`, "")
+			}
+		}
+		return strings.ReplaceAll(value, `"`, "")
+	}
+	return unquoteRdata(value)
+}
+
+// unquoteRdata returns value without the enclosing quotes, escaped quotes unescaped
+func unquoteRdata(value string) string {
+	return strings.ReplaceAll(strings.Trim(value, `\"`), `\"`, `"`)
+}
@@ -0,0 +1,322 @@
+package dns
+
+import (
+	"testing"
+
+	"github.com/apex/log"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRecordRdata(t *testing.T) {
+	signature := "eHh4eHh4eHh4eHh4eHh4eHh4eHh4eHh4eHh4eHh4eHh4eHh4eHh4eA=="
+	sha1Digest := "2BB183AF5F22588179A53B0A98631FAD1A292118"
+	sha256Digest := "D4B7D520E7BB5F0F67674A0CCEB1E3E0614B93C4F9E99B8383F6A1E4469DA50A"
+
+	tests := map[string]struct {
+		recordType   string
+		resourceMap  map[string]interface{}
+		errorMessage string
+	}{
+		"A": {
+			recordType:  RRTypeA,
+			resourceMap: map[string]interface{}{"target": []interface{}{"192.0.2.1", "192.0.2.2"}},
+		},
+		"A not an IPv4 address": {
+			recordType:   RRTypeA,
+			resourceMap:  map[string]interface{}{"target": []interface{}{"192.0.2.1", "192.0.2"}},
+			errorMessage: `configuration argument target.1 is invalid for A: "192.0.2": not an IPv4 address`,
+		},
+		"AAAA": {
+			recordType:  RRTypeAaaa,
+			resourceMap: map[string]interface{}{"target": []interface{}{"2001:db8::1"}},
+		},
+		"AAAA not an IPv6 address": {
+			recordType:   RRTypeAaaa,
+			resourceMap:  map[string]interface{}{"target": []interface{}{"192.0.2.1"}},
+			errorMessage: `configuration argument target.0 is invalid for AAAA: "192.0.2.1": not an IPv6 address`,
+		},
+		"CNAME": {
+			recordType:  RRTypeCname,
+			resourceMap: map[string]interface{}{"target": []interface{}{"origin.example.org.edgesuite.net."}},
+		},
+		"CNAME invalid label": {
+			recordType:   RRTypeCname,
+			resourceMap:  map[string]interface{}{"target": []interface{}{"-origin.example.org"}},
+			errorMessage: `label "-origin" must only contain letters, digits, hyphens and underscores`,
+		},
+		"LOC": {
+			recordType:  RRTypeLoc,
+			resourceMap: map[string]interface{}{"target": []interface{}{"52 22 23.000 N 4 53 32.000 E -2.00m 0.00m 10000.00m 10.00m", "42 21 43.528 N 71 05 06.284 W 12m"}},
+		},
+		"LOC latitude out of range": {
+			recordType:   RRTypeLoc,
+			resourceMap:  map[string]interface{}{"target": []interface{}{"91 0 0 N 4 53 32.000 E -2.00m"}},
+			errorMessage: `latitude degrees "91" must be between 0 and 90`,
+		},
+		"LOC missing hemisphere": {
+			recordType:   RRTypeLoc,
+			resourceMap:  map[string]interface{}{"target": []interface{}{"52 22 23.000 N 4 53 32.000 -2.00m"}},
+			errorMessage: "longitude must be followed by E or W",
+		},
+		"TXT": {
+			recordType:  RRTypeTxt,
+			resourceMap: map[string]interface{}{"target": []interface{}{"v=spf1 -all", `"first string" "second \"string\""`}},
+		},
+		"TXT text outside of quotes": {
+			recordType:   RRTypeTxt,
+			resourceMap:  map[string]interface{}{"target": []interface{}{`"first string" second "third"`}},
+			errorMessage: `text "second \"third\"" is outside of the quoted strings`,
+		},
+		"CAA": {
+			recordType: RRTypeCaa,
+			resourceMap: map[string]interface{}{"target": []interface{}{
+				`0 issue "ca.example.net; account=230123"`, "0 issuewild ;", "0 iodef mailto:security@example.com", `128 tbs "Unknown"`,
+			}},
+		},
+		"CAA invalid tag": {
+			recordType:   RRTypeCaa,
+			resourceMap:  map[string]interface{}{"target": []interface{}{"0 is-sue ca.example.net"}},
+			errorMessage: `tag "is-sue" must be 1 to 15 letters or digits`,
+		},
+		"CAA invalid issuer": {
+			recordType:   RRTypeCaa,
+			resourceMap:  map[string]interface{}{"target": []interface{}{`0 issue "ca..example.net"`}},
+			errorMessage: `issuer "ca..example.net": domain name has an empty label`,
+		},
+		"CAA invalid iodef": {
+			recordType:   RRTypeCaa,
+			resourceMap:  map[string]interface{}{"target": []interface{}{"0 iodef ftp://example.com"}},
+			errorMessage: `iodef "ftp://example.com" must be a mailto:, http: or https: URL`,
+		},
+		"MX": {
+			recordType:  RRTypeMx,
+			resourceMap: map[string]interface{}{"target": []interface{}{"10 mail.example.com.", "backup.example.com"}, "priority": 20},
+		},
+		"MX priority out of range": {
+			recordType:   RRTypeMx,
+			resourceMap:  map[string]interface{}{"target": []interface{}{"70000 mail.example.com."}},
+			errorMessage: `priority "70000" must be between 0 and 65535`,
+		},
+		"SRV": {
+			recordType:  RRTypeSrv,
+			resourceMap: map[string]interface{}{"target": []interface{}{"sip.example.com"}, "priority": 10, "weight": 5, "port": 5060},
+		},
+		"SRV port out of range": {
+			recordType:   RRTypeSrv,
+			resourceMap:  map[string]interface{}{"target": []interface{}{"sip.example.com"}, "priority": 10, "weight": 5, "port": 70000},
+			errorMessage: "configuration argument port is invalid for SRV: 70000 must be between 0 and 65535",
+		},
+		"DNSKEY": {
+			recordType:  RRTypeDnskey,
+			resourceMap: map[string]interface{}{"flags": 257, "protocol": 3, "algorithm": 10, "key": publicKey},
+		},
+		"DNSKEY invalid protocol": {
+			recordType:   RRTypeDnskey,
+			resourceMap:  map[string]interface{}{"flags": 257, "protocol": 2, "algorithm": 8, "key": publicKey},
+			errorMessage: "configuration argument protocol is invalid for DNSKEY: 2 must be 3",
+		},
+		"DNSKEY key not base64": {
+			recordType:   RRTypeDnskey,
+			resourceMap:  map[string]interface{}{"flags": 256, "protocol": 3, "algorithm": 8, "key": "not a key!"},
+			errorMessage: `configuration argument key is invalid for DNSKEY: "not a key!": must be base64 encoded`,
+		},
+		"DS": {
+			recordType:  RRTypeDs,
+			resourceMap: map[string]interface{}{"keytag": 60485, "algorithm": 5, "digest_type": 1, "digest": sha1Digest},
+		},
+		"DS digest size": {
+			recordType:   RRTypeDs,
+			resourceMap:  map[string]interface{}{"keytag": 60485, "algorithm": 5, "digest_type": 2, "digest": sha1Digest},
+			errorMessage: "must be 64 hexadecimal digits",
+		},
+		"HINFO": {
+			recordType:  RRTypeHinfo,
+			resourceMap: map[string]interface{}{"hardware": `"INTEL 386"`, "software": "LINUX"},
+		},
+		"HINFO unquoted spaces": {
+			recordType:   RRTypeHinfo,
+			resourceMap:  map[string]interface{}{"hardware": "INTEL 386", "software": "LINUX"},
+			errorMessage: `configuration argument hardware is invalid for HINFO: "INTEL 386": must be quoted to contain spaces`,
+		},
+		"NAPTR": {
+			recordType: RRTypeNaptr,
+			resourceMap: map[string]interface{}{
+				"order": 100, "preference": 10, "flagsnaptr": "U", "service": "E2U+sip", "regexp": `!^(.*)$!sip:\1@example.com!`, "replacement": ".",
+			},
+		},
+		"NAPTR back reference": {
+			recordType: RRTypeNaptr,
+			resourceMap: map[string]interface{}{
+				"order": 100, "preference": 10, "flagsnaptr": "U", "service": "E2U+sip", "regexp": `!^.*$!sip:\1@example.com!`, "replacement": ".",
+			},
+			errorMessage: `back reference \1 has no matching subexpression`,
+		},
+		"NAPTR regexp and replacement": {
+			recordType: RRTypeNaptr,
+			resourceMap: map[string]interface{}{
+				"order": 100, "preference": 10, "flagsnaptr": "S", "service": "SIP+D2U", "regexp": "!^.*$!sip:info@example.com!", "replacement": "_sip._udp.example.com.",
+			},
+			errorMessage: `configuration argument replacement is invalid for NAPTR: "_sip._udp.example.com.": must be . when regexp is set`,
+		},
+		"NSEC3": {
+			recordType: RRTypeNsec3,
+			resourceMap: map[string]interface{}{
+				"flags": 1, "algorithm": 1, "iterations": 12, "salt": "aabbccdd", "next_hashed_owner_name": "2vptu5timamqttgl4luu9kg21e0aor3s", "type_bitmaps": "A RRSIG",
+			},
+		},
+		"NSEC3 unknown type": {
+			recordType: RRTypeNsec3,
+			resourceMap: map[string]interface{}{
+				"flags": 1, "algorithm": 1, "iterations": 12, "salt": "aabbccdd", "next_hashed_owner_name": "2vptu5timamqttgl4luu9kg21e0aor3s", "type_bitmaps": "A FOO",
+			},
+			errorMessage: `type "FOO" is not a record type`,
+		},
+		"NSEC3PARAM salt not hexadecimal": {
+			recordType:   RRTypeNsec3Param,
+			resourceMap:  map[string]interface{}{"algorithm": 1, "iterations": 12, "salt": "salty"},
+			errorMessage: `configuration argument salt is invalid for NSEC3PARAM: "salty": must be - or hexadecimal`,
+		},
+		"RRSIG": {
+			recordType: RRTypeRrsig,
+			resourceMap: map[string]interface{}{
+				"type_covered": "A", "algorithm": 5, "labels": 3, "original_ttl": 86400, "expiration": "20030322173103",
+				"inception": "20030220173103", "keytag": 2642, "signer": "example.com.", "signature": signature,
+			},
+		},
+		"RRSIG expired before inception": {
+			recordType: RRTypeRrsig,
+			resourceMap: map[string]interface{}{
+				"type_covered": "A", "algorithm": 5, "labels": 3, "original_ttl": 86400, "expiration": "20030220173103",
+				"inception": "20030322173103", "keytag": 2642, "signer": "example.com.", "signature": signature,
+			},
+			errorMessage: `configuration argument expiration is invalid for RRSIG: "20030220173103": must be after inception "20030322173103"`,
+		},
+		"SSHFP fingerprint size": {
+			recordType:   RRTypeSshfp,
+			resourceMap:  map[string]interface{}{"algorithm": 4, "fingerprint_type": 2, "fingerprint": sha1Digest},
+			errorMessage: "must be 64 hexadecimal digits",
+		},
+		"SOA": {
+			recordType: RRTypeSoa,
+			resourceMap: map[string]interface{}{
+				"name_server": "ns1.example.net.", "email_address": "hostmaster.example.com.", "refresh": 3600, "retry": 600, "expiry": 604800, "nxdomain_ttl": 300,
+			},
+		},
+		"CERT": {
+			recordType:  RRTypeCert,
+			resourceMap: map[string]interface{}{"type_mnemonic": "PGP", "keytag": 1, "algorithm": 8, "certificate": signature},
+		},
+		"CERT unknown type": {
+			recordType:   RRTypeCert,
+			resourceMap:  map[string]interface{}{"type_mnemonic": "X509", "keytag": 1, "algorithm": 8, "certificate": signature},
+			errorMessage: `configuration argument type_mnemonic is invalid for CERT: "X509": must be one of ACPKIX, IACPKIX, IPGP`,
+		},
+		"TLSA": {
+			recordType:  RRTypeTlsa,
+			resourceMap: map[string]interface{}{"usage": 3, "selector": 1, "match_type": 1, "certificate": sha256Digest},
+		},
+		"TLSA usage out of range": {
+			recordType:   RRTypeTlsa,
+			resourceMap:  map[string]interface{}{"usage": 4, "selector": 1, "match_type": 1, "certificate": sha256Digest},
+			errorMessage: "configuration argument usage is invalid for TLSA: 4 must be between 0 and 3",
+		},
+		"HTTPS": {
+			recordType:  RRTypeHTTPS,
+			resourceMap: map[string]interface{}{"svc_priority": 1, "target_name": ".", "svc_params": `alpn="h2,h3" ipv4hint=192.0.2.1 mandatory=alpn,port port=443 key667=hello`},
+		},
+		"SVCB no-default-alpn without alpn": {
+			recordType:   RRTypeSvcb,
+			resourceMap:  map[string]interface{}{"svc_priority": 1, "target_name": "svc.example.com", "svc_params": "no-default-alpn port=443"},
+			errorMessage: "parameter no-default-alpn requires alpn",
+		},
+		"SVCB missing mandatory parameter": {
+			recordType:   RRTypeSvcb,
+			resourceMap:  map[string]interface{}{"svc_priority": 1, "target_name": "svc.example.com", "svc_params": "mandatory=port alpn=h2"},
+			errorMessage: "mandatory parameter port is missing",
+		},
+		"SVCB unknown parameter": {
+			recordType:   RRTypeSvcb,
+			resourceMap:  map[string]interface{}{"svc_priority": 1, "target_name": "svc.example.com", "svc_params": "foo=bar"},
+			errorMessage: "parameter foo: unknown parameter",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resourceMap := map[string]interface{}{"zone": "example.com", "name": "www.example.com", "recordtype": test.recordType, "ttl": 300}
+			for key, value := range test.resourceMap {
+				resourceMap[key] = value
+			}
+			d := schema.TestResourceDataRaw(t, resourceDNSv2Record().Schema, resourceMap)
+			err := validateRecord(d)
+			if test.errorMessage != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.errorMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDiffQuotedDNSRecord(t *testing.T) {
+	tests := map[string]struct {
+		recordType string
+		oldList    []string
+		newList    []string
+		suppressed bool
+	}{
+		"AAAA short and long notation": {
+			recordType: RRTypeAaaa,
+			oldList:    []string{"2001:0db8:0000:0000:0000:0000:0000:0001"},
+			newList:    []string{"2001:db8::1"},
+			suppressed: true,
+		},
+		"AAAA invalid address": {
+			recordType: RRTypeAaaa,
+			oldList:    []string{"2001:db8::zz"},
+			newList:    []string{" 2001:db8::zz"},
+			suppressed: true,
+		},
+		"AAAA invalid address changed": {
+			recordType: RRTypeAaaa,
+			oldList:    []string{"2001:db8::zz"},
+			newList:    []string{"2001:db8::1"},
+		},
+		"CNAME trailing dot and case": {
+			recordType: RRTypeCname,
+			oldList:    []string{"origin.example.org.edgesuite.net."},
+			newList:    []string{"Origin.example.org.edgesuite.net"},
+			suppressed: true,
+		},
+		"CAA quoted value and tag case": {
+			recordType: RRTypeCaa,
+			oldList:    []string{`0 issue "ca.example.net"`},
+			newList:    []string{"0 ISSUE ca.example.net"},
+			suppressed: true,
+		},
+		"TXT escaped quotes": {
+			recordType: RRTypeTxt,
+			oldList:    []string{`"say \"hello\""`},
+			newList:    []string{`say "hello"`},
+			suppressed: true,
+		},
+		"TXT changed": {
+			recordType: RRTypeTxt,
+			oldList:    []string{`"v=spf1 -all"`},
+			newList:    []string{"v=spf1 ~all"},
+		},
+		"MX reordered": {
+			recordType: RRTypeMx,
+			oldList:    []string{"10 mail.example.com.", "20 backup.example.com."},
+			newList:    []string{"20 backup.example.com", "10 mail.example.com"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			suppressed := diffQuotedDNSRecord(test.oldList, test.newList, test.oldList[0], test.newList[0], test.recordType, log.Log)
+			assert.Equal(t, test.suppressed, suppressed)
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceDNSChangeListSubmit() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDNSChangeListSubmitCreate,
+		ReadContext:   resourceDNSChangeListSubmitRead,
+		UpdateContext: resourceDNSChangeListSubmitUpdate,
+		DeleteContext: resourceDNSChangeListSubmitDelete,
+		CustomizeDiff: customdiff.All(
+			akamai.EnforceChangeFreeze(""),
+			changeListSubmitCustomizeDiff,
+		),
+		Schema: map[string]*schema.Schema{
+			akamai.IgnoreChangeFreezeKey: akamai.IgnoreChangeFreezeSchema(),
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values, the change list is submitted when they change",
+			},
+			"changes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Records removed from and added to the zone by the last submission",
+			},
+		},
+	}
+}
+
+func resourceDNSChangeListSubmitCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("AkamaiDNS", "resourceDNSChangeListSubmitCreate")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	zone := d.Get("zone").(string)
+	logger.WithField("zone", zone).Info("Change List Submit Create")
+	if diags := submitDNSChangeList(ctx, meta, d, zone); diags.HasError() {
+		return diags
+	}
+	d.SetId(zone)
+	return resourceDNSChangeListSubmitRead(ctx, d, meta)
+}
+
+func resourceDNSChangeListSubmitRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("AkamaiDNS", "resourceDNSChangeListSubmitRead")
+
+	logger.WithField("zone", d.Get("zone").(string)).Info("Change List Submit Read")
+	// submissions are not kept by Edge DNS, the last submitted changes are kept in the state
+	if _, ok := d.GetOk("changes"); !ok {
+		if err := d.Set("changes", []string{}); err != nil {
+			return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+		}
+	}
+	return nil
+}
+
+func resourceDNSChangeListSubmitUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("AkamaiDNS", "resourceDNSChangeListSubmitUpdate")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	zone := d.Get("zone").(string)
+	logger.WithField("zone", zone).Info("Change List Submit Update")
+	// an update is planned when the triggers change or when changes are staged, submitting is a no-op otherwise
+	if diags := submitDNSChangeList(ctx, meta, d, zone); diags.HasError() {
+		return diags
+	}
+	return resourceDNSChangeListSubmitRead(ctx, d, meta)
+}
+
+// changeListSubmitCustomizeDiff plans a submission when the triggers change, or when the change list of the zone holds
+// changes which were staged without being submitted, such as changes not referenced by the triggers
+func changeListSubmitCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	if d.HasChange("triggers") {
+		return d.SetNewComputed("changes")
+	}
+	meta := akamai.Meta(m)
+	logger := meta.Log("AkamaiDNS", "changeListSubmitCustomizeDiff")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	zone := d.Get("zone").(string)
+	changeList, err := getZoneChangeList(ctx, meta, zone)
+	if err != nil || changeList == nil || changeList.Stale {
+		// a missing or stale change list fails the submission, or is reopened by the first staged change
+		return err
+	}
+	changes, err := stagedZoneChanges(ctx, meta, zone)
+	if err != nil {
+		return err
+	}
+	if len(changes) > 0 {
+		logger.Debugf("Change list of zone %s holds unsubmitted changes:\n%s", zone, strings.Join(changes, "\n"))
+		return d.SetNewComputed("changes")
+	}
+	return nil
+}
+
+// Delete doesn't discard the change list, record changes of the zone are still staged as long as it is in change
+// list mode
+func resourceDNSChangeListSubmitDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("AkamaiDNS", "resourceDNSChangeListSubmitDelete")
+
+	logger.WithField("zone", d.Get("zone").(string)).Info("Change List Submit Delete")
+	d.SetId("")
+	return nil
+}
+
+func submitDNSChangeList(ctx context.Context, meta akamai.OperationMeta, d *schema.ResourceData, zone string) diag.Diagnostics {
+	logger := meta.Log("AkamaiDNS", "submitDNSChangeList")
+
+	changes, err := submitZoneChangeList(ctx, meta, zone, logger)
+	if err != nil {
+		summary := "Change list submit failure"
+		if errors.Is(err, ErrChangeListConflict) {
+			summary = "Change list conflict"
+		}
+		return append(diag.Diagnostics{}, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   err.Error(),
+		})
+	}
+	if err := d.Set("changes", changes); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+	return nil
+}
@@ -0,0 +1,251 @@
+package dns
+
+import (
+	"regexp"
+	"testing"
+
+	dns "github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/configdns"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestResDnsChangeListSubmit(t *testing.T) {
+	zone := "exampleterraform.io"
+
+	// mockChangeList stages the appended changes in the change list of the mocked zone, and makes them live on submit
+	mockChangeList := func(client *mockdns, changeLists *mockchangelists, changeList *dns.ChangeListResponse, live, staged *dns.RecordSetResponse) {
+		client.On("GetChangeList",
+			mock.Anything, // ctx is irrelevant for this test
+			zone,
+		).Return(changeList, nil)
+
+		client.On("GetRecordsets",
+			mock.Anything, // ctx is irrelevant for this test
+			zone,
+			[]dns.RecordsetQueryArgs{{ShowAll: true}},
+		).Return(live, nil)
+
+		reopen := func(mock.Arguments) {
+			staged.Recordsets = append([]dns.Recordset{}, live.Recordsets...)
+			changeList.Stale = false
+		}
+		changeLists.On("CreateChangeList",
+			mock.Anything, // ctx is irrelevant for this test
+			CreateChangeListRequest{Zone: zone, Overwrite: ChangeListOverwriteAny},
+		).Return(nil).Run(reopen).Maybe()
+		changeLists.On("CreateChangeList",
+			mock.Anything, // ctx is irrelevant for this test
+			CreateChangeListRequest{Zone: zone},
+		).Return(nil).Run(reopen)
+
+		changeLists.On("GetChangeListRecordsets",
+			mock.Anything, // ctx is irrelevant for this test
+			zone,
+		).Return(staged, nil)
+
+		changeLists.On("AppendChangeListChange",
+			mock.Anything, // ctx is irrelevant for this test
+			mock.AnythingOfType("AppendChangeListChangeRequest"),
+		).Return(nil).Run(func(args mock.Arguments) {
+			change := args.Get(1).(AppendChangeListChangeRequest).Change
+			recordsets := staged.Recordsets[:0]
+			for _, rs := range staged.Recordsets {
+				if rs.Name != change.Name || rs.Type != change.Type {
+					recordsets = append(recordsets, rs)
+				}
+			}
+			if change.Op != RecordsetChangeDelete {
+				recordsets = append(recordsets, dns.Recordset{Name: change.Name, Type: change.Type, TTL: change.TTL, Rdata: change.Rdata})
+			}
+			staged.Recordsets = recordsets
+		})
+
+		client.On("SubmitChangelist",
+			mock.Anything, // ctx is irrelevant for this test
+			&dns.ZoneCreate{Zone: zone},
+		).Return(nil).Run(func(mock.Arguments) {
+			live.Recordsets = append([]dns.Recordset{}, staged.Recordsets...)
+		}).Maybe()
+	}
+
+	zoneRecordsets := func() *dns.RecordSetResponse {
+		return &dns.RecordSetResponse{Recordsets: []dns.Recordset{
+			{Name: zone, Type: "SOA", TTL: 86400, Rdata: []string{"a1-1.akam.net. hostmaster.exampleterraform.io. 2021010101 14400 7200 604800 1200"}},
+			{Name: zone, Type: "NS", TTL: 86400, Rdata: []string{"a1-1.akam.net.", "a2-2.akam.net."}},
+		}}
+	}
+
+	resetChangeListZones := func() {
+		changeListZones.Lock()
+		changeListZones.opened = make(map[string]bool)
+		changeListZones.Unlock()
+	}
+
+	t.Run("staged changes submitted", func(t *testing.T) {
+		resetChangeListZones()
+		client := &mockdns{}
+		changeLists := &mockchangelists{}
+		live, staged := zoneRecordsets(), zoneRecordsets()
+		mockChangeList(client, changeLists, &dns.ChangeListResponse{Zone: zone}, live, staged)
+
+		useClient(client, func() {
+			useChangeListClient(changeLists, func() {
+				resource.UnitTest(t, resource.TestCase{
+					PreCheck:  func() { testAccPreCheck(t) },
+					Providers: testAccProviders,
+					Steps: []resource.TestStep{
+						{
+							Config: loadFixtureString("testdata/TestResDnsChangeListSubmit/submit.tf"),
+							Check: resource.ComposeTestCheckFunc(
+								resource.TestCheckResourceAttr("akamai_dns_changelist_submit.test", "id", zone),
+								resource.TestCheckResourceAttr("akamai_dns_changelist_submit.test", "changes.#", "3"),
+								resource.TestCheckResourceAttr("akamai_dns_changelist_submit.test", "changes.0", "+ ftp.exampleterraform.io. 300 IN CNAME www.exampleterraform.io."),
+								resource.TestCheckResourceAttr("akamai_dns_recordsets.test", "recordset.#", "2"),
+							),
+						},
+					},
+				})
+			})
+		})
+
+		// the zone records were only changed by the submission
+		client.AssertNumberOfCalls(t, "SubmitChangelist", 1)
+		client.AssertNotCalled(t, "UpdateRecordsets", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		assert.Len(t, live.Recordsets, 4)
+		client.AssertExpectations(t)
+		changeLists.AssertExpectations(t)
+	})
+
+	t.Run("zone changed after staging", func(t *testing.T) {
+		resetChangeListZones()
+		client := &mockdns{}
+		changeLists := &mockchangelists{}
+		changeList := &dns.ChangeListResponse{Zone: zone}
+		live, staged := zoneRecordsets(), zoneRecordsets()
+		// the zone is changed concurrently once the change is staged
+		changeLists.On("AppendChangeListChange",
+			mock.Anything, // ctx is irrelevant for this test
+			mock.AnythingOfType("AppendChangeListChangeRequest"),
+		).Return(nil).Run(func(mock.Arguments) {
+			changeList.Stale = true
+		}).Once()
+		mockChangeList(client, changeLists, changeList, live, staged)
+
+		useClient(client, func() {
+			useChangeListClient(changeLists, func() {
+				resource.UnitTest(t, resource.TestCase{
+					PreCheck:  func() { testAccPreCheck(t) },
+					Providers: testAccProviders,
+					Steps: []resource.TestStep{
+						{
+							Config:      loadFixtureString("testdata/TestResDnsChangeListSubmit/submit.tf"),
+							ExpectError: regexp.MustCompile(`Change list conflict`),
+						},
+					},
+				})
+			})
+		})
+
+		client.AssertNotCalled(t, "SubmitChangelist", mock.Anything, mock.Anything)
+		assert.Len(t, live.Recordsets, 2)
+	})
+
+	t.Run("changes left by an earlier apply planned and submitted", func(t *testing.T) {
+		resetChangeListZones()
+		client := &mockdns{}
+		changeLists := &mockchangelists{}
+		live, staged := zoneRecordsets(), zoneRecordsets()
+		mockChangeList(client, changeLists, &dns.ChangeListResponse{Zone: zone}, live, staged)
+
+		useClient(client, func() {
+			useChangeListClient(changeLists, func() {
+				resource.UnitTest(t, resource.TestCase{
+					PreCheck:  func() { testAccPreCheck(t) },
+					Providers: testAccProviders,
+					Steps: []resource.TestStep{
+						{
+							Config: loadFixtureString("testdata/TestResDnsChangeListSubmit/submit_only.tf"),
+							Check:  resource.TestCheckResourceAttr("akamai_dns_changelist_submit.test", "changes.#", "0"),
+						},
+						{
+							// a change staged without changing the triggers
+							PreConfig: func() {
+								staged.Recordsets = append(staged.Recordsets, dns.Recordset{Name: "www.exampleterraform.io", Type: "A", TTL: 300, Rdata: []string{"192.0.2.1"}})
+							},
+							Config:             loadFixtureString("testdata/TestResDnsChangeListSubmit/submit_only.tf"),
+							PlanOnly:           true,
+							ExpectNonEmptyPlan: true,
+						},
+						{
+							Config: loadFixtureString("testdata/TestResDnsChangeListSubmit/submit_only.tf"),
+							Check: resource.ComposeTestCheckFunc(
+								resource.TestCheckResourceAttr("akamai_dns_changelist_submit.test", "changes.#", "1"),
+								resource.TestCheckResourceAttr("akamai_dns_changelist_submit.test", "changes.0", "+ www.exampleterraform.io. 300 IN A 192.0.2.1"),
+							),
+						},
+					},
+				})
+			})
+		})
+
+		client.AssertNumberOfCalls(t, "SubmitChangelist", 1)
+		assert.Len(t, live.Recordsets, 3)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("staged record read from the change list", func(t *testing.T) {
+		resetChangeListZones()
+		client := &mockdns{}
+		changeLists := &mockchangelists{}
+		live, staged := zoneRecordsets(), zoneRecordsets()
+		mockChangeList(client, changeLists, &dns.ChangeListResponse{Zone: zone}, live, staged)
+		client.On("ParseRData",
+			mock.Anything, // ctx is irrelevant for this test
+			"A",
+			[]string{"192.0.2.1"},
+		).Return(map[string]interface{}{"target": []string{"192.0.2.1"}})
+		client.On("ProcessRdata",
+			mock.Anything, // ctx is irrelevant for this test
+			[]string{"192.0.2.1"},
+			"A",
+		).Return([]string{"192.0.2.1"})
+
+		useClient(client, func() {
+			useChangeListClient(changeLists, func() {
+				resource.UnitTest(t, resource.TestCase{
+					PreCheck:  func() { testAccPreCheck(t) },
+					Providers: testAccProviders,
+					Steps: []resource.TestStep{
+						{
+							// the staged record is refreshed from the change list before it is submitted
+							Config: loadFixtureString("testdata/TestResDnsChangeListSubmit/record.tf"),
+							Check: resource.ComposeTestCheckFunc(
+								resource.TestCheckResourceAttr("akamai_dns_record.test", "target.#", "1"),
+								func(*terraform.State) error {
+									assert.Len(t, live.Recordsets, 2)
+									return nil
+								},
+							),
+						},
+						{
+							Config: loadFixtureString("testdata/TestResDnsChangeListSubmit/record_submit.tf"),
+							Check: resource.ComposeTestCheckFunc(
+								resource.TestCheckResourceAttr("akamai_dns_changelist_submit.test", "changes.#", "1"),
+								resource.TestCheckResourceAttr("akamai_dns_changelist_submit.test", "changes.0", "+ www.exampleterraform.io. 300 IN A 192.0.2.1"),
+							),
+						},
+					},
+				})
+			})
+		})
+
+		// the record is only changed in the zone by the submission
+		client.AssertNumberOfCalls(t, "SubmitChangelist", 1)
+		client.AssertNotCalled(t, "CreateRecord", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		client.AssertNotCalled(t, "GetRecord", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		assert.Len(t, live.Recordsets, 3)
+		assert.Len(t, staged.Recordsets, 2)
+	})
+}
@@ -8,7 +8,6 @@ import (
 	"fmt"
 	"net"
 	"net/http"
-	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -22,6 +21,7 @@ import (
 	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
 	"github.com/apex/log"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -38,13 +38,24 @@ func resourceDNSv2Record() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceDNSRecordImport,
 		},
+		CustomizeDiff: customdiff.All(
+			akamai.EnforceChangeFreeze(""),
+			recordCustomizeDiff,
+		),
 		Schema: map[string]*schema.Schema{
+			akamai.IgnoreChangeFreezeKey: akamai.IgnoreChangeFreezeSchema(),
 			"zone": {
 				Type:             schema.TypeString,
 				Required:         true,
 				ForceNew:         true,
 				ValidateDiagFunc: validation.ToDiagFunc(validation.NoZeroValues),
 			},
+			"change_list_mode": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Stage the record changes in the change list of the zone, which must have change_list_mode enabled",
+			},
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -360,22 +371,12 @@ var certTypes = map[string]int{
 
 // Suppress check for fields that have dot suffix in tfstate
 func dnsRecordFieldDotSuffixSuppress(_, old, new string, _ *schema.ResourceData) bool {
-	oldValStr := strings.TrimRight(old, ".")
-	newValStr := strings.TrimRight(new, ".")
-	if oldValStr == newValStr {
-		return true
-	}
-	return false
+	return normalizeRecordsetName(old) == normalizeRecordsetName(new)
 }
 
 // Suppress check for fields that are quoted in tfstate
 func dnsRecordFieldTrimQuoteSuppress(_, old, new string, _ *schema.ResourceData) bool {
-	oldValStr := strings.Trim(old, "\\\"")
-	newValStr := strings.Trim(new, "\"")
-	if oldValStr == newValStr {
-		return true
-	}
-	return false
+	return unquoteRdata(old) == unquoteRdata(new)
 }
 
 // Suppress check for type_value. Mnemonic config comes back as numeric
@@ -454,10 +455,6 @@ func dnsRecordTargetSuppress(_, old, new string, d *schema.ResourceData) bool {
 }
 
 func diffQuotedDNSRecord(oldTargetList []string, newTargetList []string, old string, new string, recordType string, logger log.Interface) bool {
-	const (
-		singleQuote    = `"`
-		backslashQuote = `\"`
-	)
 	if len(oldTargetList) != len(newTargetList) {
 		return false
 	}
@@ -468,119 +465,63 @@ func diffQuotedDNSRecord(oldTargetList []string, newTargetList []string, old str
 	logger.Debugf("diffQuotedDNSRecord Suppress. old: [%v]", old)
 	logger.Debugf("diffQuotedDNSRecord Suppress. new: [%v]", new)
 
-	var compList []string
-	var baseVal string
-	var compTrim bool
-	if old == "" {
-		baseVal = new
-		compTrim = true
-		baseVal = strings.Trim(baseVal, singleQuote)
-		compList = oldTargetList
-	} else {
-		baseVal = old
-		baseVal = strings.Trim(baseVal, backslashQuote)
-		baseVal = strings.ReplaceAll(baseVal, backslashQuote, singleQuote)
-		compList = newTargetList
-	}
-
-	// for AAAA record type, we want to compare IPv6 values
-	if recordType == RRTypeAaaa {
-		logger.Debugf("AAAA Suppress. baseval: [%v]", baseVal)
-		fullBaseval := FullIPv6(net.ParseIP(baseVal))
-		for _, compval := range compList {
-			logger.Debugf("AAAA Suppress. compval: [%v]", compval)
-			fullCompval := FullIPv6(net.ParseIP(compval))
-			if fullBaseval == fullCompval {
-				return true
-			}
-		}
-		return false
-	}
-
-	if recordType == RRTypeCaa {
-		baseVal = strings.ReplaceAll(baseVal, singleQuote, "")
-		for _, compval := range compList {
-			compval = strings.ReplaceAll(compval, singleQuote, "")
-			logger.Debugf("updated baseVal: %v", baseVal)
-			logger.Debugf("compval: %v", compval)
-			if baseVal == compval {
-				return true
-			}
-		}
-		return false
-	}
-
+	// MX targets are compared in order, as the priorities of targets without one depend on it
 	if recordType == RRTypeMx {
-
-		// lists are same length
-		for i := 0; i < len(oldTargetList); i++ {
-			oldTargetList[i] = strings.TrimRight(oldTargetList[i], ".")
-			newTargetList[i] = strings.TrimRight(newTargetList[i], ".")
-		}
-		oldTargetString := strings.Join(oldTargetList, " ")
-		newTargetString := strings.Join(newTargetList, " ")
-		return oldTargetString == newTargetString
-	}
-
-	if recordType == RRTypeAfsdb || recordType == RRTypeCname || recordType == RRTypePtr || recordType == RRTypeSrv || recordType == RRTypeNs {
-		baseVal = strings.TrimRight(baseVal, ".")
-		for _, compval := range compList {
-			compval = strings.TrimRight(compval, ".")
-			logger.Debugf("updated baseVal: %v", baseVal)
-			logger.Debugf("compval: %v", compval)
-			if baseVal == compval {
-				return true
+		for i := range oldTargetList {
+			if normalizeRecordTarget(recordType, oldTargetList[i]) != normalizeRecordTarget(recordType, newTargetList[i]) {
+				return false
 			}
 		}
-		return false
+		return true
 	}
 
+	baseVal, compList := old, newTargetList
+	if old == "" {
+		baseVal, compList = new, oldTargetList
+	}
+	baseVal = normalizeRecordTarget(recordType, baseVal)
 	for _, compval := range compList {
-		if compTrim && strings.Contains(compval, backslashQuote) {
-			compval = strings.ReplaceAll(compval, backslashQuote, singleQuote)
-		}
-		if baseVal == strings.Trim(compval, singleQuote) {
+		logger.Debugf("updated baseVal: %v", baseVal)
+		logger.Debugf("compval: %v", compval)
+		if normalizeRecordTarget(recordType, compval) == baseVal {
 			return true
 		}
 	}
 	return false
 }
 
-// Lock per record type
-var recordCreateLock = map[string]*sync.Mutex{
-	"A":          {},
-	"AAAA":       {},
-	"AFSDB":      {},
-	"AKAMAICDN":  {},
-	"AKAMAITLC":  {},
-	"CAA":        {},
-	"CERT":       {},
-	"CNAME":      {},
-	"HINFO":      {},
-	"LOC":        {},
-	"MX":         {},
-	"NAPTR":      {},
-	"NS":         {},
-	"PTR":        {},
-	"RP":         {},
-	"SOA":        {},
-	"SRV":        {},
-	"SPF":        {},
-	"SSHFP":      {},
-	"TLSA":       {},
-	"TXT":        {},
-	"DNSKEY":     {},
-	"DS":         {},
-	"NSEC3":      {},
-	"NSEC3PARAM": {},
-	"RRSIG":      {},
-	"SVCB":       {},
-	"HTTPS":      {},
+var (
+	recordArgumentsOnce sync.Once
+	recordArguments     []string
+)
+
+// recordArgumentKeys returns the keys of the configurable arguments of akamai_dns_record, the schema is only built
+// on the first call
+func recordArgumentKeys() []string {
+	recordArgumentsOnce.Do(func() {
+		for key, field := range resourceDNSv2Record().Schema {
+			if field.Optional || field.Required {
+				recordArguments = append(recordArguments, key)
+			}
+		}
+	})
+	return recordArguments
 }
 
-// Retrieves record lock per record type
-func getRecordLock(recordType string) *sync.Mutex {
-	return recordCreateLock[recordType]
+// recordCustomizeDiff validates the record data offline, so that malformed records fail at plan time rather than
+// at apply time. Records with values known only at apply time are validated by Create and Update.
+func recordCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	changed := d.Id() == ""
+	for _, key := range recordArgumentKeys() {
+		if !d.NewValueKnown(key) {
+			return nil
+		}
+		changed = changed || d.HasChange(key)
+	}
+	if !changed {
+		return nil
+	}
+	return validateRecord(d)
 }
 
 func bumpSoaSerial(ctx context.Context, d *schema.ResourceData, meta akamai.OperationMeta, zone, host string, logger log.Interface) (*dns.RecordBody, error) {
@@ -595,7 +536,7 @@ func bumpSoaSerial(ctx context.Context, d *schema.ResourceData, meta akamai.Oper
 	if !ok {
 		return nil, fmt.Errorf("%w: %s, %q", tools.ErrInvalidType, "seral", "string")
 	}
-	if err := d.Set("serial", serial+1); err != nil {
+	if err := d.Set("serial", int(zoneSerials.next(zone, uint32(serial)))); err != nil {
 		return nil, fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error())
 	}
 	newRecord, err := bindRecord(ctx, meta, d, logger)
@@ -605,6 +546,20 @@ func bumpSoaSerial(ctx context.Context, d *schema.ResourceData, meta akamai.Oper
 	return &newRecord, nil
 }
 
+// setStagedDNSRecord sets the state of a record staged in the change list of the zone, the record is read back once
+// the change list is submitted
+func setStagedDNSRecord(d *schema.ResourceData, zone, host, recordType, sha1hash string) diag.Diagnostics {
+	if err := d.Set("record_sha", sha1hash); err != nil {
+		return diag.Errorf("%v: %s", tools.ErrValueSet, err.Error())
+	}
+	if d.Id() == "" || strings.Contains(d.Id(), "#") {
+		d.SetId(fmt.Sprintf("%s#%s#%s", zone, host, recordType))
+	} else {
+		d.SetId(fmt.Sprintf("%s-%s-%s-%s", zone, host, recordType, sha1hash))
+	}
+	return nil
+}
+
 // Record op function
 func execFunc(ctx context.Context, meta akamai.OperationMeta, fn string, rec *dns.RecordBody, zone string, rlock bool) error {
 
@@ -672,7 +627,7 @@ func executeRecordFunction(ctx context.Context, meta akamai.OperationMeta, name
 
 // Create a new DNS Record
 func resourceDNSRecordCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	// only allow one record per record type to be created at a time
+	// only allow one change per zone owner name at a time
 	// this prevents lost data if you are using a counter/dynamic variables
 	// in your config.tf which might overwrite each other
 
@@ -712,9 +667,8 @@ func resourceDNSRecordCreate(ctx context.Context, d *schema.ResourceData, m inte
 		})
 	}
 
-	// serialize record creates of same type
-	getRecordLock(recordType).Lock()
-	defer getRecordLock(recordType).Unlock()
+	// serialize changes to the records of the same owner name
+	defer recordLocks.lockRecord(zone, host)()
 
 	if recordType == "SOA" {
 		logger.Debug("Attempting to create a SOA record")
@@ -725,7 +679,7 @@ func resourceDNSRecordCreate(ctx context.Context, d *schema.ResourceData, m inte
 			if err != nil && !errors.Is(err, tools.ErrNotFound) {
 				return diag.FromErr(err)
 			}
-			if err := d.Set("serial", serial+1); err != nil {
+			if err := d.Set("serial", int(zoneSerials.next(zone, uint32(serial)))); err != nil {
 				return diag.Errorf("%v: %s", tools.ErrValueSet, err.Error())
 			}
 		} else {
@@ -754,6 +708,15 @@ func resourceDNSRecordCreate(ctx context.Context, d *schema.ResourceData, m inte
 	sha1hash := tools.GetSHAString(extractString)
 
 	logger.Debugf("SHA sum for recordcreate [%s]", sha1hash)
+	if staged, err := stageDNSRecord(ctx, meta, zone, d.Get("change_list_mode").(bool), &recordCreate, false, logger); err != nil {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Recordset stage failure",
+			Detail:   err.Error(),
+		})
+	} else if staged {
+		return setStagedDNSRecord(d, zone, host, recordType, sha1hash)
+	}
 	// First try to get the zone from the API
 	logger.Debugf("Searching for records [%s]", zone)
 	rdata := make([]string, 0)
@@ -882,9 +845,8 @@ func resourceDNSRecordUpdate(ctx context.Context, d *schema.ResourceData, m inte
 		})
 	}
 
-	// serialize record updates of same type
-	getRecordLock(recordType).Lock()
-	defer getRecordLock(recordType).Unlock()
+	// serialize changes to the records of the same owner name
+	defer recordLocks.lockRecord(zone, host)()
 
 	if recordType == "SOA" {
 		// need to get current serial and increment as part of update
@@ -907,7 +869,7 @@ func resourceDNSRecordUpdate(ctx context.Context, d *schema.ResourceData, m inte
 		if !ok {
 			return diag.Errorf("%v: %s, %q", tools.ErrInvalidType, "seral", "string")
 		}
-		if err := d.Set("serial", serial+1); err != nil {
+		if err := d.Set("serial", int(zoneSerials.next(zone, uint32(serial)))); err != nil {
 			return diag.Errorf("%v: %s", tools.ErrValueSet, err.Error())
 		}
 	}
@@ -924,6 +886,15 @@ func resourceDNSRecordUpdate(ctx context.Context, d *schema.ResourceData, m inte
 	sha1hash := tools.GetSHAString(extractString)
 
 	logger.Debugf("UPDATE SHA sum for recordupdate [%s]", sha1hash)
+	if staged, err := stageDNSRecord(ctx, meta, zone, d.Get("change_list_mode").(bool), &recordCreate, false, logger); err != nil {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Recordset stage failure",
+			Detail:   err.Error(),
+		})
+	} else if staged {
+		return setStagedDNSRecord(d, zone, host, recordType, sha1hash)
+	}
 	// First try to get the zone from the API
 	logger.Debugf("UPDATE Searching for records [%s]", zone)
 	rdata := make([]string, 0, 0)
@@ -1045,7 +1016,7 @@ func resourceDNSRecordRead(ctx context.Context, d *schema.ResourceData, m interf
 		"recordtype": recordType,
 	}).Info("READ Searching for zone records")
 
-	record, e := inst.Client(meta).GetRecord(ctx, zone, host, recordType)
+	record, e := getDNSRecord(ctx, meta, zone, d.Get("change_list_mode").(bool), host, recordType)
 	if e != nil {
 		apiError, ok := e.(*dns.Error)
 		if !ok || apiError.StatusCode != http.StatusNotFound {
@@ -1244,6 +1215,9 @@ func resourceDNSRecordImport(d *schema.ResourceData, m interface{}) ([]*schema.R
 	if err := d.Set("zone", zone); err != nil {
 		return nil, fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error())
 	}
+	if err := d.Set("change_list_mode", false); err != nil {
+		return nil, fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error())
+	}
 	if err := d.Set("name", recordset.Name); err != nil {
 		return nil, fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error())
 	}
@@ -1288,6 +1262,9 @@ func resourceDNSRecordImport(d *schema.ResourceData, m interface{}) ([]*schema.R
 }
 
 func resourceDNSRecordDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := akamai.EnforceChangeFreezeOnDelete(d, m, ""); err != nil {
+		return diag.FromErr(err)
+	}
 	meta := akamai.Meta(m)
 	logger := meta.Log("AkamaiDNS", "resourceDNSRecordUpdate")
 	// create a context with logging for api calls
@@ -1314,9 +1291,8 @@ func resourceDNSRecordDelete(ctx context.Context, d *schema.ResourceData, m inte
 	}
 	logger.Infof("Record Delete. zone: %s, host: %s, recordtype: %s", zone, host, recordType)
 	logger.Info("Record Delete.")
-	// serialize record updates of same type
-	getRecordLock(recordType).Lock()
-	defer getRecordLock(recordType).Unlock()
+	// serialize changes to the records of the same owner name
+	defer recordLocks.lockRecord(zone, host)()
 
 	target, err := tools.GetListValue("target", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
@@ -1338,6 +1314,12 @@ func resourceDNSRecordDelete(ctx context.Context, d *schema.ResourceData, m inte
 	recordcreate := dns.RecordBody{Name: host, RecordType: recordType, TTL: ttl, Target: records}
 
 	// Warning: Delete will expunge the ENTIRE Recordset regardless of whether user thought they were removing an instance
+	if staged, err := stageDNSRecord(ctx, meta, zone, d.Get("change_list_mode").(bool), &recordcreate, true, logger); err != nil {
+		return diag.FromErr(err)
+	} else if staged {
+		d.SetId("")
+		return nil
+	}
 
 	if err := executeRecordFunction(ctx, meta, "DELETE", d, "Delete", &recordcreate, zone, host, recordType, logger, false); err != nil {
 		return diag.FromErr(err)
@@ -2055,7 +2037,7 @@ func buildRecordsList(target []interface{}, recordType string, logger log.Interf
 			logger.Debugf("Bind TXT Data OUT: [%s]", recContentStr)
 			records = append(records, recContentStr)
 		case RRTypeCaa:
-			caaparts := strings.Split(recContentStr, " ")
+			caaparts := strings.SplitN(recContentStr, " ", 3)
 			if len(caaparts) < 3 {
 				return nil, fmt.Errorf("CAA record is of invalid format")
 			}
@@ -2074,7 +2056,7 @@ func buildRecordsList(target []interface{}, recordType string, logger log.Interf
 	return records, nil
 }
 
-func validateRecord(d *schema.ResourceData) error {
+func validateRecord(d tools.ResourceDataFetcher) error {
 	recordType, err := tools.GetStringValue("recordtype", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
 		return err
@@ -2082,54 +2064,57 @@ func validateRecord(d *schema.ResourceData) error {
 
 	switch recordType {
 	case RRTypeA, RRTypeAaaa, RRTypeAkamaiCdn, RRTypeCname, RRTypeLoc, RRTypeNs, RRTypePtr, RRTypeSpf, RRTypeTxt:
-		if err := checkBasicRecordTypes(d); err != nil {
-			return err
+		if err = checkBasicRecordTypes(d); err == nil {
+			err = checkTargets(d)
 		}
-		return checkTargets(d)
 	case RRTypeAfsdb:
-		return checkAsdfRecord(d)
+		err = checkAsdfRecord(d)
 	case RRTypeDnskey:
-		return checkDnskeyRecord(d)
+		err = checkDnskeyRecord(d)
 	case RRTypeDs:
-		return checkDsRecord(d)
+		err = checkDsRecord(d)
 	case RRTypeHinfo:
-		return checkHinfoRecord(d)
+		err = checkHinfoRecord(d)
 	case RRTypeMx:
-		return checkMxRecord(d)
+		err = checkMxRecord(d)
 	case RRTypeNaptr:
-		return checkNaptrRecord(d)
+		err = checkNaptrRecord(d)
 	case RRTypeNsec3:
-		return checkNsec3Record(d)
+		err = checkNsec3Record(d)
 	case RRTypeNsec3Param:
-		return checkNsec3ParamRecord(d)
+		err = checkNsec3ParamRecord(d)
 	case RRTypeRp:
-		return checkRpRecord(d)
+		err = checkRpRecord(d)
 	case RRTypeRrsig:
-		return checkRrsigRecord(d)
+		err = checkRrsigRecord(d)
 	case RRTypeSrv:
-		return checkSrvRecord(d)
+		err = checkSrvRecord(d)
 	case RRTypeSshfp:
-		return checkSshfpRecord(d)
+		err = checkSshfpRecord(d)
 	case RRTypeAkamaiTlc:
-		return checkAkamaiTlcRecord(d)
+		err = checkAkamaiTlcRecord(d)
 	case RRTypeSoa:
-		return checkSoaRecord(d)
+		err = checkSoaRecord(d)
 	case RRTypeCaa:
-		return checkCaaRecord(d)
+		err = checkCaaRecord(d)
 	case RRTypeCert:
-		return checkCertRecord(d)
+		err = checkCertRecord(d)
 	case RRTypeTlsa:
-		return checkTlsaRecord(d)
+		err = checkTlsaRecord(d)
 	case RRTypeSvcb:
-		return checkSvcbRecord(d)
+		err = checkSvcbRecord(d)
 	case RRTypeHTTPS:
-		return checkHTTPSRecord(d)
+		err = checkHTTPSRecord(d)
 	default:
 		return fmt.Errorf("invalid recordtype %v", recordType)
 	}
+	if err != nil {
+		return err
+	}
+	return checkRdata(recordType, d)
 }
 
-func checkBasicRecordTypes(d *schema.ResourceData) error {
+func checkBasicRecordTypes(d tools.ResourceDataFetcher) error {
 	_, err := tools.GetStringValue("name", d)
 	if err != nil {
 		if !errors.Is(err, tools.ErrNotFound) {
@@ -2154,7 +2139,7 @@ func checkBasicRecordTypes(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkTargets(d *schema.ResourceData) error {
+func checkTargets(d tools.ResourceDataFetcher) error {
 	target, err := tools.GetListValue("target", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
 		return err
@@ -2171,7 +2156,7 @@ func checkTargets(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkAsdfRecord(d *schema.ResourceData) error {
+func checkAsdfRecord(d tools.ResourceDataFetcher) error {
 	subtype, err := tools.GetIntValue("subtype", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
 		return err
@@ -2183,7 +2168,7 @@ func checkAsdfRecord(d *schema.ResourceData) error {
 	return checkTargets(d)
 }
 
-func checkDnskeyRecord(d *schema.ResourceData) error {
+func checkDnskeyRecord(d tools.ResourceDataFetcher) error {
 	flags, err := tools.GetIntValue("flags", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
 		return err
@@ -2217,9 +2202,8 @@ func checkDnskeyRecord(d *schema.ResourceData) error {
 		return fmt.Errorf("configuration argument protocol must be set for DNSKEY")
 	}
 
-	// FIXME this logic seems to be flawed, assertion will fail only if algorithm == 10
-	if !((algorithm >= 1 && algorithm <= 8) || algorithm != 10) {
-		return fmt.Errorf("configuration argument algorithm must not be %v for DNSKEY", algorithm)
+	if algorithm == 0 {
+		return fmt.Errorf("configuration argument algorithm must be set for DNSKEY")
 	}
 
 	if key == "" {
@@ -2229,7 +2213,7 @@ func checkDnskeyRecord(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkDsRecord(d *schema.ResourceData) error {
+func checkDsRecord(d tools.ResourceDataFetcher) error {
 	digestType, err := tools.GetIntValue("digest_type", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
 		return err
@@ -2266,7 +2250,7 @@ func checkDsRecord(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkHinfoRecord(d *schema.ResourceData) error {
+func checkHinfoRecord(d tools.ResourceDataFetcher) error {
 	hardware, err := tools.GetStringValue("hardware", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
 		return err
@@ -2287,7 +2271,7 @@ func checkHinfoRecord(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkMxRecord(d *schema.ResourceData) error {
+func checkMxRecord(d tools.ResourceDataFetcher) error {
 	priority, err := tools.GetIntValue("priority", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
 		return err
@@ -2304,7 +2288,7 @@ func checkMxRecord(d *schema.ResourceData) error {
 	return checkTargets(d)
 }
 
-func checkNaptrRecord(d *schema.ResourceData) error {
+func checkNaptrRecord(d tools.ResourceDataFetcher) error {
 	flagsnaptr, err := tools.GetStringValue("flagsnaptr", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
 		return err
@@ -2361,7 +2345,7 @@ func checkNaptrRecord(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkNsec3Record(d *schema.ResourceData) error {
+func checkNsec3Record(d tools.ResourceDataFetcher) error {
 	flags, err := tools.GetIntValue("flags", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
 		return err
@@ -2413,7 +2397,7 @@ func checkNsec3Record(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkNsec3ParamRecord(d *schema.ResourceData) error {
+func checkNsec3ParamRecord(d tools.ResourceDataFetcher) error {
 	flags, err := tools.GetIntValue("flags", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
 		return err
@@ -2454,7 +2438,7 @@ func checkNsec3ParamRecord(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkRpRecord(d *schema.ResourceData) error {
+func checkRpRecord(d tools.ResourceDataFetcher) error {
 	mailbox, err := tools.GetStringValue("mailbox", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
 		return err
@@ -2479,7 +2463,7 @@ func checkRpRecord(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkRrsigRecord(d *schema.ResourceData) error {
+func checkRrsigRecord(d tools.ResourceDataFetcher) error {
 	expiration, err := tools.GetStringValue("expiration", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
 		return err
@@ -2560,7 +2544,7 @@ func checkRrsigRecord(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkSrvRecord(d *schema.ResourceData) error {
+func checkSrvRecord(d tools.ResourceDataFetcher) error {
 	priority, err := tools.GetIntValue("priority", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
 		return err
@@ -2597,7 +2581,7 @@ func checkSrvRecord(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkSshfpRecord(d *schema.ResourceData) error {
+func checkSshfpRecord(d tools.ResourceDataFetcher) error {
 	algorithm, err := tools.GetIntValue("algorithm", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
 		return err
@@ -2630,7 +2614,7 @@ func checkSshfpRecord(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkSoaRecord(d *schema.ResourceData) error {
+func checkSoaRecord(d tools.ResourceDataFetcher) error {
 
 	nameserver, err := tools.GetStringValue("name_server", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
@@ -2684,47 +2668,21 @@ func checkSoaRecord(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkAkamaiTlcRecord(*schema.ResourceData) error {
+func checkAkamaiTlcRecord(tools.ResourceDataFetcher) error {
 
 	return fmt.Errorf("AKAMAITLC is a READ ONLY record")
 }
 
-func checkCaaRecord(d *schema.ResourceData) error {
+func checkCaaRecord(d tools.ResourceDataFetcher) error {
 
 	if err := checkBasicRecordTypes(d); err != nil {
 		return err
 	}
 
-	if err := checkTargets(d); err != nil {
-		return err
-	}
-
-	caatarget := d.Get("target").([]interface{})
-	for _, caa := range caatarget {
-		caaStr, ok := caa.(string)
-		if !ok {
-			return fmt.Errorf("CAA is of invalid type; should be 'string'")
-		}
-		caaparts := strings.Split(caaStr, " ")
-		if len(caaparts) != 3 {
-			return fmt.Errorf("configuration argument CAA target %s is invalid", caaStr)
-		}
-
-		flag, err := strconv.Atoi(caaparts[0])
-		if err != nil || flag < 0 || flag > 255 {
-			return fmt.Errorf("configuration argument CAA target %s is invalid. flag value must be <= 0 and >= 255", caaStr)
-		}
-		re := regexp.MustCompile(`[^a-zA-Z0-9]+`)
-		submatchall := re.FindAllString(caaparts[1], -1)
-		if len(submatchall) > 0 {
-			return fmt.Errorf("configuration argument  CAA target %s is invalid. tag contains invalid characters", caaStr)
-		}
-	}
-
-	return nil
+	return checkTargets(d)
 }
 
-func checkCertRecord(d *schema.ResourceData) error {
+func checkCertRecord(d tools.ResourceDataFetcher) error {
 	typemnemonic, err := tools.GetStringValue("type_mnemonic", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
 		return err
@@ -2757,7 +2715,7 @@ func checkCertRecord(d *schema.ResourceData) error {
 
 }
 
-func checkTlsaRecord(d *schema.ResourceData) error {
+func checkTlsaRecord(d tools.ResourceDataFetcher) error {
 
 	usage, err := tools.GetIntValue("usage", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
@@ -2784,17 +2742,17 @@ func checkTlsaRecord(d *schema.ResourceData) error {
 
 }
 
-func checkSvcbRecord(d *schema.ResourceData) error {
+func checkSvcbRecord(d tools.ResourceDataFetcher) error {
 
 	return checkServiceRecord(d, "SVCB")
 }
 
-func checkHTTPSRecord(d *schema.ResourceData) error {
+func checkHTTPSRecord(d tools.ResourceDataFetcher) error {
 
 	return checkServiceRecord(d, "HTTPS")
 }
 
-func checkServiceRecord(d *schema.ResourceData, rtype string) error {
+func checkServiceRecord(d tools.ResourceDataFetcher, rtype string) error {
 
 	pri, err := tools.GetIntValue("svc_priority", d)
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
@@ -3,6 +3,7 @@ package dns
 import (
 	"context"
 	"net/http"
+	"regexp"
 	"testing"
 
 	dns "github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/configdns"
@@ -104,4 +105,24 @@ func TestResDnsRecord(t *testing.T) {
 
 		client.AssertExpectations(t)
 	})
+
+	t.Run("invalid record data fails at plan", func(t *testing.T) {
+		client := &mockdns{}
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:  func() { testAccPreCheck(t) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config:      loadFixtureString("testdata/TestResDnsRecord/invalid_caa.tf"),
+						PlanOnly:    true,
+						ExpectError: regexp.MustCompile(`configuration argument target.1 is invalid for CAA`),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
 }
@@ -0,0 +1,501 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apex/log"
+
+	dns "github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/configdns"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceDNSv2Recordsets() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDNSRecordsetsCreate,
+		ReadContext:   resourceDNSRecordsetsRead,
+		UpdateContext: resourceDNSRecordsetsUpdate,
+		DeleteContext: resourceDNSRecordsetsDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceDNSRecordsetsImport,
+		},
+		CustomizeDiff: customdiff.All(
+			akamai.EnforceChangeFreeze(""),
+			recordsetsCustomizeDiff,
+		),
+		Schema: map[string]*schema.Schema{
+			akamai.IgnoreChangeFreezeKey: akamai.IgnoreChangeFreezeSchema(),
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name_prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Only manage the recordsets whose name starts with this prefix, all recordsets of the zone are managed by default",
+			},
+			"change_list_mode": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Stage the recordset changes in the change list of the zone, which must have change_list_mode enabled",
+			},
+			"recordset": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"ttl": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+						"rdata": {
+							Type:     schema.TypeList,
+							Required: true,
+							MinItems: 1,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"changes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Records removed from and added to the zone by the last apply",
+			},
+		},
+	}
+}
+
+func resourceDNSRecordsetsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("AkamaiDNS", "resourceDNSRecordsetsCreate")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	zone := d.Get("zone").(string)
+	logger.WithField("zone", zone).Info("Recordsets Create")
+	staged, err := applyDNSRecordsets(ctx, meta, d, logger)
+	if err != nil {
+		return append(diag.Diagnostics{}, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Recordsets create failure",
+			Detail:   err.Error(),
+		})
+	}
+	d.SetId(recordsetsID(zone, d.Get("name_prefix").(string)))
+	if staged {
+		// the recordsets are read back once the change list is submitted
+		return nil
+	}
+	return resourceDNSRecordsetsRead(ctx, d, meta)
+}
+
+func resourceDNSRecordsetsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("AkamaiDNS", "resourceDNSRecordsetsRead")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	zone := d.Get("zone").(string)
+	prefix := d.Get("name_prefix").(string)
+	logger.WithField("zone", zone).Info("Recordsets Read")
+
+	live, err := getZoneRecordsets(ctx, meta, zone, d.Get("change_list_mode").(bool))
+	if err != nil {
+		apiError, ok := err.(*dns.Error)
+		if ok && apiError.StatusCode == http.StatusNotFound {
+			logger.Warnf("Zone %s not found, removing recordsets from state", zone)
+			d.SetId("")
+			return nil
+		}
+		return append(diag.Diagnostics{}, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Recordsets read failure",
+			Detail:   err.Error(),
+		})
+	}
+
+	// keep the configured representation of the recordsets which did not change
+	configured := make(map[string]interface{})
+	for _, rs := range d.Get("recordset").(*schema.Set).List() {
+		configured[recordsetKey(expandRecordset(rs))] = rs
+	}
+	recordsets := make([]interface{}, 0, len(live.Recordsets))
+	for _, rs := range live.Recordsets {
+		if !recordsetInScope(zone, prefix, rs) {
+			continue
+		}
+		if c, ok := configured[recordsetKey(rs)]; ok {
+			recordsets = append(recordsets, c)
+			continue
+		}
+		recordsets = append(recordsets, flattenRecordset(rs))
+	}
+	logger.Debugf("Found %d recordsets in zone %s", len(recordsets), zone)
+
+	if err := d.Set("recordset", recordsets); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+	if _, ok := d.GetOk("changes"); !ok {
+		if err := d.Set("changes", []string{}); err != nil {
+			return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+		}
+	}
+	return nil
+}
+
+func resourceDNSRecordsetsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("AkamaiDNS", "resourceDNSRecordsetsUpdate")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	logger.WithField("zone", d.Get("zone").(string)).Info("Recordsets Update")
+	if d.HasChange("recordset") {
+		staged, err := applyDNSRecordsets(ctx, meta, d, logger)
+		if err != nil {
+			return append(diag.Diagnostics{}, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Recordsets update failure",
+				Detail:   err.Error(),
+			})
+		}
+		if staged {
+			return nil
+		}
+	}
+	return resourceDNSRecordsetsRead(ctx, d, meta)
+}
+
+func resourceDNSRecordsetsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := akamai.EnforceChangeFreezeOnDelete(d, m, ""); err != nil {
+		return diag.FromErr(err)
+	}
+	meta := akamai.Meta(m)
+	logger := meta.Log("AkamaiDNS", "resourceDNSRecordsetsDelete")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	zone := d.Get("zone").(string)
+	logger.WithField("zone", zone).Info("Recordsets Delete")
+	if _, err := putDNSRecordsets(ctx, meta, zone, d.Get("change_list_mode").(bool), d.Get("name_prefix").(string), nil, logger); err != nil {
+		return append(diag.Diagnostics{}, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Recordsets delete failure",
+			Detail:   err.Error(),
+		})
+	}
+	d.SetId("")
+	return nil
+}
+
+// Import recordsets. Id is the zone, optionally followed by :name_prefix
+func resourceDNSRecordsetsImport(d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), ":", 2)
+	if err := d.Set("zone", parts[0]); err != nil {
+		return nil, fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error())
+	}
+	if err := d.Set("change_list_mode", false); err != nil {
+		return nil, fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error())
+	}
+	if len(parts) == 2 {
+		if err := d.Set("name_prefix", parts[1]); err != nil {
+			return nil, fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error())
+		}
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// recordsetsCustomizeDiff validates the recordsets are managed by the resource and lists the planned record changes.
+// On create, the live recordsets in the resource scope are replaced, so they are listed as removed.
+func recordsetsCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" && !d.HasChange("recordset") {
+		return nil
+	}
+	if !d.NewValueKnown("recordset") || !d.NewValueKnown("zone") || !d.NewValueKnown("name_prefix") {
+		return d.SetNewComputed("changes")
+	}
+	zone := d.Get("zone").(string)
+	prefix := d.Get("name_prefix").(string)
+	oldSet, newSet := d.GetChange("recordset")
+	var oldRecords, newRecords []zoneFileRecord
+	for _, rs := range oldSet.(*schema.Set).List() {
+		oldRecords = append(oldRecords, recordsetRecords(expandRecordset(rs))...)
+	}
+	for _, rs := range newSet.(*schema.Set).List() {
+		recordset := expandRecordset(rs)
+		if recordset.Name != "" && !recordsetInScope(zone, prefix, recordset) {
+			return fmt.Errorf("recordset %s %s is not managed by this resource: names must belong to zone %s, start with name_prefix %q, and can not be the zone SOA or NS",
+				recordset.Name, recordset.Type, zone, prefix)
+		}
+		newRecords = append(newRecords, recordsetRecords(recordset)...)
+	}
+	if d.Id() == "" {
+		meta := akamai.Meta(m)
+		logger := meta.Log("AkamaiDNS", "recordsetsCustomizeDiff")
+		ctx = session.ContextWithOptions(
+			ctx,
+			session.WithContextLog(logger),
+		)
+		live, err := liveRecordsetsInScope(ctx, meta, zone, d.Get("change_list_mode").(bool), prefix)
+		if err != nil {
+			return err
+		}
+		oldRecords = live
+	}
+	return d.SetNew("changes", diffRecords(oldRecords, newRecords))
+}
+
+// liveRecordsetsInScope returns the records of the live recordsets of the zone managed by a recordsets resource of the
+// name prefix, as staged in change list mode. A zone which doesn't exist yet has none.
+func liveRecordsetsInScope(ctx context.Context, meta akamai.OperationMeta, zone string, changeListMode bool, prefix string) ([]zoneFileRecord, error) {
+	live, err := getZoneRecordsets(ctx, meta, zone, changeListMode)
+	if err != nil {
+		var apiError *dns.Error
+		if errors.As(err, &apiError) && apiError.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed retrieving recordsets of zone %s: %w", zone, err)
+	}
+	var records []zoneFileRecord
+	for _, rs := range live.Recordsets {
+		if recordsetInScope(zone, prefix, rs) {
+			records = append(records, recordsetRecords(rs)...)
+		}
+	}
+	return records, nil
+}
+
+// applyDNSRecordsets replaces the managed recordsets of the zone with the configured ones in a single request, it
+// returns true when the recordsets were staged in the change list of the zone
+func applyDNSRecordsets(ctx context.Context, meta akamai.OperationMeta, d *schema.ResourceData, logger log.Interface) (bool, error) {
+	zone := d.Get("zone").(string)
+	prefix := d.Get("name_prefix").(string)
+	oldSet, newSet := d.GetChange("recordset")
+
+	var oldRecords, newRecords []zoneFileRecord
+	if d.IsNewResource() {
+		// the live recordsets in scope are replaced, as planned
+		live, err := liveRecordsetsInScope(ctx, meta, zone, d.Get("change_list_mode").(bool), prefix)
+		if err != nil {
+			return false, err
+		}
+		oldRecords = live
+	}
+	for _, rs := range oldSet.(*schema.Set).List() {
+		oldRecords = append(oldRecords, recordsetRecords(expandRecordset(rs))...)
+	}
+	recordsets := make([]dns.Recordset, 0, newSet.(*schema.Set).Len())
+	for _, rs := range newSet.(*schema.Set).List() {
+		recordset := expandRecordset(rs)
+		recordsets = append(recordsets, recordset)
+		newRecords = append(newRecords, recordsetRecords(recordset)...)
+	}
+	changes := diffRecords(oldRecords, newRecords)
+	logger.Debugf("Submitting %d recordsets of zone %s with %d record changes", len(recordsets), zone, len(changes))
+
+	staged, err := putDNSRecordsets(ctx, meta, zone, d.Get("change_list_mode").(bool), prefix, recordsets, logger)
+	if err != nil {
+		return staged, err
+	}
+	if err := d.Set("changes", changes); err != nil {
+		return staged, fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error())
+	}
+	return staged, nil
+}
+
+// putDNSRecordsets submits all recordsets of the zone, the recordsets out of the resource scope are kept unchanged and
+// the SOA serial is incremented. The submission is retried when the zone was changed concurrently. In change list mode,
+// the changes are staged in the change list of the zone instead and true is returned.
+func putDNSRecordsets(ctx context.Context, meta akamai.OperationMeta, zone string, changeListMode bool, prefix string, recordsets []dns.Recordset, logger log.Interface) (bool, error) {
+	staged, err := stageRecordsets(ctx, meta, zone, changeListMode, recordsets, func(rs dns.Recordset) bool {
+		return recordsetInScope(zone, prefix, rs)
+	}, logger)
+	if err != nil || staged {
+		return staged, err
+	}
+
+	defer recordLocks.lockZone(zone)()
+
+	for retry := opRetryCount; ; retry-- {
+		live, err := inst.Client(meta).GetRecordsets(ctx, zone, dns.RecordsetQueryArgs{ShowAll: true})
+		if err != nil {
+			return false, err
+		}
+		request := &dns.Recordsets{Recordsets: make([]dns.Recordset, 0, len(live.Recordsets)+len(recordsets))}
+		for _, rs := range live.Recordsets {
+			if recordsetInScope(zone, prefix, rs) {
+				continue
+			}
+			if rs.Type == "SOA" {
+				if rs, err = incrementSoaSerial(zone, rs); err != nil {
+					return false, err
+				}
+			}
+			request.Recordsets = append(request.Recordsets, rs)
+		}
+		request.Recordsets = append(request.Recordsets, recordsets...)
+
+		err = inst.Client(meta).UpdateRecordsets(ctx, request, zone, true)
+		var apiError *dns.Error
+		if err == nil || retry == 0 || !errors.As(err, &apiError) || apiError.StatusCode != http.StatusConflict {
+			return false, err
+		}
+		logger.Debugf("Zone %s changed while submitting recordsets, retrying", zone)
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// incrementSoaSerial returns the SOA recordset of the zone with the next serial number
+func incrementSoaSerial(zone string, soa dns.Recordset) (dns.Recordset, error) {
+	if len(soa.Rdata) != 1 {
+		return soa, fmt.Errorf("invalid SOA record of %s: %v", soa.Name, soa.Rdata)
+	}
+	fields := strings.Fields(soa.Rdata[0])
+	if len(fields) != 7 {
+		return soa, fmt.Errorf("invalid SOA record of %s: %s", soa.Name, soa.Rdata[0])
+	}
+	serial, err := strconv.ParseUint(fields[2], 10, 32)
+	if err != nil {
+		return soa, fmt.Errorf("invalid SOA serial of %s: %s", soa.Name, fields[2])
+	}
+	fields[2] = strconv.FormatUint(uint64(zoneSerials.next(zone, uint32(serial))), 10)
+	soa.Rdata = []string{strings.Join(fields, " ")}
+	return soa, nil
+}
+
+// recordsetInScope returns true for the recordsets managed by a recordsets resource of the zone and name prefix. The
+// SOA and NS recordsets of the zone apex belong to the zone and are never managed.
+func recordsetInScope(zone, prefix string, rs dns.Recordset) bool {
+	name := normalizeRecordsetName(rs.Name)
+	zone = normalizeRecordsetName(zone)
+	if name != zone && !strings.HasSuffix(name, "."+zone) {
+		return false
+	}
+	if name == zone && (strings.EqualFold(rs.Type, "SOA") || strings.EqualFold(rs.Type, "NS")) {
+		return false
+	}
+	return strings.HasPrefix(name, strings.ToLower(prefix))
+}
+
+func recordsetsID(zone, prefix string) string {
+	if prefix == "" {
+		return zone
+	}
+	return fmt.Sprintf("%s:%s", zone, prefix)
+}
+
+func expandRecordset(v interface{}) dns.Recordset {
+	// attributes may still be unknown while planning
+	rs := v.(map[string]interface{})
+	name, _ := rs["name"].(string)
+	recordType, _ := rs["type"].(string)
+	ttl, _ := rs["ttl"].(int)
+	recordset := dns.Recordset{
+		Name: name,
+		Type: strings.ToUpper(recordType),
+		TTL:  ttl,
+	}
+	rdataList, _ := rs["rdata"].([]interface{})
+	for _, rdata := range rdataList {
+		if r, ok := rdata.(string); ok {
+			recordset.Rdata = append(recordset.Rdata, r)
+		}
+	}
+	return recordset
+}
+
+func flattenRecordset(rs dns.Recordset) map[string]interface{} {
+	return map[string]interface{}{
+		"name":  rs.Name,
+		"type":  rs.Type,
+		"ttl":   rs.TTL,
+		"rdata": rs.Rdata,
+	}
+}
+
+// recordsetRecords returns a record per rdata of the recordset
+func recordsetRecords(rs dns.Recordset) []zoneFileRecord {
+	records := make([]zoneFileRecord, 0, len(rs.Rdata))
+	for _, rdata := range rs.Rdata {
+		records = append(records, zoneFileRecord{
+			Name:  fqdn(rs.Name),
+			TTL:   rs.TTL,
+			Type:  strings.ToUpper(rs.Type),
+			Rdata: rdata,
+		})
+	}
+	return records
+}
+
+// recordsetKey identifies a recordset regardless of the name case, the rdata order and representation
+func recordsetKey(rs dns.Recordset) string {
+	recordType := strings.ToUpper(rs.Type)
+	rdata := make([]string, 0, len(rs.Rdata))
+	for _, r := range rs.Rdata {
+		rdata = append(rdata, normalizeRdata(recordType, r))
+	}
+	sort.Strings(rdata)
+	return fmt.Sprintf("%s %d %s %s", normalizeRecordsetName(rs.Name), rs.TTL, recordType, strings.Join(rdata, "|"))
+}
+
+func normalizeRecordsetName(name string) string {
+	return strings.TrimSuffix(strings.ToLower(name), ".")
+}
+
+// normalizeRdata returns the canonical presentation of a single rdata value: whitespace is collapsed, domain names are
+// lower case without trailing dot and character strings are quoted
+func normalizeRdata(recordType, rdata string) string {
+	rdata = strings.TrimSpace(rdata)
+	switch recordType {
+	case "TXT", "SPF":
+		if !strings.HasPrefix(rdata, `"`) {
+			return `"` + rdata + `"`
+		}
+		return rdata
+	}
+	fields := strings.Fields(rdata)
+	switch recordType {
+	case "CNAME", "NS", "PTR", "MX", "SRV", "AFSDB", "AKAMAICDN":
+		for i, f := range fields {
+			fields[i] = normalizeRecordsetName(f)
+		}
+	}
+	return strings.Join(fields, " ")
+}
@@ -0,0 +1,191 @@
+package dns
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	dns "github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/configdns"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestResDnsRecordsets(t *testing.T) {
+	zone := "exampleterraform.io"
+
+	// mockZone stores the recordsets submitted to the mocked zone, TXT data is returned quoted as by Edge DNS
+	mockZone := func(client *mockdns, live *dns.RecordSetResponse, submitted *[]*dns.Recordsets) {
+		client.On("GetRecordsets",
+			mock.Anything, // ctx is irrelevant for this test
+			zone,
+			[]dns.RecordsetQueryArgs{{ShowAll: true}},
+		).Return(live, nil)
+
+		client.On("UpdateRecordsets",
+			mock.Anything, // ctx is irrelevant for this test
+			mock.AnythingOfType("*dns.Recordsets"),
+			zone,
+			[]bool{true},
+		).Return(nil).Run(func(args mock.Arguments) {
+			request := args.Get(1).(*dns.Recordsets)
+			*submitted = append(*submitted, request)
+			live.Recordsets = nil
+			for _, rs := range request.Recordsets {
+				rdata := make([]string, 0, len(rs.Rdata))
+				for _, r := range rs.Rdata {
+					if rs.Type == "TXT" && !strings.HasPrefix(r, `"`) {
+						r = `"` + r + `"`
+					}
+					rdata = append(rdata, r)
+				}
+				rs.Rdata = rdata
+				live.Recordsets = append(live.Recordsets, rs)
+			}
+		})
+	}
+
+	zoneRecordsets := func() *dns.RecordSetResponse {
+		return &dns.RecordSetResponse{Recordsets: []dns.Recordset{
+			{Name: zone, Type: "SOA", TTL: 86400, Rdata: []string{"a1-1.akam.net. hostmaster.exampleterraform.io. 2021010101 14400 7200 604800 1200"}},
+			{Name: zone, Type: "NS", TTL: 86400, Rdata: []string{"a1-1.akam.net.", "a2-2.akam.net."}},
+			{Name: "old.exampleterraform.io", Type: "A", TTL: 300, Rdata: []string{"192.0.2.9"}},
+		}}
+	}
+
+	t.Run("lifecycle test", func(t *testing.T) {
+		client := &mockdns{}
+		live := zoneRecordsets()
+		var submitted []*dns.Recordsets
+		mockZone(client, live, &submitted)
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:  func() { testAccPreCheck(t) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestResDnsRecordsets/create_recordsets.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_dns_recordsets.test", "id", zone),
+							resource.TestCheckResourceAttr("akamai_dns_recordsets.test", "recordset.#", "2"),
+							// the live record in scope which is not configured is replaced, and planned as removed
+							resource.TestCheckResourceAttr("akamai_dns_recordsets.test", "changes.#", "4"),
+							resource.TestCheckResourceAttr("akamai_dns_recordsets.test", "changes.0", "+ ftp.exampleterraform.io. 300 IN CNAME www.exampleterraform.io."),
+							resource.TestCheckResourceAttr("akamai_dns_recordsets.test", "changes.1", "- old.exampleterraform.io. 300 IN A 192.0.2.9"),
+						),
+					},
+					{
+						Config:             loadFixtureString("testdata/TestResDnsRecordsets/update_recordsets.tf"),
+						PlanOnly:           true,
+						ExpectNonEmptyPlan: true,
+					},
+					{
+						Config: loadFixtureString("testdata/TestResDnsRecordsets/update_recordsets.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_dns_recordsets.test", "changes.#", "2"),
+							resource.TestCheckResourceAttr("akamai_dns_recordsets.test", "changes.0", "- www.exampleterraform.io. 300 IN A 192.0.2.2"),
+							resource.TestCheckResourceAttr("akamai_dns_recordsets.test", "changes.1", "+ www.exampleterraform.io. 300 IN A 192.0.2.3"),
+						),
+					},
+				},
+			})
+		})
+
+		// every apply is a single request, keeping the zone SOA and NS with a new serial
+		assert.Len(t, submitted, 3)
+		assert.Equal(t, []dns.Recordset{
+			{Name: zone, Type: "SOA", TTL: 86400, Rdata: []string{"a1-1.akam.net. hostmaster.exampleterraform.io. 2021010102 14400 7200 604800 1200"}},
+			{Name: zone, Type: "NS", TTL: 86400, Rdata: []string{"a1-1.akam.net.", "a2-2.akam.net."}},
+		}, submitted[0].Recordsets[:2])
+		assert.Len(t, submitted[0].Recordsets, 4)
+		assert.Len(t, submitted[2].Recordsets, 2)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("name prefix", func(t *testing.T) {
+		client := &mockdns{}
+		live := zoneRecordsets()
+		var submitted []*dns.Recordsets
+		mockZone(client, live, &submitted)
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:  func() { testAccPreCheck(t) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestResDnsRecordsets/prefix_recordsets.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_dns_recordsets.test", "id", "exampleterraform.io:api"),
+							resource.TestCheckResourceAttr("akamai_dns_recordsets.test", "recordset.#", "1"),
+						),
+					},
+				},
+			})
+		})
+
+		// the records out of the prefix are kept, the configured TXT record was read back quoted without a diff
+		assert.Len(t, submitted, 2)
+		assert.Len(t, submitted[0].Recordsets, 4)
+		assert.Contains(t, submitted[1].Recordsets, dns.Recordset{Name: "old.exampleterraform.io", Type: "A", TTL: 300, Rdata: []string{"192.0.2.9"}})
+		assert.Len(t, submitted[1].Recordsets, 3)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("recordset out of scope", func(t *testing.T) {
+		client := &mockdns{}
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:  func() { testAccPreCheck(t) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config:      loadFixtureString("testdata/TestResDnsRecordsets/out_of_scope_recordsets.tf"),
+						ExpectError: regexp.MustCompile(`recordset www.exampleterraform.io A is not managed by this resource`),
+					},
+				},
+			})
+		})
+		client.AssertExpectations(t)
+	})
+
+	t.Run("concurrent zone change retried", func(t *testing.T) {
+		client := &mockdns{}
+		live := zoneRecordsets()
+		var submitted []*dns.Recordsets
+		client.On("UpdateRecordsets",
+			mock.Anything, // ctx is irrelevant for this test
+			mock.AnythingOfType("*dns.Recordsets"),
+			zone,
+			[]bool{true},
+		).Return(&dns.Error{StatusCode: http.StatusConflict}).Once()
+		mockZone(client, live, &submitted)
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:  func() { testAccPreCheck(t) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestResDnsRecordsets/create_recordsets.tf"),
+						Check:  resource.TestCheckResourceAttr("akamai_dns_recordsets.test", "recordset.#", "2"),
+					},
+				},
+			})
+		})
+		assert.Len(t, submitted, 2)
+		client.AssertExpectations(t)
+	})
+}
+
+func TestIncrementSoaSerial(t *testing.T) {
+	soa, err := incrementSoaSerial("serial.example.com", dns.Recordset{Name: "example.com", Type: "SOA", Rdata: []string{"a. b. 4294967295 1 2 3 4"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a. b. 0 1 2 3 4"}, soa.Rdata)
+
+	_, err = incrementSoaSerial("serial.example.com", dns.Recordset{Name: "example.com", Type: "SOA", Rdata: []string{"a. b. serial 1 2 3 4"}})
+	assert.EqualError(t, err, "invalid SOA serial of example.com: serial")
+}
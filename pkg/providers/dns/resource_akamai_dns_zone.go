@@ -18,9 +18,19 @@ import (
 	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+var (
+	// ZoneTransferPollInterval is the interval for polling the transfer status of a secondary zone waiting for its
+	// first transfer
+	ZoneTransferPollInterval = 10 * time.Second
+
+	// ZoneCreateTimeout is the default timeout of zone creation, including the wait for the first transfer
+	ZoneCreateTimeout = time.Minute * 30
+)
+
 func resourceDNSv2Zone() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceDNSv2ZoneCreate,
@@ -30,7 +40,18 @@ func resourceDNSv2Zone() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceDNSv2ZoneImport,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: &ZoneCreateTimeout,
+		},
+		CustomizeDiff: customdiff.All(
+			akamai.EnforceChangeFreeze(""),
+			zoneFileCustomizeDiff,
+			customdiff.ForceNewIfChange("type", func(_ context.Context, old, new, _ interface{}) bool {
+				return !convertibleZoneTypes(old.(string), new.(string))
+			}),
+		),
 		Schema: map[string]*schema.Schema{
+			akamai.IgnoreChangeFreezeKey: akamai.IgnoreChangeFreezeSchema(),
 			"contract": {
 				Type:             schema.TypeString,
 				Required:         true,
@@ -43,7 +64,6 @@ func resourceDNSv2Zone() *schema.Resource {
 			"type": {
 				Type:             schema.TypeString,
 				Required:         true,
-				ForceNew:         true,
 				ValidateDiagFunc: validateZoneType,
 				StateFunc: func(val interface{}) string {
 					return strings.ToUpper(val.(string))
@@ -102,6 +122,32 @@ func resourceDNSv2Zone() *schema.Resource {
 					},
 				},
 			},
+			"zone_file": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateZoneFile,
+				DiffSuppressFunc: zoneFileSuppress,
+				Description:      "RFC 1035 master file uploaded to the PRIMARY zone, replacing all its records",
+			},
+			"change_list_mode": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"zone_file"},
+				Description:   "Stage record changes of the PRIMARY zone in a change list, submitted by an akamai_dns_changelist_submit resource",
+			},
+			"wait_for_first_transfer": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Wait on create until the SECONDARY zone is transferred from its masters",
+			},
+			"zone_file_changes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Records removed from and added to the zone by the last zone_file upload",
+			},
 			"version_id": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -217,6 +263,32 @@ func resourceDNSv2ZoneCreate(ctx context.Context, d *schema.ResourceData, m inte
 			})
 		}
 	}
+	if zoneFile := d.Get("zone_file").(string); zoneFile != "" {
+		logger.Debugf("Uploading zone file of zone %s", hostname)
+		unlock := recordLocks.lockZone(hostname)
+		e = inst.Client(meta).PostMasterZoneFile(ctx, hostname, zoneFile)
+		unlock()
+		if e != nil {
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Zone file upload failure",
+				Detail:   e.Error(),
+			})
+		}
+	}
+	if d.Get("change_list_mode").(bool) {
+		logger.Debugf("Opening change list of zone %s", hostname)
+		if e = reopenZoneChangeList(ctx, meta, hostname); e != nil {
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Zone change list create failure",
+				Detail:   e.Error(),
+			})
+		}
+	}
+	if err := d.Set("zone_file_changes", []string{}); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
 	zone, e = inst.Client(meta).GetZone(ctx, hostname)
 	if e != nil {
 		return append(diags, diag.Diagnostic{
@@ -226,6 +298,16 @@ func resourceDNSv2ZoneCreate(ctx context.Context, d *schema.ResourceData, m inte
 		})
 	}
 	d.SetId(fmt.Sprintf("%s#%s#%s", zone.VersionId, zone.Zone, hostname))
+	if d.Get("wait_for_first_transfer").(bool) {
+		logger.Debugf("Waiting for first transfer of zone %s", hostname)
+		if e = waitForZoneTransfer(ctx, meta, hostname); e != nil {
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Zone transfer failure",
+				Detail:   e.Error(),
+			})
+		}
+	}
 	return resourceDNSv2ZoneRead(ctx, d, meta)
 
 }
@@ -308,6 +390,27 @@ func resourceDNSv2ZoneRead(ctx context.Context, d *schema.ResourceData, m interf
 	if err := populateDNSv2ZoneState(d, zone); err != nil {
 		return diag.FromErr(err)
 	}
+	if err := readDNSv2ZoneFile(ctx, meta, d, zone, logger); err != nil {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Zone file read failure",
+			Detail:   err.Error(),
+		})
+	}
+	if d.Get("change_list_mode").(bool) {
+		// the change list may have been submitted or discarded outside of terraform
+		changeList, err := getZoneChangeList(ctx, meta, zone.Zone)
+		if err != nil {
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Zone change list read failure",
+				Detail:   err.Error(),
+			})
+		}
+		if err := d.Set("change_list_mode", changeList != nil); err != nil {
+			return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+		}
+	}
 
 	logger.Debugf("READ content: %v", zone)
 	if strings.Contains(d.Id(), "#") {
@@ -375,6 +478,30 @@ func resourceDNSv2ZoneUpdate(ctx context.Context, d *schema.ResourceData, m inte
 	if err := populateDNSv2ZoneObject(d, zoneCreate, logger); err != nil {
 		return diag.FromErr(err)
 	}
+	converted := d.HasChange("type")
+	if converted && strings.ToUpper(zoneType) != "SECONDARY" {
+		zoneCreate.TsigKey = nil
+	}
+	var imported []dns.Recordset
+	if converted && strings.ToUpper(zoneType) == "PRIMARY" && d.Get("zone_file").(string) == "" {
+		// the records transferred to the secondary zone are kept by the primary zone
+		if imported, e = secondaryZoneRecordsets(ctx, meta, hostname); e != nil {
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Zone conversion failure",
+				Detail:   e.Error(),
+			})
+		}
+	}
+	if d.HasChange("target") && strings.ToUpper(zoneType) == "ALIAS" {
+		if e = checkDNSv2AliasTarget(ctx, meta, hostname, zoneCreate.Target); e != nil {
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Zone update failure",
+				Detail:   e.Error(),
+			})
+		}
+	}
 	// Save the zone to the API
 	logger.Debugf("Saving zone %v", zoneCreate)
 	e = inst.Client(meta).UpdateZone(ctx, zoneCreate, zoneQueryString)
@@ -385,6 +512,34 @@ func resourceDNSv2ZoneUpdate(ctx context.Context, d *schema.ResourceData, m inte
 			Detail:   e.Error(),
 		})
 	}
+	if converted && strings.ToUpper(zoneType) == "PRIMARY" && d.Get("zone_file").(string) == "" {
+		logger.Debugf("Importing %d recordsets in converted zone %s", len(imported), hostname)
+		if e = importDNSv2ZoneRecordsets(ctx, meta, hostname, imported, logger); e != nil {
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Zone conversion failure",
+				Detail:   e.Error(),
+			})
+		}
+	}
+	if d.HasChange("zone_file") {
+		if err := updateDNSv2ZoneFile(ctx, meta, d, hostname, logger); err != nil {
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Zone file upload failure",
+				Detail:   err.Error(),
+			})
+		}
+	}
+	if d.HasChange("change_list_mode") {
+		if err := updateDNSv2ZoneChangeList(ctx, meta, d, hostname, logger); err != nil {
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Zone change list update failure",
+				Detail:   err.Error(),
+			})
+		}
+	}
 
 	// Give terraform the ID
 	if strings.Contains(d.Id(), "#") {
@@ -619,6 +774,18 @@ func checkDNSv2Zone(d tools.ResourceDataFetcher) error {
 	if err != nil && !errors.Is(err, tools.ErrNotFound) {
 		return err
 	}
+	zoneFile, err := tools.GetStringValue("zone_file", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return err
+	}
+	changeListMode, err := tools.GetBoolValue("change_list_mode", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return err
+	}
+	waitForTransfer, err := tools.GetBoolValue("wait_for_first_transfer", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return err
+	}
 	ztype := strings.ToUpper(zoneType)
 	masters := mastersSet.List()
 	if ztype == "SECONDARY" && len(masters) == 0 {
@@ -636,12 +803,194 @@ func checkDNSv2Zone(d tools.ResourceDataFetcher) error {
 	if signandserve && ztype == "ALIAS" {
 		return fmt.Errorf("sign_and_serve is not valid in %s zone %s configuration", ztype, zone)
 	}
+	if ztype != "PRIMARY" && zoneFile != "" {
+		return fmt.Errorf("zone_file can not be populated in %s zone %s configuration", ztype, zone)
+	}
+	if ztype != "PRIMARY" && changeListMode {
+		return fmt.Errorf("change_list_mode is not valid in %s zone %s configuration", ztype, zone)
+	}
 	if ztype != "SECONDARY" && len(tsig) > 0 {
 		return fmt.Errorf("tsig_key can not be populated in %s zone %s configuration", ztype, zone)
 	}
+	if ztype != "SECONDARY" && waitForTransfer {
+		return fmt.Errorf("wait_for_first_transfer is not valid in %s zone %s configuration", ztype, zone)
+	}
+
+	return nil
+
+}
+
+// convertibleZoneTypes returns whether a zone of the old type can be converted in place to the new type, only
+// SECONDARY and PRIMARY zones are converted into each other
+func convertibleZoneTypes(oldType, newType string) bool {
+	oldType, newType = strings.ToUpper(oldType), strings.ToUpper(newType)
+	if oldType == newType {
+		return true
+	}
+	return (oldType == "PRIMARY" || oldType == "SECONDARY") && (newType == "PRIMARY" || newType == "SECONDARY")
+}
+
+// secondaryZoneRecordsets returns the recordsets transferred to the secondary zone, without the SOA and NS recordsets
+// of the zone apex
+func secondaryZoneRecordsets(ctx context.Context, meta akamai.OperationMeta, zone string) ([]dns.Recordset, error) {
+	resp, err := inst.Client(meta).GetRecordsets(ctx, zone, dns.RecordsetQueryArgs{ShowAll: true})
+	if err != nil {
+		return nil, err
+	}
+	recordsets := make([]dns.Recordset, 0, len(resp.Recordsets))
+	for _, rs := range resp.Recordsets {
+		if recordsetInScope(zone, "", rs) {
+			recordsets = append(recordsets, rs)
+		}
+	}
+	return recordsets, nil
+}
+
+// importDNSv2ZoneRecordsets adds the recordsets to a zone converted to PRIMARY, after creating its SOA and NS records
+func importDNSv2ZoneRecordsets(ctx context.Context, meta akamai.OperationMeta, zone string, recordsets []dns.Recordset, logger log.Interface) error {
+	zoneResp, err := inst.Client(meta).GetZone(ctx, zone)
+	if err != nil {
+		return err
+	}
+	if err := checkZoneSOAandNSRecords(ctx, meta, zoneResp, logger); err != nil {
+		return err
+	}
+	if len(recordsets) == 0 {
+		return nil
+	}
+	// zone_file can't be used with change_list_mode, the records are made live
+	_, err = putDNSRecordsets(ctx, meta, zone, false, "", recordsets, logger)
+	return err
+}
+
+// checkDNSv2AliasTarget checks the target of an alias zone exists and is not an alias zone itself
+func checkDNSv2AliasTarget(ctx context.Context, meta akamai.OperationMeta, zone, target string) error {
+	targetZone, err := inst.Client(meta).GetZone(ctx, target)
+	if err != nil {
+		return fmt.Errorf("target %s of alias zone %s: %w", target, zone, err)
+	}
+	if strings.ToUpper(targetZone.Type) == "ALIAS" {
+		return fmt.Errorf("target %s of alias zone %s is an alias zone", target, zone)
+	}
+	return nil
+}
+
+// waitForZoneTransfer polls the transfer status of the secondary zone until a transfer from its masters succeeds,
+// the error of the last failed attempt is reported when the context is done first
+func waitForZoneTransfer(ctx context.Context, meta akamai.OperationMeta, zone string) error {
+	var lastError string
+	for {
+		statuses, err := inst.ZoneTransferClient(meta).GetZonesTransferStatus(ctx, GetZonesTransferStatusRequest{Zones: []string{zone}})
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses.Zones {
+			if normalizeRecordsetName(status.Zone) != normalizeRecordsetName(zone) {
+				continue
+			}
+			if status.LastTransferResult {
+				return nil
+			}
+			if status.LastTransferError != "" {
+				lastError = status.LastTransferError
+			}
+		}
+		select {
+		case <-time.After(ZoneTransferPollInterval):
+		case <-ctx.Done():
+			if lastError != "" {
+				return fmt.Errorf("waiting for first transfer of zone %s: %w: last transfer error: %s", zone, ctx.Err(), lastError)
+			}
+			return fmt.Errorf("waiting for first transfer of zone %s: %w", zone, ctx.Err())
+		}
+	}
+}
 
+// validateZoneFile is a SchemaValidateDiagFunc to validate the zone file syntax
+func validateZoneFile(v interface{}, _ cty.Path) diag.Diagnostics {
+	if _, err := parseZoneFile("validation.invalid", v.(string)); err != nil {
+		return diag.Errorf("invalid zone file: %s", err)
+	}
 	return nil
+}
 
+// zoneFileCustomizeDiff lists the records changed by a zone_file update in zone_file_changes
+func zoneFileCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChange("zone_file") {
+		return nil
+	}
+	oldFile, newFile := d.GetChange("zone_file")
+	if newFile.(string) == "" {
+		return d.SetNew("zone_file_changes", []string{})
+	}
+	changes, err := diffZoneFiles(d.Get("zone").(string), oldFile.(string), newFile.(string))
+	if err != nil {
+		return err
+	}
+	return d.SetNew("zone_file_changes", changes)
+}
+
+// readDNSv2ZoneFile refreshes zone_file with the zone content when it no longer matches the uploaded records
+func readDNSv2ZoneFile(ctx context.Context, meta akamai.OperationMeta, d *schema.ResourceData, zone *dns.ZoneResponse, logger log.Interface) error {
+	zoneFile := d.Get("zone_file").(string)
+	if zoneFile == "" || strings.ToUpper(zone.Type) != "PRIMARY" {
+		return nil
+	}
+	current, err := inst.Client(meta).GetMasterZoneFile(ctx, zone.Zone)
+	if err != nil {
+		return err
+	}
+	drift, err := diffZoneFiles(zone.Zone, zoneFile, current)
+	if err != nil {
+		return err
+	}
+	if len(drift) == 0 {
+		return nil
+	}
+	logger.Warnf("Zone %s records changed outside of zone_file:\n%s", zone.Zone, strings.Join(drift, "\n"))
+	if err := d.Set("zone_file", current); err != nil {
+		return fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error())
+	}
+	return nil
+}
+
+// updateDNSv2ZoneFile uploads the configured zone file and records the changed records in zone_file_changes
+func updateDNSv2ZoneFile(ctx context.Context, meta akamai.OperationMeta, d *schema.ResourceData, zone string, logger log.Interface) error {
+	oldFile, newFile := d.GetChange("zone_file")
+	changes := []string{}
+	if newFile.(string) != "" {
+		var err error
+		if changes, err = diffZoneFiles(zone, oldFile.(string), newFile.(string)); err != nil {
+			return err
+		}
+		logger.Debugf("Uploading zone file of zone %s with %d record changes", zone, len(changes))
+		unlock := recordLocks.lockZone(zone)
+		err = inst.Client(meta).PostMasterZoneFile(ctx, zone, newFile.(string))
+		unlock()
+		if err != nil {
+			return err
+		}
+	}
+	if err := d.Set("zone_file_changes", changes); err != nil {
+		return fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error())
+	}
+	return nil
+}
+
+// updateDNSv2ZoneChangeList opens the change list of the zone when change_list_mode is enabled, and discards it when
+// disabled
+func updateDNSv2ZoneChangeList(ctx context.Context, meta akamai.OperationMeta, d *schema.ResourceData, zone string, logger log.Interface) error {
+	if d.Get("change_list_mode").(bool) {
+		logger.Debugf("Opening change list of zone %s", zone)
+		return reopenZoneChangeList(ctx, meta, zone)
+	}
+	logger.Debugf("Discarding change list of zone %s", zone)
+	err := inst.ChangeListClient(meta).DeleteChangeList(ctx, zone)
+	var apiError *dns.Error
+	if errors.As(err, &apiError) && apiError.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return err
 }
 
 // Util func to create SOA and NS records
@@ -1,12 +1,19 @@
 package dns
 
 import (
+	"context"
+	"errors"
 	dns "github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/configdns"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"net/http"
 	"os"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestResDnsZone(t *testing.T) {
@@ -92,4 +99,389 @@ func TestResDnsZone(t *testing.T) {
 
 		client.AssertExpectations(t)
 	})
+
+	t.Run("zone file", func(t *testing.T) {
+		client := &mockdns{}
+		fileZone := *zone
+		fileZone.Comment = "This is a test primary zone"
+
+		getCall := client.On("GetZone",
+			mock.Anything, // ctx is irrelevant for this test
+			fileZone.Zone,
+		).Return(nil, &dns.Error{
+			StatusCode: http.StatusNotFound,
+		})
+
+		client.On("CreateZone",
+			mock.Anything, // ctx is irrelevant for this test
+			mock.AnythingOfType("*dns.ZoneCreate"),
+			mock.AnythingOfType("dns.ZoneQueryString"),
+			true,
+		).Return(nil).Run(func(args mock.Arguments) {
+			getCall.ReturnArguments = mock.Arguments{&fileZone, nil}
+		})
+
+		client.On("UpdateZone",
+			mock.Anything, // ctx is irrelevant for this test
+			mock.AnythingOfType("*dns.ZoneCreate"),
+			mock.AnythingOfType("dns.ZoneQueryString"),
+		).Return(nil)
+
+		client.On("SaveChangelist",
+			mock.Anything, // ctx is irrelevant for this test
+			mock.AnythingOfType("*dns.ZoneCreate"),
+		).Return(nil)
+
+		client.On("SubmitChangelist",
+			mock.Anything, // ctx is irrelevant for this test
+			mock.AnythingOfType("*dns.ZoneCreate"),
+		).Return(nil)
+
+		client.On("GetRecordsets",
+			mock.Anything, // ctx is irrelevant for this test
+			fileZone.Zone,
+			mock.AnythingOfType("[]dns.RecordsetQueryArgs"),
+		).Return(recordsetsResp, nil)
+
+		getFileCall := client.On("GetMasterZoneFile",
+			mock.Anything, // ctx is irrelevant for this test
+			fileZone.Zone,
+		).Return("", nil)
+
+		client.On("PostMasterZoneFile",
+			mock.Anything, // ctx is irrelevant for this test
+			fileZone.Zone,
+			mock.AnythingOfType("string"),
+		).Return(nil).Run(func(args mock.Arguments) {
+			// the zone is exported with fully qualified names and a new SOA serial
+			records, err := parseZoneFile(fileZone.Zone, args.String(2))
+			require.NoError(t, err)
+			var exported strings.Builder
+			for _, r := range records {
+				if r.Type == "SOA" {
+					r.Rdata = strings.Replace(r.Rdata, " 1 ", " 2021010107 ", 1)
+				}
+				exported.WriteString(r.String() + "\n")
+			}
+			getFileCall.ReturnArguments = mock.Arguments{exported.String(), nil}
+		}).Twice()
+
+		resourceName := "akamai_dns_zone.primary_test_zone"
+
+		// work around to skip Delete which fails intentionally
+		os.Setenv("DNS_ZONE_SKIP_DELETE", "")
+		defer os.Unsetenv("DNS_ZONE_SKIP_DELETE")
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:  func() { testAccPreCheck(t) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestResDnsZone/create_primary_zone_file.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr(resourceName, "zone_file_changes.#", "0"),
+						),
+					},
+					{
+						Config: loadFixtureString("testdata/TestResDnsZone/update_primary_zone_file.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr(resourceName, "zone_file_changes.#", "1"),
+							resource.TestCheckResourceAttr(resourceName, "zone_file_changes.0", "+ api.primaryexampleterraform.io. 300 IN A 192.0.2.2"),
+						),
+					},
+					{
+						// a record added outside of terraform is reported as drift
+						PreConfig: func() {
+							getFileCall.ReturnArguments = mock.Arguments{
+								getFileCall.ReturnArguments.String(0) + "ftp 300 IN A 192.0.2.3\n", nil,
+							}
+						},
+						Config:             loadFixtureString("testdata/TestResDnsZone/update_primary_zone_file.tf"),
+						PlanOnly:           true,
+						ExpectNonEmptyPlan: true,
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+
+	t.Run("secondary zone converted to primary", func(t *testing.T) {
+		client := &mockdns{}
+		convertZone := &dns.ZoneResponse{
+			ContractID:      "ctr1",
+			Zone:            "convertexampleterraform.io",
+			Type:            "SECONDARY",
+			Masters:         []string{"192.0.2.1"},
+			Comment:         "This is a test zone",
+			ActivationState: "ACTIVE",
+		}
+		live := &dns.RecordSetResponse{Recordsets: []dns.Recordset{
+			{Name: "convertexampleterraform.io", Type: "SOA", TTL: 86400, Rdata: []string{"ns1.example.net. hostmaster.convertexampleterraform.io. 2021010101 14400 7200 604800 1200"}},
+			{Name: "convertexampleterraform.io", Type: "NS", TTL: 86400, Rdata: []string{"ns1.example.net."}},
+			{Name: "www.convertexampleterraform.io", Type: "A", TTL: 300, Rdata: []string{"192.0.2.10"}},
+		}}
+
+		getCall := client.On("GetZone",
+			mock.Anything, // ctx is irrelevant for this test
+			convertZone.Zone,
+		).Return(nil, &dns.Error{
+			StatusCode: http.StatusNotFound,
+		})
+
+		client.On("CreateZone",
+			mock.Anything, // ctx is irrelevant for this test
+			mock.AnythingOfType("*dns.ZoneCreate"),
+			mock.AnythingOfType("dns.ZoneQueryString"),
+			true,
+		).Return(nil).Run(func(args mock.Arguments) {
+			getCall.ReturnArguments = mock.Arguments{convertZone, nil}
+		})
+
+		var updated *dns.ZoneCreate
+		client.On("UpdateZone",
+			mock.Anything, // ctx is irrelevant for this test
+			mock.AnythingOfType("*dns.ZoneCreate"),
+			mock.AnythingOfType("dns.ZoneQueryString"),
+		).Return(nil).Run(func(args mock.Arguments) {
+			updated = args.Get(1).(*dns.ZoneCreate)
+			convertZone.Type = strings.ToUpper(updated.Type)
+			convertZone.Masters = updated.Masters
+		})
+
+		client.On("GetRecordsets",
+			mock.Anything, // ctx is irrelevant for this test
+			convertZone.Zone,
+			mock.AnythingOfType("[]dns.RecordsetQueryArgs"),
+		).Return(live, nil)
+
+		var imported *dns.Recordsets
+		client.On("UpdateRecordsets",
+			mock.Anything, // ctx is irrelevant for this test
+			mock.AnythingOfType("*dns.Recordsets"),
+			convertZone.Zone,
+			[]bool{true},
+		).Return(nil).Run(func(args mock.Arguments) {
+			imported = args.Get(1).(*dns.Recordsets)
+		})
+
+		resourceName := "akamai_dns_zone.convert_test_zone"
+
+		// work around to skip Delete which fails intentionally
+		os.Setenv("DNS_ZONE_SKIP_DELETE", "")
+		defer os.Unsetenv("DNS_ZONE_SKIP_DELETE")
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:  func() { testAccPreCheck(t) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestResDnsZone/convert_secondary.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr(resourceName, "type", "SECONDARY"),
+							resource.TestCheckResourceAttr(resourceName, "masters.#", "1"),
+						),
+					},
+					{
+						Config: loadFixtureString("testdata/TestResDnsZone/convert_primary.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr(resourceName, "type", "PRIMARY"),
+							resource.TestCheckResourceAttr(resourceName, "masters.#", "0"),
+						),
+					},
+				},
+			})
+		})
+
+		// the zone is converted in place, and the transferred records are imported with a new SOA serial
+		client.AssertNumberOfCalls(t, "CreateZone", 1)
+		require.NotNil(t, updated)
+		assert.Equal(t, "primary", updated.Type)
+		assert.Empty(t, updated.Masters)
+		require.NotNil(t, imported)
+		assert.Contains(t, imported.Recordsets, live.Recordsets[2])
+		assert.Equal(t, []string{"ns1.example.net. hostmaster.convertexampleterraform.io. 2021010102 14400 7200 604800 1200"}, imported.Recordsets[0].Rdata)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("alias zone retargeted", func(t *testing.T) {
+		client := &mockdns{}
+		aliasZone := &dns.ZoneResponse{
+			ContractID:      "ctr1",
+			Zone:            "aliasexampleterraform.io",
+			Type:            "ALIAS",
+			Target:          "a.exampleterraform.io",
+			Comment:         "This is a test zone",
+			ActivationState: "ACTIVE",
+		}
+
+		getCall := client.On("GetZone",
+			mock.Anything, // ctx is irrelevant for this test
+			aliasZone.Zone,
+		).Return(nil, &dns.Error{
+			StatusCode: http.StatusNotFound,
+		})
+
+		client.On("CreateZone",
+			mock.Anything, // ctx is irrelevant for this test
+			mock.AnythingOfType("*dns.ZoneCreate"),
+			mock.AnythingOfType("dns.ZoneQueryString"),
+			true,
+		).Return(nil).Run(func(args mock.Arguments) {
+			getCall.ReturnArguments = mock.Arguments{aliasZone, nil}
+		})
+
+		client.On("UpdateZone",
+			mock.Anything, // ctx is irrelevant for this test
+			mock.AnythingOfType("*dns.ZoneCreate"),
+			mock.AnythingOfType("dns.ZoneQueryString"),
+		).Return(nil).Run(func(args mock.Arguments) {
+			aliasZone.Target = args.Get(1).(*dns.ZoneCreate).Target
+		}).Once()
+
+		client.On("GetZone",
+			mock.Anything, // ctx is irrelevant for this test
+			"b.exampleterraform.io",
+		).Return(&dns.ZoneResponse{Zone: "b.exampleterraform.io", Type: "PRIMARY"}, nil)
+
+		client.On("GetZone",
+			mock.Anything, // ctx is irrelevant for this test
+			"c.exampleterraform.io",
+		).Return(&dns.ZoneResponse{Zone: "c.exampleterraform.io", Type: "ALIAS", Target: "b.exampleterraform.io"}, nil)
+
+		resourceName := "akamai_dns_zone.alias_test_zone"
+
+		// work around to skip Delete which fails intentionally
+		os.Setenv("DNS_ZONE_SKIP_DELETE", "")
+		defer os.Unsetenv("DNS_ZONE_SKIP_DELETE")
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:  func() { testAccPreCheck(t) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestResDnsZone/alias_a.tf"),
+						Check:  resource.TestCheckResourceAttr(resourceName, "target", "a.exampleterraform.io"),
+					},
+					{
+						Config: loadFixtureString("testdata/TestResDnsZone/alias_b.tf"),
+						Check:  resource.TestCheckResourceAttr(resourceName, "target", "b.exampleterraform.io"),
+					},
+					{
+						Config:      loadFixtureString("testdata/TestResDnsZone/alias_c.tf"),
+						ExpectError: regexp.MustCompile(`is an\s+alias zone`),
+					},
+				},
+			})
+		})
+
+		client.AssertNumberOfCalls(t, "CreateZone", 1)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("secondary zone waits for first transfer", func(t *testing.T) {
+		ZoneTransferPollInterval = time.Millisecond
+		defer func() { ZoneTransferPollInterval = 10 * time.Second }()
+
+		client := &mockdns{}
+		transferClient := &mockzonetransfer{}
+		waitZone := &dns.ZoneResponse{
+			ContractID:      "ctr1",
+			Zone:            "waitexampleterraform.io",
+			Type:            "SECONDARY",
+			Masters:         []string{"192.0.2.1"},
+			Comment:         "This is a test zone",
+			ActivationState: "ACTIVE",
+		}
+
+		getCall := client.On("GetZone",
+			mock.Anything, // ctx is irrelevant for this test
+			waitZone.Zone,
+		).Return(nil, &dns.Error{
+			StatusCode: http.StatusNotFound,
+		})
+
+		client.On("CreateZone",
+			mock.Anything, // ctx is irrelevant for this test
+			mock.AnythingOfType("*dns.ZoneCreate"),
+			mock.AnythingOfType("dns.ZoneQueryString"),
+			true,
+		).Return(nil).Run(func(args mock.Arguments) {
+			getCall.ReturnArguments = mock.Arguments{waitZone, nil}
+		})
+
+		// the first attempt fails, the zone is created once the next one succeeds
+		transferClient.On("GetZonesTransferStatus",
+			mock.Anything, // ctx is irrelevant for this test
+			GetZonesTransferStatusRequest{Zones: []string{waitZone.Zone}},
+		).Return(&GetZonesTransferStatusResponse{Zones: []TransferStatus{{
+			Zone:               waitZone.Zone,
+			LastTransferResult: false,
+			LastTransferError:  "connection refused",
+		}}}, nil).Once()
+		transferClient.On("GetZonesTransferStatus",
+			mock.Anything, // ctx is irrelevant for this test
+			GetZonesTransferStatusRequest{Zones: []string{waitZone.Zone}},
+		).Return(&GetZonesTransferStatusResponse{Zones: []TransferStatus{{
+			Zone:               waitZone.Zone,
+			ZoneSerial:         2021010101,
+			LastTransferResult: true,
+		}}}, nil).Once()
+
+		resourceName := "akamai_dns_zone.wait_test_zone"
+
+		// work around to skip Delete which fails intentionally
+		os.Setenv("DNS_ZONE_SKIP_DELETE", "")
+		defer os.Unsetenv("DNS_ZONE_SKIP_DELETE")
+		useClient(client, func() {
+			useZoneTransferClient(transferClient, func() {
+				resource.UnitTest(t, resource.TestCase{
+					PreCheck:  func() { testAccPreCheck(t) },
+					Providers: testAccProviders,
+					Steps: []resource.TestStep{
+						{
+							Config: loadFixtureString("testdata/TestResDnsZone/wait_secondary.tf"),
+							Check:  resource.TestCheckResourceAttr(resourceName, "wait_for_first_transfer", "true"),
+						},
+					},
+				})
+			})
+		})
+
+		transferClient.AssertNumberOfCalls(t, "GetZonesTransferStatus", 2)
+		transferClient.AssertExpectations(t)
+		client.AssertExpectations(t)
+	})
+}
+
+func TestWaitForZoneTransfer(t *testing.T) {
+	ZoneTransferPollInterval = time.Millisecond
+	defer func() { ZoneTransferPollInterval = 10 * time.Second }()
+
+	transferClient := &mockzonetransfer{}
+	transferClient.On("GetZonesTransferStatus",
+		mock.Anything, // ctx is irrelevant for this test
+		GetZonesTransferStatusRequest{Zones: []string{"waitexampleterraform.io"}},
+	).Return(&GetZonesTransferStatusResponse{Zones: []TransferStatus{{
+		Zone:              "waitexampleterraform.io",
+		LastTransferError: "connection refused",
+	}}}, nil)
+
+	useZoneTransferClient(transferClient, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		err := waitForZoneTransfer(ctx, nil, "waitexampleterraform.io")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded), "want: %s; got: %s", context.DeadlineExceeded, err)
+		assert.Contains(t, err.Error(), "connection refused")
+	})
+}
+
+func TestConvertibleZoneTypes(t *testing.T) {
+	assert.True(t, convertibleZoneTypes("SECONDARY", "primary"))
+	assert.True(t, convertibleZoneTypes("PRIMARY", "SECONDARY"))
+	assert.True(t, convertibleZoneTypes("ALIAS", "alias"))
+	assert.False(t, convertibleZoneTypes("PRIMARY", "ALIAS"))
+	assert.False(t, convertibleZoneTypes("ALIAS", "SECONDARY"))
 }
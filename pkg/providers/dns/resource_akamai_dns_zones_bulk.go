@@ -0,0 +1,620 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	dns "github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/configdns"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	"github.com/apex/log"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var (
+	// BulkZonesPollInterval is the interval for polling the status of bulk zone create and delete requests
+	BulkZonesPollInterval = 10 * time.Second
+
+	// BulkZonesTimeout is the default timeout of bulk zone create and delete requests
+	BulkZonesTimeout = time.Minute * 60
+)
+
+const (
+	bulkZoneCreate = "create"
+	bulkZoneUpdate = "update"
+	bulkZoneDelete = "delete"
+
+	bulkZoneSuccess = "SUCCESS"
+	bulkZoneFailed  = "FAILED"
+)
+
+// bulkZoneResult is the outcome of a zone operation of a bulk request
+type bulkZoneResult struct {
+	Zone          string
+	Operation     string
+	Status        string
+	FailureReason string
+}
+
+func resourceDNSZonesBulk() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDNSZonesBulkCreate,
+		ReadContext:   resourceDNSZonesBulkRead,
+		UpdateContext: resourceDNSZonesBulkUpdate,
+		DeleteContext: resourceDNSZonesBulkDelete,
+		CustomizeDiff: customdiff.All(
+			akamai.EnforceChangeFreeze(""),
+			zonesBulkCustomizeDiff,
+		),
+		Timeouts: &schema.ResourceTimeout{
+			Default: &BulkZonesTimeout,
+		},
+		Schema: map[string]*schema.Schema{
+			akamai.IgnoreChangeFreezeKey: akamai.IgnoreChangeFreezeSchema(),
+			"contract": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: tools.FieldPrefixSuppress("ctr_"),
+			},
+			"group": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: tools.FieldPrefixSuppress("grp_"),
+			},
+			"bypass_safety_checks": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether zones are deleted even when Edge DNS safety checks fail",
+			},
+			"zone": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"zone": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"type": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validateZoneType,
+						},
+						"masters": {
+							Type:     schema.TypeSet,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Optional: true,
+							Set:      schema.HashString,
+						},
+						"comment": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "Managed by Terraform",
+						},
+						"target": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"end_customer_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"sign_and_serve": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
+			},
+			"results": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Outcome of every zone operation of the last apply",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"zone": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"operation": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"failure_reason": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceDNSZonesBulkCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("AkamaiDNS", "resourceDNSZonesBulkCreate")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	zones, err := expandBulkZones(d.Get("zone").(*schema.Set).List())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	creates := make([]*dns.ZoneCreate, 0, len(zones))
+	for _, name := range sortedBulkZoneNames(zones) {
+		creates = append(creates, zones[name])
+	}
+	logger.Infof("Zones Bulk Create of %d zones", len(creates))
+
+	requestID, results, err := createBulkZones(ctx, meta, bulkZoneQuery(d), creates, logger)
+	if err != nil {
+		return append(diag.Diagnostics{}, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Bulk zone create failure",
+			Detail:   err.Error(),
+		})
+	}
+	diags := bulkZoneDiagnostics(results)
+	if len(diags) == len(creates) {
+		// no zone was created, there is nothing to keep in the state
+		for i := range diags {
+			diags[i].Severity = diag.Error
+		}
+		return diags
+	}
+
+	d.SetId(requestID)
+	if err := d.Set("results", flattenBulkZoneResults(results)); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+	// zones that failed to be created are removed from the state by read, and are created again by the next apply
+	return append(diags, resourceDNSZonesBulkRead(ctx, d, meta)...)
+}
+
+func resourceDNSZonesBulkRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("AkamaiDNS", "resourceDNSZonesBulkRead")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	logger.WithField("id", d.Id()).Info("Zones Bulk Read")
+	contract := strings.TrimPrefix(d.Get("contract").(string), "ctr_")
+	list, err := inst.Client(meta).ListZones(ctx, dns.ZoneListQueryArgs{ContractIDs: contract, ShowAll: true})
+	if err != nil {
+		return append(diag.Diagnostics{}, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Zones read failure",
+			Detail:   err.Error(),
+		})
+	}
+	existing := make(map[string]*dns.ZoneResponse, len(list.Zones))
+	for _, zone := range list.Zones {
+		existing[normalizeRecordsetName(zone.Zone)] = zone
+	}
+
+	// a zone that failed to be created may exist in the contract without being managed by this resource, it must
+	// not be adopted or it would be deleted on destroy
+	failed := failedBulkCreates(d)
+	zones := make([]interface{}, 0, d.Get("zone").(*schema.Set).Len())
+	for _, z := range d.Get("zone").(*schema.Set).List() {
+		zone := z.(map[string]interface{})
+		if _, ok := failed[normalizeRecordsetName(zone["zone"].(string))]; ok {
+			logger.Warnf("Zone %s failed to be created, removing it from state", zone["zone"])
+			continue
+		}
+		resp, ok := existing[normalizeRecordsetName(zone["zone"].(string))]
+		if !ok {
+			logger.Warnf("Zone %s not found, removing it from state", zone["zone"])
+			continue
+		}
+		zones = append(zones, flattenBulkZone(zone, resp))
+	}
+	if len(zones) == 0 {
+		logger.Warn("No zone found, removing resource from state")
+		d.SetId("")
+		return nil
+	}
+	if err := d.Set("zone", zones); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+	if _, ok := d.GetOk("results"); !ok {
+		if err := d.Set("results", []interface{}{}); err != nil {
+			return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+		}
+	}
+	return nil
+}
+
+func resourceDNSZonesBulkUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("AkamaiDNS", "resourceDNSZonesBulkUpdate")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	logger.WithField("id", d.Id()).Info("Zones Bulk Update")
+	if !d.HasChange("zone") {
+		return resourceDNSZonesBulkRead(ctx, d, meta)
+	}
+
+	oldSet, newSet := d.GetChange("zone")
+	oldZones, err := expandBulkZones(oldSet.(*schema.Set).List())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	newZones, err := expandBulkZones(newSet.(*schema.Set).List())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var creates, updates []*dns.ZoneCreate
+	var deletes []string
+	for _, name := range sortedBulkZoneNames(newZones) {
+		zone, old := newZones[name], oldZones[name]
+		switch {
+		case old == nil:
+			creates = append(creates, zone)
+		case !strings.EqualFold(old.Type, zone.Type):
+			return diag.Errorf("type of zone %s can't be changed from %s to %s", zone.Zone, old.Type, zone.Type)
+		case !reflect.DeepEqual(old, zone):
+			updates = append(updates, zone)
+		}
+	}
+	for _, name := range sortedBulkZoneNames(oldZones) {
+		if _, ok := newZones[name]; !ok {
+			deletes = append(deletes, oldZones[name].Zone)
+		}
+	}
+
+	var results []bulkZoneResult
+	zones := newSet.(*schema.Set).List()
+	if len(deletes) > 0 {
+		deleteResults, err := deleteBulkZones(ctx, meta, deletes, d.Get("bypass_safety_checks").(bool), logger)
+		if err != nil {
+			return append(diag.Diagnostics{}, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Bulk zone delete failure",
+				Detail:   err.Error(),
+			})
+		}
+		results = append(results, deleteResults...)
+		// zones that failed to be deleted are kept in the state, and are deleted again by the next apply
+		for _, r := range deleteResults {
+			if r.Status == bulkZoneFailed {
+				zones = append(zones, bulkZoneElement(oldSet.(*schema.Set), r.Zone))
+			}
+		}
+	}
+	if len(creates) > 0 {
+		_, createResults, err := createBulkZones(ctx, meta, bulkZoneQuery(d), creates, logger)
+		if err != nil {
+			return append(diag.Diagnostics{}, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Bulk zone create failure",
+				Detail:   err.Error(),
+			})
+		}
+		results = append(results, createResults...)
+	}
+	for _, zone := range updates {
+		logger.Debugf("Updating zone %s", zone.Zone)
+		result := bulkZoneResult{Zone: zone.Zone, Operation: bulkZoneUpdate, Status: bulkZoneSuccess}
+		if err := inst.Client(meta).UpdateZone(ctx, zone, bulkZoneQuery(d)); err != nil {
+			result.Status, result.FailureReason = bulkZoneFailed, err.Error()
+		}
+		results = append(results, result)
+	}
+
+	if err := d.Set("zone", zones); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+	if err := d.Set("results", flattenBulkZoneResults(results)); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+	return append(bulkZoneDiagnostics(results), resourceDNSZonesBulkRead(ctx, d, meta)...)
+}
+
+func resourceDNSZonesBulkDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := akamai.EnforceChangeFreezeOnDelete(d, m, ""); err != nil {
+		return diag.FromErr(err)
+	}
+	meta := akamai.Meta(m)
+	logger := meta.Log("AkamaiDNS", "resourceDNSZonesBulkDelete")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	logger.WithField("id", d.Id()).Info("Zones Bulk Delete")
+	zoneSet := d.Get("zone").(*schema.Set)
+	zones, err := expandBulkZones(zoneSet.List())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// zones that failed to be created aren't managed by this resource, even if state wasn't refreshed
+	failed := failedBulkCreates(d)
+	deletes := make([]string, 0, len(zones))
+	for _, name := range sortedBulkZoneNames(zones) {
+		if _, ok := failed[normalizeRecordsetName(zones[name].Zone)]; !ok {
+			deletes = append(deletes, zones[name].Zone)
+		}
+	}
+	if len(deletes) == 0 {
+		d.SetId("")
+		return nil
+	}
+
+	results, err := deleteBulkZones(ctx, meta, deletes, d.Get("bypass_safety_checks").(bool), logger)
+	if err != nil {
+		return append(diag.Diagnostics{}, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Bulk zone delete failure",
+			Detail:   err.Error(),
+		})
+	}
+	diags := bulkZoneDiagnostics(results)
+	if len(diags) == 0 {
+		d.SetId("")
+		return nil
+	}
+
+	// only the zones that failed to be deleted are kept in the state
+	remaining := make([]interface{}, 0, len(diags))
+	for _, r := range results {
+		if r.Status == bulkZoneFailed {
+			remaining = append(remaining, bulkZoneElement(zoneSet, r.Zone))
+		}
+	}
+	if err := d.Set("zone", remaining); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+	if err := d.Set("results", flattenBulkZoneResults(results)); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+	for i := range diags {
+		diags[i].Severity = diag.Error
+	}
+	return diags
+}
+
+// createBulkZones submits a bulk create request for the zones and waits for its completion. It returns the request ID
+// and the outcome of every zone.
+func createBulkZones(ctx context.Context, meta akamai.OperationMeta, query dns.ZoneQueryString, zones []*dns.ZoneCreate, logger log.Interface) (string, []bulkZoneResult, error) {
+	client := inst.Client(meta)
+	request, err := client.CreateBulkZones(ctx, &dns.BulkZonesCreate{Zones: zones}, query)
+	if err != nil {
+		return "", nil, err
+	}
+	logger.Debugf("Waiting for bulk create request %s of %d zones", request.RequestId, len(zones))
+	if err := waitForBulkZones(ctx, request.RequestId, client.GetBulkZoneCreateStatus); err != nil {
+		return "", nil, err
+	}
+	result, err := client.GetBulkZoneCreateResult(ctx, request.RequestId)
+	if err != nil {
+		return "", nil, err
+	}
+
+	results := make([]bulkZoneResult, 0, len(zones))
+	for _, zone := range result.SuccessfullyCreatedZones {
+		results = append(results, bulkZoneResult{Zone: zone, Operation: bulkZoneCreate, Status: bulkZoneSuccess})
+	}
+	for _, failed := range result.FailedZones {
+		results = append(results, bulkZoneResult{Zone: failed.Zone, Operation: bulkZoneCreate, Status: bulkZoneFailed, FailureReason: failed.FailureReason})
+	}
+	return request.RequestId, results, nil
+}
+
+// deleteBulkZones submits a bulk delete request for the zones and waits for its completion. It returns the outcome of
+// every zone.
+func deleteBulkZones(ctx context.Context, meta akamai.OperationMeta, zones []string, bypassSafetyChecks bool, logger log.Interface) ([]bulkZoneResult, error) {
+	client := inst.Client(meta)
+	request, err := client.DeleteBulkZones(ctx, &dns.ZoneNameListResponse{Zones: zones}, bypassSafetyChecks)
+	if err != nil {
+		return nil, err
+	}
+	logger.Debugf("Waiting for bulk delete request %s of %d zones", request.RequestId, len(zones))
+	if err := waitForBulkZones(ctx, request.RequestId, client.GetBulkZoneDeleteStatus); err != nil {
+		return nil, err
+	}
+	result, err := client.GetBulkZoneDeleteResult(ctx, request.RequestId)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]bulkZoneResult, 0, len(zones))
+	for _, zone := range result.SuccessfullyDeletedZones {
+		results = append(results, bulkZoneResult{Zone: zone, Operation: bulkZoneDelete, Status: bulkZoneSuccess})
+	}
+	for _, failed := range result.FailedZones {
+		results = append(results, bulkZoneResult{Zone: failed.Zone, Operation: bulkZoneDelete, Status: bulkZoneFailed, FailureReason: failed.FailureReason})
+	}
+	return results, nil
+}
+
+func waitForBulkZones(ctx context.Context, requestID string, getStatus func(context.Context, string) (*dns.BulkStatusResponse, error)) error {
+	for {
+		status, err := getStatus(ctx, requestID)
+		if err != nil {
+			return err
+		}
+		if status.IsComplete {
+			return nil
+		}
+		select {
+		case <-time.After(BulkZonesPollInterval):
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for bulk zone request %s: %w", requestID, ctx.Err())
+		}
+	}
+}
+
+// zonesBulkCustomizeDiff fails the plan when the type of a zone is changed, which the zones can't be updated to.
+// Zones with a type known only at apply time are checked by Update.
+func zonesBulkCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChange("zone") {
+		return nil
+	}
+	oldSet, newSet := d.GetChange("zone")
+	oldTypes := make(map[string]string)
+	for _, e := range oldSet.(*schema.Set).List() {
+		element := e.(map[string]interface{})
+		oldTypes[normalizeRecordsetName(element["zone"].(string))] = element["type"].(string)
+	}
+	for _, e := range newSet.(*schema.Set).List() {
+		element := e.(map[string]interface{})
+		oldType, ok := oldTypes[normalizeRecordsetName(element["zone"].(string))]
+		newType := element["type"].(string)
+		if ok && newType != "" && !strings.EqualFold(oldType, newType) {
+			return fmt.Errorf("type of zone %s can't be changed from %s to %s", element["zone"], strings.ToUpper(oldType), strings.ToUpper(newType))
+		}
+	}
+	return nil
+}
+
+func bulkZoneQuery(d *schema.ResourceData) dns.ZoneQueryString {
+	return dns.ZoneQueryString{
+		Contract: strings.TrimPrefix(d.Get("contract").(string), "ctr_"),
+		Group:    strings.TrimPrefix(d.Get("group").(string), "grp_"),
+	}
+}
+
+// expandBulkZones returns the zones of the set by normalized zone name, checking the settings required by their type
+func expandBulkZones(elements []interface{}) (map[string]*dns.ZoneCreate, error) {
+	zones := make(map[string]*dns.ZoneCreate, len(elements))
+	for _, e := range elements {
+		element := e.(map[string]interface{})
+		zone := &dns.ZoneCreate{
+			Zone:          element["zone"].(string),
+			Type:          strings.ToUpper(element["type"].(string)),
+			Masters:       []string{},
+			Comment:       element["comment"].(string),
+			Target:        element["target"].(string),
+			EndCustomerID: element["end_customer_id"].(string),
+			SignAndServe:  element["sign_and_serve"].(bool),
+		}
+		if masters, ok := element["masters"].(*schema.Set); ok {
+			for _, master := range masters.List() {
+				zone.Masters = append(zone.Masters, master.(string))
+			}
+		}
+		sort.Strings(zone.Masters)
+
+		switch {
+		case zone.Type == "SECONDARY" && len(zone.Masters) == 0:
+			return nil, fmt.Errorf("DNS Secondary zone requires masters for zone %v", zone.Zone)
+		case zone.Type == "ALIAS" && zone.Target == "":
+			return nil, fmt.Errorf("DNS Alias zone requires target for zone %v", zone.Zone)
+		case zone.Type != "SECONDARY" && len(zone.Masters) > 0:
+			return nil, fmt.Errorf("masters is only valid for secondary zones, zone %v", zone.Zone)
+		}
+		name := normalizeRecordsetName(zone.Zone)
+		if _, ok := zones[name]; ok {
+			return nil, fmt.Errorf("zone %s is configured more than once", zone.Zone)
+		}
+		zones[name] = zone
+	}
+	return zones, nil
+}
+
+func sortedBulkZoneNames(zones map[string]*dns.ZoneCreate) []string {
+	names := make([]string, 0, len(zones))
+	for name := range zones {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// bulkZoneElement returns the element of the set with the given zone name
+func bulkZoneElement(set *schema.Set, zone string) interface{} {
+	for _, e := range set.List() {
+		if normalizeRecordsetName(e.(map[string]interface{})["zone"].(string)) == normalizeRecordsetName(zone) {
+			return e
+		}
+	}
+	return map[string]interface{}{"zone": zone}
+}
+
+// flattenBulkZone refreshes the zone element with the zone settings, the configured zone name and type case are kept
+func flattenBulkZone(element map[string]interface{}, zone *dns.ZoneResponse) map[string]interface{} {
+	zoneType := zone.Type
+	if strings.EqualFold(zoneType, element["type"].(string)) {
+		zoneType = element["type"].(string)
+	}
+	return map[string]interface{}{
+		"zone":            element["zone"],
+		"type":            zoneType,
+		"masters":         zone.Masters,
+		"comment":         zone.Comment,
+		"target":          zone.Target,
+		"end_customer_id": zone.EndCustomerID,
+		"sign_and_serve":  zone.SignAndServe,
+	}
+}
+
+func flattenBulkZoneResults(results []bulkZoneResult) []interface{} {
+	flattened := make([]interface{}, 0, len(results))
+	for _, r := range results {
+		flattened = append(flattened, map[string]interface{}{
+			"zone":           r.Zone,
+			"operation":      r.Operation,
+			"status":         r.Status,
+			"failure_reason": r.FailureReason,
+		})
+	}
+	return flattened
+}
+
+// failedBulkCreates returns the normalized names of the zones that failed to be created by the last apply
+func failedBulkCreates(d *schema.ResourceData) map[string]struct{} {
+	failed := make(map[string]struct{})
+	for _, r := range d.Get("results").([]interface{}) {
+		result := r.(map[string]interface{})
+		if result["operation"] == bulkZoneCreate && result["status"] == bulkZoneFailed {
+			failed[normalizeRecordsetName(result["zone"].(string))] = struct{}{}
+		}
+	}
+	return failed
+}
+
+// bulkZoneDiagnostics returns a warning for every failed zone operation
+func bulkZoneDiagnostics(results []bulkZoneResult) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, r := range results {
+		if r.Status != bulkZoneFailed {
+			continue
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Zone %s %s failure", r.Zone, r.Operation),
+			Detail:   r.FailureReason,
+		})
+	}
+	return diags
+}
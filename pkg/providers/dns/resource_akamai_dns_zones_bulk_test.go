@@ -0,0 +1,257 @@
+package dns
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	dns "github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/configdns"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestResDnsZonesBulk(t *testing.T) {
+	query := dns.ZoneQueryString{Contract: "1-3CV382", Group: "100"}
+
+	// mockBulkZones creates and deletes the zones of the bulk requests in the mocked contract, the zones named in
+	// failures fail with the given reason
+	mockBulkZones := func(client *mockdns, live *dns.ZoneListResponse, failures map[string]string, creates *[]*dns.BulkZonesCreate, deletes *[]*dns.ZoneNameListResponse) {
+		client.On("ListZones",
+			mock.Anything, // ctx is irrelevant for this test
+			dns.ZoneListQueryArgs{ContractIDs: "1-3CV382", ShowAll: true},
+		).Return(live, nil)
+
+		createResult := &dns.BulkCreateResultResponse{}
+		client.On("CreateBulkZones",
+			mock.Anything, // ctx is irrelevant for this test
+			mock.AnythingOfType("*dns.BulkZonesCreate"),
+			query,
+		).Return(&dns.BulkZonesResponse{RequestId: "create-request"}, nil).Run(func(args mock.Arguments) {
+			request := args.Get(1).(*dns.BulkZonesCreate)
+			*creates = append(*creates, request)
+			*createResult = dns.BulkCreateResultResponse{RequestId: "create-request"}
+			for _, zone := range request.Zones {
+				if reason, ok := failures[zone.Zone]; ok {
+					createResult.FailedZones = append(createResult.FailedZones, &dns.BulkFailedZone{Zone: zone.Zone, FailureReason: reason})
+					continue
+				}
+				createResult.SuccessfullyCreatedZones = append(createResult.SuccessfullyCreatedZones, zone.Zone)
+				live.Zones = append(live.Zones, &dns.ZoneResponse{Zone: zone.Zone, Type: zone.Type, Masters: zone.Masters, Comment: zone.Comment})
+			}
+		})
+		client.On("GetBulkZoneCreateStatus",
+			mock.Anything, // ctx is irrelevant for this test
+			"create-request",
+		).Return(&dns.BulkStatusResponse{RequestId: "create-request"}, nil).Once()
+		client.On("GetBulkZoneCreateStatus",
+			mock.Anything, // ctx is irrelevant for this test
+			"create-request",
+		).Return(&dns.BulkStatusResponse{RequestId: "create-request", IsComplete: true}, nil)
+		client.On("GetBulkZoneCreateResult",
+			mock.Anything, // ctx is irrelevant for this test
+			"create-request",
+		).Return(createResult, nil)
+
+		deleteResult := &dns.BulkDeleteResultResponse{}
+		client.On("DeleteBulkZones",
+			mock.Anything, // ctx is irrelevant for this test
+			mock.AnythingOfType("*dns.ZoneNameListResponse"),
+			[]bool{false},
+		).Return(&dns.BulkZonesResponse{RequestId: "delete-request"}, nil).Run(func(args mock.Arguments) {
+			request := args.Get(1).(*dns.ZoneNameListResponse)
+			*deletes = append(*deletes, request)
+			*deleteResult = dns.BulkDeleteResultResponse{RequestId: "delete-request", SuccessfullyDeletedZones: request.Zones}
+			zones := live.Zones[:0]
+			for _, zone := range live.Zones {
+				deleted := false
+				for _, name := range request.Zones {
+					deleted = deleted || name == zone.Zone
+				}
+				if !deleted {
+					zones = append(zones, zone)
+				}
+			}
+			live.Zones = zones
+		})
+		client.On("GetBulkZoneDeleteStatus",
+			mock.Anything, // ctx is irrelevant for this test
+			"delete-request",
+		).Return(&dns.BulkStatusResponse{RequestId: "delete-request", IsComplete: true}, nil)
+		client.On("GetBulkZoneDeleteResult",
+			mock.Anything, // ctx is irrelevant for this test
+			"delete-request",
+		).Return(deleteResult, nil)
+	}
+
+	BulkZonesPollInterval = time.Millisecond
+	defer func() { BulkZonesPollInterval = 10 * time.Second }()
+
+	t.Run("lifecycle test", func(t *testing.T) {
+		client := &mockdns{}
+		live := &dns.ZoneListResponse{}
+		var creates []*dns.BulkZonesCreate
+		var deletes []*dns.ZoneNameListResponse
+		mockBulkZones(client, live, nil, &creates, &deletes)
+		client.On("UpdateZone",
+			mock.Anything, // ctx is irrelevant for this test
+			&dns.ZoneCreate{Zone: "a.exampleterraform.io", Type: "PRIMARY", Masters: []string{}, Comment: "brand a"},
+			query,
+		).Return(nil).Run(func(args mock.Arguments) {
+			live.Zones[0].Comment = args.Get(1).(*dns.ZoneCreate).Comment
+		})
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:  func() { testAccPreCheck(t) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestResDnsZonesBulk/create.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_dns_zones_bulk.test", "id", "create-request"),
+							resource.TestCheckResourceAttr("akamai_dns_zones_bulk.test", "zone.#", "3"),
+							resource.TestCheckResourceAttr("akamai_dns_zones_bulk.test", "results.#", "3"),
+							resource.TestCheckResourceAttr("akamai_dns_zones_bulk.test", "results.0.zone", "a.exampleterraform.io"),
+							resource.TestCheckResourceAttr("akamai_dns_zones_bulk.test", "results.0.operation", "create"),
+							resource.TestCheckResourceAttr("akamai_dns_zones_bulk.test", "results.0.status", "SUCCESS"),
+						),
+					},
+					{
+						Config: loadFixtureString("testdata/TestResDnsZonesBulk/update.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_dns_zones_bulk.test", "zone.#", "3"),
+							resource.TestCheckResourceAttr("akamai_dns_zones_bulk.test", "results.#", "3"),
+							resource.TestCheckResourceAttr("akamai_dns_zones_bulk.test", "results.0.zone", "c.exampleterraform.io"),
+							resource.TestCheckResourceAttr("akamai_dns_zones_bulk.test", "results.0.operation", "delete"),
+							resource.TestCheckResourceAttr("akamai_dns_zones_bulk.test", "results.1.zone", "d.exampleterraform.io"),
+							resource.TestCheckResourceAttr("akamai_dns_zones_bulk.test", "results.1.operation", "create"),
+							resource.TestCheckResourceAttr("akamai_dns_zones_bulk.test", "results.2.zone", "a.exampleterraform.io"),
+							resource.TestCheckResourceAttr("akamai_dns_zones_bulk.test", "results.2.operation", "update"),
+						),
+					},
+				},
+			})
+		})
+
+		// the zones are created and deleted by bulk requests only
+		assert.Len(t, creates, 2)
+		assert.Len(t, creates[0].Zones, 3)
+		assert.Equal(t, &dns.ZoneCreate{Zone: "c.exampleterraform.io", Type: "SECONDARY", Masters: []string{"192.0.2.1"}, Comment: "Managed by Terraform"}, creates[0].Zones[2])
+		assert.Len(t, deletes, 2)
+		assert.Equal(t, []string{"c.exampleterraform.io"}, deletes[0].Zones)
+		assert.Equal(t, []string{"a.exampleterraform.io", "b.exampleterraform.io", "d.exampleterraform.io"}, deletes[1].Zones)
+		assert.Empty(t, live.Zones)
+		client.AssertNotCalled(t, "CreateZone", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("zone create failure", func(t *testing.T) {
+		client := &mockdns{}
+		live := &dns.ZoneListResponse{}
+		var creates []*dns.BulkZonesCreate
+		var deletes []*dns.ZoneNameListResponse
+		failures := map[string]string{"b.exampleterraform.io": "ZONE_ALREADY_EXISTS"}
+		mockBulkZones(client, live, failures, &creates, &deletes)
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:  func() { testAccPreCheck(t) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestResDnsZonesBulk/create.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_dns_zones_bulk.test", "results.#", "3"),
+							resource.TestCheckResourceAttr("akamai_dns_zones_bulk.test", "results.2.zone", "b.exampleterraform.io"),
+							resource.TestCheckResourceAttr("akamai_dns_zones_bulk.test", "results.2.status", "FAILED"),
+							resource.TestCheckResourceAttr("akamai_dns_zones_bulk.test", "results.2.failure_reason", "ZONE_ALREADY_EXISTS"),
+						),
+						// the failed zone is removed from the state on refresh, and created again by the next apply
+						ExpectNonEmptyPlan: true,
+					},
+					{
+						PreConfig: func() { delete(failures, "b.exampleterraform.io") },
+						Config:    loadFixtureString("testdata/TestResDnsZonesBulk/create.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_dns_zones_bulk.test", "zone.#", "3"),
+							resource.TestCheckResourceAttr("akamai_dns_zones_bulk.test", "results.#", "1"),
+							resource.TestCheckResourceAttr("akamai_dns_zones_bulk.test", "results.0.zone", "b.exampleterraform.io"),
+							resource.TestCheckResourceAttr("akamai_dns_zones_bulk.test", "results.0.status", "SUCCESS"),
+						),
+					},
+				},
+			})
+		})
+
+		assert.Len(t, creates, 2)
+		assert.Equal(t, []*dns.ZoneCreate{{Zone: "b.exampleterraform.io", Type: "PRIMARY", Masters: []string{}, Comment: "Managed by Terraform"}}, creates[1].Zones)
+		assert.Len(t, deletes, 1)
+		assert.Equal(t, "a.exampleterraform.io b.exampleterraform.io c.exampleterraform.io", strings.Join(deletes[0].Zones, " "))
+		client.AssertExpectations(t)
+	})
+
+	t.Run("existing zone not adopted", func(t *testing.T) {
+		client := &mockdns{}
+		live := &dns.ZoneListResponse{Zones: []*dns.ZoneResponse{{Zone: "b.exampleterraform.io", Type: "PRIMARY"}}}
+		var creates []*dns.BulkZonesCreate
+		var deletes []*dns.ZoneNameListResponse
+		failures := map[string]string{"b.exampleterraform.io": "ZONE_ALREADY_EXISTS"}
+		mockBulkZones(client, live, failures, &creates, &deletes)
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:  func() { testAccPreCheck(t) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestResDnsZonesBulk/create.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_dns_zones_bulk.test", "results.2.zone", "b.exampleterraform.io"),
+							resource.TestCheckResourceAttr("akamai_dns_zones_bulk.test", "results.2.status", "FAILED"),
+						),
+						// the zone found in the contract isn't managed by the resource, its create is planned again
+						ExpectNonEmptyPlan: true,
+					},
+				},
+			})
+		})
+
+		// the zone that already existed is kept on destroy
+		assert.Len(t, deletes, 1)
+		assert.Equal(t, "a.exampleterraform.io c.exampleterraform.io", strings.Join(deletes[0].Zones, " "))
+		assert.Len(t, live.Zones, 1)
+		assert.Equal(t, "b.exampleterraform.io", live.Zones[0].Zone)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("zone type change fails the plan", func(t *testing.T) {
+		client := &mockdns{}
+		live := &dns.ZoneListResponse{}
+		var creates []*dns.BulkZonesCreate
+		var deletes []*dns.ZoneNameListResponse
+		mockBulkZones(client, live, nil, &creates, &deletes)
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:  func() { testAccPreCheck(t) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestResDnsZonesBulk/create.tf"),
+					},
+					{
+						Config:      loadFixtureString("testdata/TestResDnsZonesBulk/change_type.tf"),
+						PlanOnly:    true,
+						ExpectError: regexp.MustCompile(`type of zone b.exampleterraform.io can't be changed from PRIMARY to ALIAS`),
+					},
+				},
+			})
+		})
+
+		assert.Len(t, creates, 1)
+		client.AssertNotCalled(t, "UpdateZone", mock.Anything, mock.Anything, mock.Anything)
+		client.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,17 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+data "akamai_dns_unmanaged_records" "test" {
+  zone = "exampleterraform.io"
+
+  managed {
+    name = "www.exampleterraform.io"
+    type = "A"
+  }
+
+  managed {
+    name = "MAIL.exampleterraform.io."
+    type = "mx"
+  }
+}
@@ -0,0 +1,8 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+data "akamai_dns_zone_aliases" "test" {
+  zone     = "exampleterraform.io"
+  contract = "ctr_1-3CV382"
+}
@@ -0,0 +1,7 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+data "akamai_dns_zone_dnssec" "test" {
+  zone = "exampleterraform.io"
+}
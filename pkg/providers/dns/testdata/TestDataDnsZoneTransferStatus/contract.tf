@@ -0,0 +1,7 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+data "akamai_dns_zone_transfer_status" "test" {
+  contract = "ctr_1-3CV382"
+}
@@ -0,0 +1,12 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+resource "akamai_dns_record" "test" {
+  zone             = "exampleterraform.io"
+  name             = "www.exampleterraform.io"
+  recordtype       = "A"
+  ttl              = 300
+  target           = ["192.0.2.1"]
+  change_list_mode = true
+}
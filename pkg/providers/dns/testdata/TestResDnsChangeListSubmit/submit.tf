@@ -0,0 +1,30 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+resource "akamai_dns_recordsets" "test" {
+  zone             = "exampleterraform.io"
+  change_list_mode = true
+
+  recordset {
+    name  = "www.exampleterraform.io"
+    type  = "A"
+    ttl   = 300
+    rdata = ["192.0.2.1", "192.0.2.2"]
+  }
+
+  recordset {
+    name  = "ftp.exampleterraform.io"
+    type  = "CNAME"
+    ttl   = 300
+    rdata = ["www.exampleterraform.io."]
+  }
+}
+
+resource "akamai_dns_changelist_submit" "test" {
+  zone = akamai_dns_recordsets.test.zone
+
+  triggers = {
+    recordsets = jsonencode(akamai_dns_recordsets.test.recordset)
+  }
+}
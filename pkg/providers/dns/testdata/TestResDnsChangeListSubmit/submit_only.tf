@@ -0,0 +1,7 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+resource "akamai_dns_changelist_submit" "test" {
+  zone = "exampleterraform.io"
+}
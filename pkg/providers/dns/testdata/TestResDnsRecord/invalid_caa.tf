@@ -0,0 +1,12 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+resource "akamai_dns_record" "caa_record" {
+  zone       = "exampleterraform.io"
+  name       = "exampleterraform.io"
+  recordtype = "CAA"
+  active     = true
+  ttl        = 300
+  target     = ["0 issue \"ca.example.net\"", "0 iodef ftp://exampleterraform.io"]
+}
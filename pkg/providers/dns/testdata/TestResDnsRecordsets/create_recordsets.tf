@@ -0,0 +1,21 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+resource "akamai_dns_recordsets" "test" {
+  zone = "exampleterraform.io"
+
+  recordset {
+    name  = "www.exampleterraform.io"
+    type  = "A"
+    ttl   = 300
+    rdata = ["192.0.2.1", "192.0.2.2"]
+  }
+
+  recordset {
+    name  = "ftp.exampleterraform.io"
+    type  = "CNAME"
+    ttl   = 300
+    rdata = ["www.exampleterraform.io."]
+  }
+}
@@ -0,0 +1,15 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+resource "akamai_dns_recordsets" "test" {
+  zone        = "exampleterraform.io"
+  name_prefix = "api"
+
+  recordset {
+    name  = "www.exampleterraform.io"
+    type  = "A"
+    ttl   = 300
+    rdata = ["192.0.2.1"]
+  }
+}
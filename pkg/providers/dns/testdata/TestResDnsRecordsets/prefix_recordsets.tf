@@ -0,0 +1,15 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+resource "akamai_dns_recordsets" "test" {
+  zone        = "exampleterraform.io"
+  name_prefix = "api"
+
+  recordset {
+    name  = "api.exampleterraform.io"
+    type  = "TXT"
+    ttl   = 60
+    rdata = ["v=spf1 -all"]
+  }
+}
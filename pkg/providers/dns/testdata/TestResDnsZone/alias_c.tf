@@ -0,0 +1,12 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+resource "akamai_dns_zone" "alias_test_zone" {
+  contract = "ctr1"
+  group    = "grp1"
+  zone     = "aliasexampleterraform.io"
+  type     = "alias"
+  target   = "c.exampleterraform.io"
+  comment  = "This is a test zone"
+}
@@ -0,0 +1,11 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+resource "akamai_dns_zone" "convert_test_zone" {
+  contract = "ctr1"
+  group    = "grp1"
+  zone     = "convertexampleterraform.io"
+  type     = "primary"
+  comment  = "This is a test zone"
+}
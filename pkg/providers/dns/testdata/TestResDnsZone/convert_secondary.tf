@@ -0,0 +1,12 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+resource "akamai_dns_zone" "convert_test_zone" {
+  contract = "ctr1"
+  group    = "grp1"
+  zone     = "convertexampleterraform.io"
+  masters  = ["192.0.2.1"]
+  type     = "secondary"
+  comment  = "This is a test zone"
+}
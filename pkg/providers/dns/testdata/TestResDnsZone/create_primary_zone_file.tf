@@ -0,0 +1,17 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+resource "akamai_dns_zone" "primary_test_zone" {
+  contract  = "ctr1"
+  zone      = "primaryexampleterraform.io"
+  type      = "primary"
+  comment   = "This is a test primary zone"
+  group     = "grp1"
+  zone_file = <<-EOT
+    $TTL 3600
+    @   86400 IN SOA a1-1.akam.net. hostmaster.primaryexampleterraform.io. 1 14400 7200 604800 1200
+    @   86400 IN NS  a1-1.akam.net.
+    www 300   IN A   192.0.2.1
+  EOT
+}
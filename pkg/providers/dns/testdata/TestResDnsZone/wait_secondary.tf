@@ -0,0 +1,13 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+resource "akamai_dns_zone" "wait_test_zone" {
+  contract                = "ctr1"
+  group                   = "grp1"
+  zone                    = "waitexampleterraform.io"
+  masters                 = ["192.0.2.1"]
+  type                    = "secondary"
+  comment                 = "This is a test zone"
+  wait_for_first_transfer = true
+}
@@ -0,0 +1,25 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+resource "akamai_dns_zones_bulk" "test" {
+  contract = "ctr_1-3CV382"
+  group    = "grp_100"
+
+  zone {
+    zone = "a.exampleterraform.io"
+    type = "primary"
+  }
+
+  zone {
+    zone   = "b.exampleterraform.io"
+    type   = "alias"
+    target = "a.exampleterraform.io"
+  }
+
+  zone {
+    zone    = "c.exampleterraform.io"
+    type    = "secondary"
+    masters = ["192.0.2.1"]
+  }
+}
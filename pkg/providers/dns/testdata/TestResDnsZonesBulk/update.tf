@@ -0,0 +1,24 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+resource "akamai_dns_zones_bulk" "test" {
+  contract = "ctr_1-3CV382"
+  group    = "grp_100"
+
+  zone {
+    zone    = "a.exampleterraform.io"
+    type    = "primary"
+    comment = "brand a"
+  }
+
+  zone {
+    zone = "b.exampleterraform.io"
+    type = "primary"
+  }
+
+  zone {
+    zone = "d.exampleterraform.io"
+    type = "primary"
+  }
+}
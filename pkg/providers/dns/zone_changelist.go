@@ -0,0 +1,241 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/apex/log"
+
+	dns "github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/configdns"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+)
+
+var (
+	// ErrChangeListConflict is returned when the zone changed after changes were staged in its change list
+	ErrChangeListConflict = errors.New("change list conflict")
+
+	// changeListLocks serializes the staging and submission of the change list of a zone
+	changeListLocks = newDNSLocks()
+
+	// changeListZones keeps the zones where this apply staged changes. Changes staged by an earlier apply and never
+	// submitted are kept, and planned by the akamai_dns_changelist_submit of the zone, unless the zone changed since.
+	changeListZones = struct {
+		sync.Mutex
+		opened map[string]bool
+	}{opened: make(map[string]bool)}
+)
+
+// getZoneChangeList returns the change list of the zone, nil when the zone isn't in change list mode
+func getZoneChangeList(ctx context.Context, meta akamai.OperationMeta, zone string) (*dns.ChangeListResponse, error) {
+	changeList, err := inst.Client(meta).GetChangeList(ctx, zone)
+	if err != nil {
+		var apiError *dns.Error
+		if errors.As(err, &apiError) && apiError.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return changeList, nil
+}
+
+// reopenZoneChangeList replaces the change list of the zone by one holding its current records
+func reopenZoneChangeList(ctx context.Context, meta akamai.OperationMeta, zone string) error {
+	return inst.ChangeListClient(meta).CreateChangeList(ctx, CreateChangeListRequest{
+		Zone:      zone,
+		Overwrite: ChangeListOverwriteAny,
+	})
+}
+
+// stageRecordsets stages the given recordsets, and the removal of the recordsets matched by remove, in the change list
+// of the zone. It returns false when the resource isn't configured in change list mode, and the changes must be made
+// live. A change list opened outside of Terraform doesn't redirect the changes of other resources.
+func stageRecordsets(ctx context.Context, meta akamai.OperationMeta, zone string, changeListMode bool, recordsets []dns.Recordset, remove func(dns.Recordset) bool, logger log.Interface) (bool, error) {
+	if !changeListMode {
+		return false, nil
+	}
+	defer changeListLocks.lockZone(zone)()
+
+	changeList, err := getZoneChangeList(ctx, meta, zone)
+	if err != nil {
+		return true, err
+	}
+	if changeList == nil {
+		return true, fmt.Errorf("zone %s has no change list, enable change_list_mode on the zone", zone)
+	}
+
+	key := normalizeRecordsetName(zone)
+	changeListZones.Lock()
+	opened := changeListZones.opened[key]
+	changeListZones.Unlock()
+	switch {
+	case changeList.Stale && !opened:
+		// the changes staged by an earlier apply can't be submitted anymore
+		logger.Debugf("Reopening stale change list of zone %s", zone)
+		if err := reopenZoneChangeList(ctx, meta, zone); err != nil {
+			return true, err
+		}
+	case changeList.Stale:
+		return true, fmt.Errorf("%w: zone %s changed after changes were staged in its change list", ErrChangeListConflict, zone)
+	}
+	changeListZones.Lock()
+	changeListZones.opened[key] = true
+	changeListZones.Unlock()
+
+	staged, err := inst.ChangeListClient(meta).GetChangeListRecordsets(ctx, zone)
+	if err != nil {
+		return true, err
+	}
+	current := make(map[string]dns.Recordset, len(staged.Recordsets))
+	for _, rs := range staged.Recordsets {
+		current[recordsetTypeKey(rs)] = rs
+	}
+
+	var changes []RecordsetChange
+	desired := make(map[string]struct{}, len(recordsets))
+	for _, rs := range recordsets {
+		desired[recordsetTypeKey(rs)] = struct{}{}
+		existing, ok := current[recordsetTypeKey(rs)]
+		switch {
+		case !ok:
+			changes = append(changes, RecordsetChange{Name: rs.Name, Type: rs.Type, Op: RecordsetChangeAdd, TTL: rs.TTL, Rdata: rs.Rdata})
+		case recordsetKey(existing) != recordsetKey(rs):
+			changes = append(changes, RecordsetChange{Name: rs.Name, Type: rs.Type, Op: RecordsetChangeEdit, TTL: rs.TTL, Rdata: rs.Rdata})
+		}
+	}
+	for _, rs := range staged.Recordsets {
+		if _, ok := desired[recordsetTypeKey(rs)]; !ok && remove != nil && remove(rs) {
+			changes = append(changes, RecordsetChange{Name: rs.Name, Type: rs.Type, Op: RecordsetChangeDelete})
+		}
+	}
+
+	for _, change := range changes {
+		logger.Debugf("Staging %s of %s %s in change list of zone %s", change.Op, change.Name, change.Type, zone)
+		if err := inst.ChangeListClient(meta).AppendChangeListChange(ctx, AppendChangeListChangeRequest{Zone: zone, Change: change}); err != nil {
+			return true, err
+		}
+	}
+	return true, nil
+}
+
+// stageDNSRecord stages the change, or the removal, of the recordset of the record in the change list of the zone. It
+// returns false when the record isn't in change list mode.
+func stageDNSRecord(ctx context.Context, meta akamai.OperationMeta, zone string, changeListMode bool, rec *dns.RecordBody, remove bool, logger log.Interface) (bool, error) {
+	recordset := dns.Recordset{Name: rec.Name, Type: rec.RecordType, TTL: rec.TTL, Rdata: rec.Target}
+	if remove {
+		return stageRecordsets(ctx, meta, zone, changeListMode, nil, func(rs dns.Recordset) bool {
+			return recordsetTypeKey(rs) == recordsetTypeKey(recordset)
+		}, logger)
+	}
+	return stageRecordsets(ctx, meta, zone, changeListMode, []dns.Recordset{recordset}, nil, logger)
+}
+
+// getZoneRecordsets returns the recordsets of the zone, with the changes staged in its change list applied in change
+// list mode. The live recordsets are returned when the change list was submitted or discarded outside of Terraform.
+func getZoneRecordsets(ctx context.Context, meta akamai.OperationMeta, zone string, changeListMode bool) (*dns.RecordSetResponse, error) {
+	if changeListMode {
+		changeList, err := getZoneChangeList(ctx, meta, zone)
+		if err != nil {
+			return nil, err
+		}
+		if changeList != nil {
+			return inst.ChangeListClient(meta).GetChangeListRecordsets(ctx, zone)
+		}
+	}
+	return inst.Client(meta).GetRecordsets(ctx, zone, dns.RecordsetQueryArgs{ShowAll: true})
+}
+
+// getDNSRecord returns the recordset of the record, as staged in the change list of the zone in change list mode. A
+// recordset missing from the change list is reported as not found, like a missing live recordset.
+func getDNSRecord(ctx context.Context, meta akamai.OperationMeta, zone string, changeListMode bool, host, recordType string) (*dns.RecordBody, error) {
+	if !changeListMode {
+		return inst.Client(meta).GetRecord(ctx, zone, host, recordType)
+	}
+	recordsets, err := getZoneRecordsets(ctx, meta, zone, true)
+	if err != nil {
+		return nil, err
+	}
+	key := recordsetTypeKey(dns.Recordset{Name: host, Type: recordType})
+	for _, rs := range recordsets.Recordsets {
+		if recordsetTypeKey(rs) == key {
+			return &dns.RecordBody{Name: rs.Name, RecordType: rs.Type, TTL: rs.TTL, Target: rs.Rdata}, nil
+		}
+	}
+	return nil, &dns.Error{
+		Type:       "not-found",
+		Title:      "Not Found",
+		Detail:     fmt.Sprintf("recordset %s %s not found in zone %s", host, recordType, zone),
+		StatusCode: http.StatusNotFound,
+	}
+}
+
+// submitZoneChangeList submits the changes staged in the change list of the zone, by this apply or an earlier one, and
+// opens a new change list. It returns the submitted record changes.
+func submitZoneChangeList(ctx context.Context, meta akamai.OperationMeta, zone string, logger log.Interface) ([]string, error) {
+	defer changeListLocks.lockZone(zone)()
+
+	changeList, err := getZoneChangeList(ctx, meta, zone)
+	if err != nil {
+		return nil, err
+	}
+	if changeList == nil {
+		return nil, fmt.Errorf("zone %s has no change list, enable change_list_mode on the zone", zone)
+	}
+
+	changeListZones.Lock()
+	delete(changeListZones.opened, normalizeRecordsetName(zone))
+	changeListZones.Unlock()
+	if changeList.Stale {
+		// the staged changes can't be submitted, they are staged again by the next apply
+		if err := reopenZoneChangeList(ctx, meta, zone); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: zone %s changed after changes were staged in its change list, the staged changes were discarded", ErrChangeListConflict, zone)
+	}
+
+	changes, err := stagedZoneChanges(ctx, meta, zone)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		logger.Debugf("No change staged in zone %s", zone)
+		return changes, nil
+	}
+	logger.Debugf("Submitting change list of zone %s:\n%s", zone, strings.Join(changes, "\n"))
+	if err := inst.Client(meta).SubmitChangelist(ctx, &dns.ZoneCreate{Zone: zone}); err != nil {
+		return nil, err
+	}
+	if err := inst.ChangeListClient(meta).CreateChangeList(ctx, CreateChangeListRequest{Zone: zone}); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// stagedZoneChanges returns the record changes between the zone and its change list
+func stagedZoneChanges(ctx context.Context, meta akamai.OperationMeta, zone string) ([]string, error) {
+	live, err := inst.Client(meta).GetRecordsets(ctx, zone, dns.RecordsetQueryArgs{ShowAll: true})
+	if err != nil {
+		return nil, err
+	}
+	staged, err := inst.ChangeListClient(meta).GetChangeListRecordsets(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+	var liveRecords, stagedRecords []zoneFileRecord
+	for _, rs := range live.Recordsets {
+		liveRecords = append(liveRecords, recordsetRecords(rs)...)
+	}
+	for _, rs := range staged.Recordsets {
+		stagedRecords = append(stagedRecords, recordsetRecords(rs)...)
+	}
+	return diffRecords(liveRecords, stagedRecords), nil
+}
+
+// recordsetTypeKey identifies a recordset by its name and type
+func recordsetTypeKey(rs dns.Recordset) string {
+	return normalizeRecordsetName(rs.Name) + " " + strings.ToUpper(rs.Type)
+}
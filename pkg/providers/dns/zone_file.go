@@ -0,0 +1,306 @@
+package dns
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// zoneFileRecord is a single resource record of an RFC 1035 master file
+type zoneFileRecord struct {
+	Name  string
+	TTL   int
+	Type  string
+	Rdata string
+}
+
+// String returns the record in master file presentation format, with a fully qualified owner name
+func (r zoneFileRecord) String() string {
+	return fmt.Sprintf("%s %d IN %s %s", r.Name, r.TTL, r.Type, r.Rdata)
+}
+
+// key returns the record without the parts expected to change on every zone update, used to compare zone files.
+// The SOA serial is bumped by Edge DNS on each change and is ignored.
+func (r zoneFileRecord) key() string {
+	if r.Type == "SOA" {
+		fields := strings.Fields(r.Rdata)
+		if len(fields) == 7 {
+			fields[2] = "0"
+			return zoneFileRecord{Name: r.Name, TTL: r.TTL, Type: r.Type, Rdata: strings.Join(fields, " ")}.String()
+		}
+	}
+	return zoneFileRecord{Name: r.Name, TTL: r.TTL, Type: r.Type, Rdata: normalizeRdata(r.Type, r.Rdata)}.String()
+}
+
+// zoneFileRdataNames are the positions of the domain names in the rdata of the record types that have them
+var zoneFileRdataNames = map[string][]int{
+	"CNAME": {0},
+	"NS":    {0},
+	"PTR":   {0},
+	"MX":    {1},
+	"AFSDB": {1},
+	"SRV":   {3},
+	"SOA":   {0, 1},
+}
+
+var zoneFileClasses = map[string]struct{}{"IN": {}, "CH": {}, "HS": {}, "CS": {}}
+
+// parseZoneFile parses an RFC 1035 master file. Relative owner names, and relative domain names in the rdata, are
+// qualified with the zone name, or the last $ORIGIN directive. Records are returned in file order.
+func parseZoneFile(zone, content string) ([]zoneFileRecord, error) {
+	origin := fqdn(zone)
+	var records []zoneFileRecord
+	var previousName string
+	defaultTTL := -1
+	previousTTL := -1
+
+	entries, err := zoneFileEntries(content)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		tokens := entry.tokens
+		switch strings.ToUpper(tokens[0]) {
+		case "$ORIGIN":
+			if len(tokens) != 2 {
+				return nil, fmt.Errorf("line %d: $ORIGIN requires a domain name", entry.line)
+			}
+			origin = qualifyName(tokens[1], origin)
+			continue
+		case "$TTL":
+			if len(tokens) != 2 {
+				return nil, fmt.Errorf("line %d: $TTL requires a value", entry.line)
+			}
+			ttl, err := strconv.Atoi(tokens[1])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid $TTL %q", entry.line, tokens[1])
+			}
+			defaultTTL = ttl
+			continue
+		case "$INCLUDE", "$GENERATE":
+			return nil, fmt.Errorf("line %d: %s directive is not supported", entry.line, tokens[0])
+		}
+
+		record := zoneFileRecord{TTL: -1}
+		if entry.inheritsName {
+			if previousName == "" {
+				return nil, fmt.Errorf("line %d: record has no owner name", entry.line)
+			}
+			record.Name = previousName
+		} else {
+			record.Name = qualifyName(tokens[0], origin)
+			tokens = tokens[1:]
+		}
+
+		// TTL and class are both optional and may appear in any order before the type
+		for i := 0; i < 2 && len(tokens) > 0; i++ {
+			if _, ok := zoneFileClasses[strings.ToUpper(tokens[0])]; ok {
+				tokens = tokens[1:]
+				continue
+			}
+			if ttl, err := strconv.Atoi(tokens[0]); err == nil && record.TTL < 0 {
+				record.TTL = ttl
+				tokens = tokens[1:]
+			}
+		}
+		if len(tokens) < 2 {
+			return nil, fmt.Errorf("line %d: record %s has no type or data", entry.line, record.Name)
+		}
+		record.Type = strings.ToUpper(tokens[0])
+		record.Rdata = qualifyRdata(record.Type, tokens[1:], origin)
+
+		switch {
+		case record.TTL >= 0:
+		case defaultTTL >= 0:
+			record.TTL = defaultTTL
+		case previousTTL >= 0:
+			record.TTL = previousTTL
+		default:
+			return nil, fmt.Errorf("line %d: record %s has no TTL", entry.line, record.Name)
+		}
+		previousName = record.Name
+		previousTTL = record.TTL
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+type zoneFileEntry struct {
+	line         int
+	inheritsName bool
+	tokens       []string
+}
+
+// zoneFileEntries splits the master file into entries, joining parenthesized lines and dropping comments
+func zoneFileEntries(content string) ([]zoneFileEntry, error) {
+	var entries []zoneFileEntry
+	var current *zoneFileEntry
+	depth := 0
+
+	for i, line := range strings.Split(content, "\n") {
+		tokens, opened, err := zoneFileTokens(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		if current == nil {
+			if len(tokens) == 0 && opened == 0 {
+				continue
+			}
+			current = &zoneFileEntry{
+				line:         i + 1,
+				inheritsName: line != "" && (line[0] == ' ' || line[0] == '\t'),
+			}
+		}
+		current.tokens = append(current.tokens, tokens...)
+		if depth += opened; depth < 0 {
+			return nil, fmt.Errorf("line %d: unbalanced parentheses", i+1)
+		}
+		if depth == 0 {
+			if len(current.tokens) > 0 {
+				entries = append(entries, *current)
+			}
+			current = nil
+		}
+	}
+	if current != nil {
+		return nil, fmt.Errorf("line %d: unbalanced parentheses", current.line)
+	}
+	return entries, nil
+}
+
+// zoneFileTokens splits a master file line into tokens, keeping quoted strings whole, and returns the balance of
+// opened parentheses
+func zoneFileTokens(line string) ([]string, int, error) {
+	var tokens []string
+	var token strings.Builder
+	opened := 0
+	inToken, quoted := false, false
+
+	flush := func() {
+		if inToken {
+			tokens = append(tokens, token.String())
+			token.Reset()
+			inToken = false
+		}
+	}
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '\\' && i+1 < len(line):
+			token.WriteByte(c)
+			token.WriteByte(line[i+1])
+			inToken = true
+			i++
+		case quoted:
+			token.WriteByte(c)
+			if c == '"' {
+				quoted = false
+			}
+		case c == '"':
+			token.WriteByte(c)
+			inToken, quoted = true, true
+		case c == ';':
+			flush()
+			return tokens, opened, nil
+		case c == '(' || c == ')':
+			flush()
+			if c == '(' {
+				opened++
+			} else {
+				opened--
+			}
+		case c == ' ' || c == '\t' || c == '\r':
+			flush()
+		default:
+			token.WriteByte(c)
+			inToken = true
+		}
+	}
+	if quoted {
+		return nil, 0, fmt.Errorf("unterminated quoted string")
+	}
+	flush()
+	return tokens, opened, nil
+}
+
+// qualifyName returns the fully qualified, lower case, form of a master file name
+func qualifyName(name, origin string) string {
+	switch {
+	case name == "@":
+		return origin
+	case strings.HasSuffix(name, "."):
+		return strings.ToLower(name)
+	default:
+		return strings.ToLower(name + "." + origin)
+	}
+}
+
+// qualifyRdata returns the rdata tokens joined by a space, with the domain names of the record type qualified
+func qualifyRdata(recordType string, tokens []string, origin string) string {
+	rdata := append([]string(nil), tokens...)
+	for _, i := range zoneFileRdataNames[recordType] {
+		if i < len(rdata) {
+			rdata[i] = qualifyName(rdata[i], origin)
+		}
+	}
+	return strings.Join(rdata, " ")
+}
+
+func fqdn(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, ".") + ".")
+}
+
+// diffZoneFiles returns the records removed from, and added to, the old zone file, as "- record" and "+ record" lines
+func diffZoneFiles(zone, oldContent, newContent string) ([]string, error) {
+	oldRecords, err := parseZoneFile(zone, oldContent)
+	if err != nil {
+		return nil, err
+	}
+	newRecords, err := parseZoneFile(zone, newContent)
+	if err != nil {
+		return nil, err
+	}
+	return diffRecords(oldRecords, newRecords), nil
+}
+
+// diffRecords returns the records removed from, and added to, the old records, as "- record" and "+ record" lines
+// sorted by record
+func diffRecords(oldRecords, newRecords []zoneFileRecord) []string {
+	count := make(map[string]int)
+	for _, r := range oldRecords {
+		count[r.key()]--
+	}
+	for _, r := range newRecords {
+		count[r.key()]++
+	}
+	changes := []string{}
+	for _, r := range oldRecords {
+		if count[r.key()] < 0 {
+			changes = append(changes, "- "+r.String())
+			count[r.key()]++
+		}
+	}
+	for _, r := range newRecords {
+		if count[r.key()] > 0 {
+			changes = append(changes, "+ "+r.String())
+			count[r.key()]--
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i][2:] < changes[j][2:]
+	})
+	return changes
+}
+
+// zoneFileSuppress suppresses differences in formatting, comments and record order between zone files, as well as
+// SOA serial changes
+func zoneFileSuppress(_, old, new string, d *schema.ResourceData) bool {
+	if old == "" || new == "" {
+		return old == new
+	}
+	changes, err := diffZoneFiles(d.Get("zone").(string), old, new)
+	return err == nil && len(changes) == 0
+}
@@ -0,0 +1,135 @@
+package dns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testZoneFile = `$TTL 3600
+; example zone
+@	86400 IN SOA a1-1.akam.net. hostmaster.example.com. (
+		2021010101 ; serial
+		14400 7200 604800 1200 )
+@	86400 IN NS a1-1.akam.net.
+	IN NS a2-2.akam.net.
+www	300 IN A 192.0.2.1
+ftp IN CNAME www
+api.example.com. IN 60 TXT "v=spf1 -all" "semi;colon"
+$ORIGIN sub.example.com.
+mail	MX 10 mx.example.com.
+`
+
+func TestParseZoneFile(t *testing.T) {
+	records, err := parseZoneFile("example.com", testZoneFile)
+	require.NoError(t, err)
+
+	var lines []string
+	for _, r := range records {
+		lines = append(lines, r.String())
+	}
+	assert.Equal(t, []string{
+		"example.com. 86400 IN SOA a1-1.akam.net. hostmaster.example.com. 2021010101 14400 7200 604800 1200",
+		"example.com. 86400 IN NS a1-1.akam.net.",
+		"example.com. 3600 IN NS a2-2.akam.net.",
+		"www.example.com. 300 IN A 192.0.2.1",
+		"ftp.example.com. 3600 IN CNAME www.example.com.",
+		`api.example.com. 60 IN TXT "v=spf1 -all" "semi;colon"`,
+		"mail.sub.example.com. 3600 IN MX 10 mx.example.com.",
+	}, lines)
+}
+
+func TestParseZoneFileErrors(t *testing.T) {
+	tests := map[string]struct {
+		content string
+		withErr string
+	}{
+		"unbalanced parentheses": {
+			content: "@ 300 IN SOA a. b. ( 1 2 3 4 5\n",
+			withErr: "line 1: unbalanced parentheses",
+		},
+		"unterminated quote": {
+			content: "www 300 IN TXT \"abc\n",
+			withErr: "line 1: unterminated quoted string",
+		},
+		"missing ttl": {
+			content: "www IN A 192.0.2.1\n",
+			withErr: "line 1: record www.example.com. has no TTL",
+		},
+		"missing owner": {
+			content: "  300 IN A 192.0.2.1\n",
+			withErr: "line 1: record has no owner name",
+		},
+		"missing data": {
+			content: "$TTL 300\nwww IN A\n",
+			withErr: "line 2: record www.example.com. has no type or data",
+		},
+		"include": {
+			content: "$INCLUDE other.zone\n",
+			withErr: "line 1: $INCLUDE directive is not supported",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseZoneFile("example.com", test.content)
+			assert.EqualError(t, err, test.withErr)
+		})
+	}
+}
+
+func TestDiffZoneFiles(t *testing.T) {
+	reordered := `$ORIGIN example.com.
+www.example.com.	300	IN	A	192.0.2.1
+@ 86400 IN SOA a1-1.akam.net. hostmaster.example.com. 2021010155 14400 7200 604800 1200
+example.com. 86400 IN NS a1-1.akam.net.
+example.com. 3600 IN NS a2-2.akam.net.
+ftp 3600 IN CNAME www
+api 60 IN TXT "v=spf1 -all" "semi;colon"
+mail.sub 3600 IN MX 10 mx.example.com.
+`
+	changes, err := diffZoneFiles("example.com", testZoneFile, reordered)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	changed := reordered + "www 300 IN A 192.0.2.2\n"
+	changes, err = diffZoneFiles("example.com", testZoneFile, changed+"files 300 IN A 192.0.2.3\n")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"+ files.example.com. 300 IN A 192.0.2.3",
+		"+ www.example.com. 300 IN A 192.0.2.2",
+	}, changes)
+
+	changes, err = diffZoneFiles("example.com", changed, testZoneFile)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"- www.example.com. 300 IN A 192.0.2.2"}, changes)
+}
+
+func TestDiffZoneFilesRelativeNames(t *testing.T) {
+	relative := `$TTL 300
+@ IN NS ns1
+www IN CNAME web
+@ IN MX 10 mail
+_sip._tcp IN SRV 0 5 5060 sip
+$ORIGIN 2.0.192.in-addr.arpa.
+1 IN PTR www.example.com.
+`
+	exported := `example.com. 300 IN NS ns1.example.com.
+www.example.com. 300 IN CNAME Web.Example.com.
+example.com. 300 IN MX 10 mail.example.com.
+_sip._tcp.example.com. 300 IN SRV 0 5 5060 sip.example.com.
+1.2.0.192.in-addr.arpa. 300 IN PTR www.example.com.
+`
+	records, err := parseZoneFile("example.com", relative)
+	require.NoError(t, err)
+	assert.Equal(t, "www.example.com. 300 IN CNAME web.example.com.", records[1].String())
+	assert.Equal(t, "_sip._tcp.example.com. 300 IN SRV 0 5 5060 sip.example.com.", records[3].String())
+
+	changes, err := diffZoneFiles("example.com", exported, relative)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	changes, err = diffZoneFiles("example.com", exported, relative+"$ORIGIN example.com.\nftp IN CNAME www\n")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"+ ftp.example.com. 300 IN CNAME www.example.com."}, changes)
+}
@@ -0,0 +1,93 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	dns "github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/configdns"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// ZoneTransfers contains the Edge DNS operations on the zone transfers of secondary zones, which the configdns
+	// client doesn't provide
+	// See: https://developer.akamai.com/api/cloud_security/edge_dns_zone_management/v2.html#zonetransferstatus
+	ZoneTransfers interface {
+		// GetZonesTransferStatus returns the status of the last transfer of the secondary zones from their masters
+		//
+		// See: https://developer.akamai.com/api/cloud_security/edge_dns_zone_management/v2.html#postzonestransferstatus
+		GetZonesTransferStatus(context.Context, GetZonesTransferStatusRequest) (*GetZonesTransferStatusResponse, error)
+	}
+
+	zoneTransferClient struct {
+		session.Session
+	}
+
+	// GetZonesTransferStatusRequest contains the secondary zones whose transfer status is returned
+	GetZonesTransferStatusRequest struct {
+		Zones []string `json:"zones"`
+	}
+
+	// GetZonesTransferStatusResponse contains the transfer status of the requested zones
+	GetZonesTransferStatusResponse struct {
+		Zones []TransferStatus `json:"zones"`
+	}
+
+	// TransferStatus is the status of the last transfer of a secondary zone
+	TransferStatus struct {
+		Zone                    string   `json:"zone"`
+		MasterServers           []string `json:"masterServers"`
+		ZoneSerial              int      `json:"zoneSerial"`
+		LastTransferResult      bool     `json:"lastTransferResult"`
+		LastTransferError       string   `json:"lastTransferError"`
+		LastTransferTime        string   `json:"lastTransferTime"`
+		LastTransferAttemptTime string   `json:"lastTransferAttemptTime"`
+		LastNotifyTime          string   `json:"lastNotifyTime"`
+	}
+)
+
+var (
+	// ErrGetZonesTransferStatus represents error when fetching the transfer status of zones fails
+	ErrGetZonesTransferStatus = errors.New("fetching zones transfer status")
+)
+
+// NewZoneTransferClient returns a ZoneTransfers client using the given session
+func NewZoneTransferClient(sess session.Session) ZoneTransfers {
+	return &zoneTransferClient{Session: sess}
+}
+
+// Validate validates GetZonesTransferStatusRequest
+func (r GetZonesTransferStatusRequest) Validate() error {
+	return validation.Errors{
+		"Zones": validation.Validate(r.Zones, validation.Required),
+	}.Filter()
+}
+
+func (c *zoneTransferClient) GetZonesTransferStatus(ctx context.Context, params GetZonesTransferStatusRequest) (*GetZonesTransferStatusResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetZonesTransferStatus, dns.ErrStructValidation, err)
+	}
+
+	logger := c.Log(ctx)
+	logger.Debug("GetZonesTransferStatus")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/config-dns/v2/zones/zone-transfer-status", nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetZonesTransferStatus, err)
+	}
+
+	var rval GetZonesTransferStatusResponse
+	resp, err := c.Exec(req, &rval, params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetZonesTransferStatus, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetZonesTransferStatus, parseDNSError(c.Session, resp))
+	}
+
+	return &rval, nil
+}
@@ -0,0 +1,103 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	dns "github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/configdns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockzonetransfer struct {
+	mock.Mock
+}
+
+func (c *mockzonetransfer) GetZonesTransferStatus(ctx context.Context, r GetZonesTransferStatusRequest) (*GetZonesTransferStatusResponse, error) {
+	args := c.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*GetZonesTransferStatusResponse), args.Error(1)
+}
+
+func TestGetZonesTransferStatus(t *testing.T) {
+	tests := map[string]struct {
+		request          GetZonesTransferStatusRequest
+		responseStatus   int
+		responseBody     string
+		expectedResponse *GetZonesTransferStatusResponse
+		withError        func(*testing.T, error)
+	}{
+		"200 OK": {
+			request:        GetZonesTransferStatusRequest{Zones: []string{"example.com"}},
+			responseStatus: http.StatusOK,
+			responseBody: `{"zones": [{
+				"zone": "example.com",
+				"masterServers": ["1.2.3.4"],
+				"zoneSerial": 2021010101,
+				"lastTransferResult": false,
+				"lastTransferError": "connection refused",
+				"lastTransferTime": "2021-01-01T00:00:00Z",
+				"lastTransferAttemptTime": "2021-01-02T00:00:00Z",
+				"lastNotifyTime": "2021-01-01T00:00:00Z"
+			}]}`,
+			expectedResponse: &GetZonesTransferStatusResponse{Zones: []TransferStatus{{
+				Zone:                    "example.com",
+				MasterServers:           []string{"1.2.3.4"},
+				ZoneSerial:              2021010101,
+				LastTransferResult:      false,
+				LastTransferError:       "connection refused",
+				LastTransferTime:        "2021-01-01T00:00:00Z",
+				LastTransferAttemptTime: "2021-01-02T00:00:00Z",
+				LastNotifyTime:          "2021-01-01T00:00:00Z",
+			}}},
+		},
+		"validation error": {
+			request: GetZonesTransferStatusRequest{},
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, dns.ErrStructValidation), "want: %s; got: %s", dns.ErrStructValidation, err)
+			},
+		},
+		"404 not found": {
+			request:        GetZonesTransferStatusRequest{Zones: []string{"example.com"}},
+			responseStatus: http.StatusNotFound,
+			responseBody:   `{"type": "not-found", "title": "Not Found", "status": 404}`,
+			withError: func(t *testing.T, err error) {
+				want := &dns.Error{Type: "not-found", Title: "Not Found", StatusCode: http.StatusNotFound}
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/config-dns/v2/zones/zone-transfer-status", r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				require.NoError(t, err)
+				assert.JSONEq(t, `{"zones": ["example.com"]}`, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := NewZoneTransferClient(mockAPISession(t, mockServer))
+			result, err := client.GetZonesTransferStatus(context.Background(), test.request)
+			if test.withError != nil {
+				test.withError(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
@@ -23,7 +23,9 @@ func resourceGTMv1ASmap() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceGTMv1ASmapImport,
 		},
+		CustomizeDiff: akamai.EnforceChangeFreeze(""),
 		Schema: map[string]*schema.Schema{
+			akamai.IgnoreChangeFreezeKey: akamai.IgnoreChangeFreezeSchema(),
 			"domain": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -347,6 +349,9 @@ func resourceGTMv1ASmapImport(d *schema.ResourceData, m interface{}) ([]*schema.
 
 // Delete GTM ASmap.
 func resourceGTMv1ASmapDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := akamai.EnforceChangeFreezeOnDelete(d, m, ""); err != nil {
+		return diag.FromErr(err)
+	}
 	meta := akamai.Meta(m)
 	logger := meta.Log("Akamai GTM", "resourceGTMv1ASmapDelete")
 	// create a context with logging for api calls
@@ -22,7 +22,9 @@ func resourceGTMv1Cidrmap() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceGTMv1CidrMapImport,
 		},
+		CustomizeDiff: akamai.EnforceChangeFreeze(""),
 		Schema: map[string]*schema.Schema{
+			akamai.IgnoreChangeFreezeKey: akamai.IgnoreChangeFreezeSchema(),
 			"domain": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -302,6 +304,9 @@ func resourceGTMv1CidrMapImport(d *schema.ResourceData, m interface{}) ([]*schem
 
 // Delete GTM CidrMap.
 func resourceGTMv1CidrMapDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := akamai.EnforceChangeFreezeOnDelete(d, m, ""); err != nil {
+		return diag.FromErr(err)
+	}
 	meta := akamai.Meta(m)
 	logger := meta.Log("Akamai GTM", "resourceGTMCidrMapDelete")
 	// create a context with logging for api calls
@@ -27,7 +27,9 @@ func resourceGTMv1Datacenter() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceGTMv1DatacenterImport,
 		},
+		CustomizeDiff: akamai.EnforceChangeFreeze(""),
 		Schema: map[string]*schema.Schema{
+			akamai.IgnoreChangeFreezeKey: akamai.IgnoreChangeFreezeSchema(),
 			"domain": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -383,6 +385,9 @@ func resourceGTMv1DatacenterImport(d *schema.ResourceData, m interface{}) ([]*sc
 
 // Delete GTM Datacenter.
 func resourceGTMv1DatacenterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := akamai.EnforceChangeFreezeOnDelete(d, m, ""); err != nil {
+		return diag.FromErr(err)
+	}
 	meta := akamai.Meta(m)
 	logger := meta.Log("Akamai GTM", "resourceGTMv1DatacenterDelete")
 	// create a context with logging for api calls
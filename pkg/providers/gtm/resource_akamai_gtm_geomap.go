@@ -22,7 +22,9 @@ func resourceGTMv1Geomap() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceGTMv1GeomapImport,
 		},
+		CustomizeDiff: akamai.EnforceChangeFreeze(""),
 		Schema: map[string]*schema.Schema{
+			akamai.IgnoreChangeFreezeKey: akamai.IgnoreChangeFreezeSchema(),
 			"domain": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -312,6 +314,9 @@ func resourceGTMv1GeomapImport(d *schema.ResourceData, m interface{}) ([]*schema
 
 // Delete GTM GeoMap.
 func resourceGTMv1GeomapDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := akamai.EnforceChangeFreezeOnDelete(d, m, ""); err != nil {
+		return diag.FromErr(err)
+	}
 	meta := akamai.Meta(m)
 	logger := meta.Log("Akamai GTM", "resourceGTMv1GeomapDelete")
 	// create a context with logging for api calls
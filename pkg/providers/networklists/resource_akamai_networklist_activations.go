@@ -20,7 +20,9 @@ func resourceActivations() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceActivationsCreate,
 		ReadContext:   resourceActivationsRead,
+		UpdateContext: resourceActivationsUpdate,
 		DeleteContext: resourceActivationsDelete,
+		CustomizeDiff: akamai.EnforceChangeFreeze("network"),
 		Schema: map[string]*schema.Schema{
 			"network_list_id": {
 				Type:     schema.TypeString,
@@ -55,6 +57,7 @@ func resourceActivations() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			akamai.IgnoreChangeFreezeKey: akamai.IgnoreChangeFreezeSchema(),
 		},
 	}
 }
@@ -152,6 +155,11 @@ func resourceActivationsCreate(ctx context.Context, d *schema.ResourceData, m in
 	return resourceActivationsRead(ctx, d, m)
 }
 
+// resourceActivationsUpdate only handles changes of ignore_change_freeze, all other arguments force a new activation
+func resourceActivationsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourceActivationsRead(ctx, d, m)
+}
+
 func resourceActivationsDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	meta := akamai.Meta(m)
 	logger := meta.Log("NETWORKLIST", "resourceActivationsRemove")
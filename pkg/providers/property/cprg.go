@@ -0,0 +1,158 @@
+package property
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// CPRG contains the CP Code and Reporting Group API operations used to update CP codes, PAPI can only
+	// create them
+	// See: https://developer.akamai.com/api/core_features/cp_codes_reporting_groups/v1.html
+	CPRG interface {
+		// GetCPCode fetches the details of a CP code
+		//
+		// See: https://developer.akamai.com/api/core_features/cp_codes_reporting_groups/v1.html#getcpcode
+		GetCPCode(context.Context, GetCPCodeRequest) (*CPCodeDetail, error)
+
+		// UpdateCPCode updates the name, time zone and purgeability of a CP code
+		//
+		// See: https://developer.akamai.com/api/core_features/cp_codes_reporting_groups/v1.html#putcpcode
+		UpdateCPCode(context.Context, UpdateCPCodeRequest) (*CPCodeDetail, error)
+	}
+
+	cprg struct {
+		session.Session
+	}
+
+	// GetCPCodeRequest contains the ID of a CP code to fetch
+	GetCPCodeRequest struct {
+		CPCodeID int
+	}
+
+	// UpdateCPCodeRequest contains the ID of a CP code and its new details
+	UpdateCPCodeRequest struct {
+		CPCodeID int
+		CPCode   CPCodeDetail
+	}
+
+	// CPCodeDetail is a CP code as represented by the CP Code and Reporting Group API
+	CPCodeDetail struct {
+		CPCodeID         int              `json:"cpcodeId,omitempty"`
+		CPCodeName       string           `json:"cpcodeName"`
+		Purgeable        bool             `json:"purgeable"`
+		AccountID        string           `json:"accountId,omitempty"`
+		DefaultTimeZone  string           `json:"defaultTimezone,omitempty"`
+		OverrideTimeZone *CPCodeTimeZone  `json:"overrideTimezone,omitempty"`
+		Type             string           `json:"type,omitempty"`
+		Contracts        []CPCodeContract `json:"contracts"`
+		Products         []CPCodeProduct  `json:"products"`
+	}
+
+	// CPCodeTimeZone is the time zone used for the reports of a CP code
+	CPCodeTimeZone struct {
+		TimeZoneID    string `json:"timezoneId"`
+		TimeZoneValue string `json:"timezoneValue,omitempty"`
+	}
+
+	// CPCodeContract is a contract a CP code belongs to
+	CPCodeContract struct {
+		ContractID string `json:"contractId"`
+		Status     string `json:"status,omitempty"`
+	}
+
+	// CPCodeProduct is a product a CP code is used for
+	CPCodeProduct struct {
+		ProductID   string `json:"productId"`
+		ProductName string `json:"productName,omitempty"`
+	}
+)
+
+var (
+	// ErrGetCPRGCPCode represents error when fetching a CP code from the CP Code and Reporting Group API fails
+	ErrGetCPRGCPCode = errors.New("fetching CP code details")
+	// ErrUpdateCPRGCPCode represents error when updating a CP code fails
+	ErrUpdateCPRGCPCode = errors.New("updating CP code")
+)
+
+// NewCPRGClient returns a CPRG client using the given session
+func NewCPRGClient(sess session.Session) CPRG {
+	return &cprg{Session: sess}
+}
+
+// Validate validates GetCPCodeRequest
+func (r GetCPCodeRequest) Validate() error {
+	return validation.Errors{
+		"CPCodeID": validation.Validate(r.CPCodeID, validation.Required),
+	}.Filter()
+}
+
+// Validate validates UpdateCPCodeRequest
+func (r UpdateCPCodeRequest) Validate() error {
+	return validation.Errors{
+		"CPCodeID":          validation.Validate(r.CPCodeID, validation.Required),
+		"CPCode.CPCodeName": validation.Validate(r.CPCode.CPCodeName, validation.Required),
+		"CPCode.Contracts":  validation.Validate(r.CPCode.Contracts, validation.Required),
+		"CPCode.Products":   validation.Validate(r.CPCode.Products, validation.Required),
+	}.Filter()
+}
+
+func (c *cprg) GetCPCode(ctx context.Context, params GetCPCodeRequest) (*CPCodeDetail, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetCPRGCPCode, papi.ErrStructValidation, err)
+	}
+
+	logger := c.Log(ctx)
+	logger.Debug("GetCPCode")
+
+	uri := fmt.Sprintf("/cprg/v1/cpcodes/%d", params.CPCodeID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetCPRGCPCode, err)
+	}
+
+	var rval CPCodeDetail
+	resp, err := c.Exec(req, &rval)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetCPRGCPCode, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetCPRGCPCode, parseAPIError(c.Session, resp))
+	}
+
+	return &rval, nil
+}
+
+func (c *cprg) UpdateCPCode(ctx context.Context, params UpdateCPCodeRequest) (*CPCodeDetail, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrUpdateCPRGCPCode, papi.ErrStructValidation, err)
+	}
+
+	logger := c.Log(ctx)
+	logger.Debug("UpdateCPCode")
+
+	uri := fmt.Sprintf("/cprg/v1/cpcodes/%d", params.CPCodeID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrUpdateCPRGCPCode, err)
+	}
+
+	var rval CPCodeDetail
+	resp, err := c.Exec(req, &rval, params.CPCode)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrUpdateCPRGCPCode, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrUpdateCPRGCPCode, parseAPIError(c.Session, resp))
+	}
+
+	return &rval, nil
+}
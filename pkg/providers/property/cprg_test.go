@@ -0,0 +1,151 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockcprg struct {
+	mock.Mock
+}
+
+func (c *mockcprg) GetCPCode(ctx context.Context, r GetCPCodeRequest) (*CPCodeDetail, error) {
+	args := c.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*CPCodeDetail), args.Error(1)
+}
+
+func (c *mockcprg) UpdateCPCode(ctx context.Context, r UpdateCPCodeRequest) (*CPCodeDetail, error) {
+	args := c.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*CPCodeDetail), args.Error(1)
+}
+
+func TestGetCPRGCPCode(t *testing.T) {
+	tests := map[string]struct {
+		request          GetCPCodeRequest
+		responseStatus   int
+		responseBody     string
+		expectedResponse *CPCodeDetail
+		withError        func(*testing.T, error)
+	}{
+		"200 OK": {
+			request:        GetCPCodeRequest{CPCodeID: 123},
+			responseStatus: http.StatusOK,
+			responseBody: `{
+				"cpcodeId": 123,
+				"cpcodeName": "test cpcode",
+				"purgeable": true,
+				"accountId": "act_1",
+				"defaultTimezone": "GMT 0 (Greenwich Mean Time)",
+				"overrideTimezone": {"timezoneId": "1", "timezoneValue": "GMT + 1"},
+				"type": "Regular",
+				"contracts": [{"contractId": "1-ABC", "status": "ongoing"}],
+				"products": [{"productId": "Web_Accel", "productName": "Web Application Accelerator"}]
+			}`,
+			expectedResponse: &CPCodeDetail{
+				CPCodeID:         123,
+				CPCodeName:       "test cpcode",
+				Purgeable:        true,
+				AccountID:        "act_1",
+				DefaultTimeZone:  "GMT 0 (Greenwich Mean Time)",
+				OverrideTimeZone: &CPCodeTimeZone{TimeZoneID: "1", TimeZoneValue: "GMT + 1"},
+				Type:             "Regular",
+				Contracts:        []CPCodeContract{{ContractID: "1-ABC", Status: "ongoing"}},
+				Products:         []CPCodeProduct{{ProductID: "Web_Accel", ProductName: "Web Application Accelerator"}},
+			},
+		},
+		"404 not found": {
+			request:        GetCPCodeRequest{CPCodeID: 123},
+			responseStatus: http.StatusNotFound,
+			responseBody:   `{"type": "not_found", "title": "Not Found", "status": 404}`,
+			withError: func(t *testing.T, err error) {
+				want := &papi.Error{Type: "not_found", Title: "Not Found", StatusCode: http.StatusNotFound}
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+			},
+		},
+		"validation error": {
+			request: GetCPCodeRequest{},
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, papi.ErrStructValidation), "want: %s; got: %s", papi.ErrStructValidation, err)
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/cprg/v1/cpcodes/123", r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := NewCPRGClient(mockAPISession(t, mockServer))
+			result, err := client.GetCPCode(context.Background(), test.request)
+			if test.withError != nil {
+				test.withError(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestUpdateCPRGCPCode(t *testing.T) {
+	cpCode := CPCodeDetail{
+		CPCodeName:       "renamed cpcode",
+		Purgeable:        false,
+		OverrideTimeZone: &CPCodeTimeZone{TimeZoneID: "1"},
+		Contracts:        []CPCodeContract{{ContractID: "1-ABC", Status: "ongoing"}},
+		Products:         []CPCodeProduct{{ProductID: "Web_Accel"}},
+	}
+
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/cprg/v1/cpcodes/123", r.URL.String())
+		assert.Equal(t, http.MethodPut, r.Method)
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"cpcodeName": "renamed cpcode",
+			"purgeable": false,
+			"overrideTimezone": {"timezoneId": "1"},
+			"contracts": [{"contractId": "1-ABC", "status": "ongoing"}],
+			"products": [{"productId": "Web_Accel"}]
+		}`, string(body))
+		w.WriteHeader(http.StatusOK)
+		updated := cpCode
+		updated.CPCodeID = 123
+		assert.NoError(t, json.NewEncoder(w).Encode(updated))
+	}))
+	defer mockServer.Close()
+	client := NewCPRGClient(mockAPISession(t, mockServer))
+
+	result, err := client.UpdateCPCode(context.Background(), UpdateCPCodeRequest{CPCodeID: 123, CPCode: cpCode})
+	require.NoError(t, err)
+	assert.Equal(t, 123, result.CPCodeID)
+	assert.Equal(t, "renamed cpcode", result.CPCodeName)
+
+	_, err = client.UpdateCPCode(context.Background(), UpdateCPCodeRequest{CPCodeID: 123})
+	assert.True(t, errors.Is(err, papi.ErrStructValidation))
+}
@@ -0,0 +1,102 @@
+package property
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// CPS contains the Certificate Provisioning System API operations used to resolve the slots a certificate
+	// enrollment is deployed on, which the enrollments of the edgegrid CPS client don't expose
+	// See: https://developer.akamai.com/api/core_features/certificate_provisioning_system/v2.html
+	CPS interface {
+		// GetEnrollmentSlots fetches the slots an enrollment's certificate is deployed on
+		//
+		// See: https://developer.akamai.com/api/core_features/certificate_provisioning_system/v2.html#getasingleenrollment
+		GetEnrollmentSlots(context.Context, GetEnrollmentSlotsRequest) (*EnrollmentSlots, error)
+	}
+
+	cpsClient struct {
+		session.Session
+	}
+
+	// GetEnrollmentSlotsRequest contains the ID of an enrollment to fetch
+	GetEnrollmentSlotsRequest struct {
+		EnrollmentID int
+	}
+
+	// EnrollmentSlots contains the slots an enrollment's certificate is deployed on
+	EnrollmentSlots struct {
+		AssignedSlots   []int `json:"assignedSlots"`
+		StagingSlots    []int `json:"stagingSlots"`
+		ProductionSlots []int `json:"productionSlots"`
+	}
+)
+
+var (
+	// ErrGetEnrollmentSlots represents error when fetching the slots of an enrollment fails
+	ErrGetEnrollmentSlots = errors.New("fetching enrollment slots")
+)
+
+// NewCPSClient returns a CPS client using the given session
+func NewCPSClient(sess session.Session) CPS {
+	return &cpsClient{Session: sess}
+}
+
+// Validate validates GetEnrollmentSlotsRequest
+func (r GetEnrollmentSlotsRequest) Validate() error {
+	return validation.Errors{
+		"EnrollmentID": validation.Validate(r.EnrollmentID, validation.Required),
+	}.Filter()
+}
+
+func (c *cpsClient) GetEnrollmentSlots(ctx context.Context, params GetEnrollmentSlotsRequest) (*EnrollmentSlots, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetEnrollmentSlots, papi.ErrStructValidation, err)
+	}
+
+	logger := c.Log(ctx)
+	logger.Debug("GetEnrollmentSlots")
+
+	uri := fmt.Sprintf("/cps/v2/enrollments/%d", params.EnrollmentID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetEnrollmentSlots, err)
+	}
+	// the slots are only returned from version 11 of the enrollment
+	req.Header.Set("Accept", "application/vnd.akamai.cps.enrollment.v11+json")
+
+	var rval EnrollmentSlots
+	resp, err := c.Exec(req, &rval)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetEnrollmentSlots, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetEnrollmentSlots, parseAPIError(c.Session, resp))
+	}
+
+	return &rval, nil
+}
+
+// Slots returns the assigned, staging and production slots of the enrollment without duplicates
+func (s EnrollmentSlots) Slots() []int {
+	seen := make(map[int]struct{})
+	var slots []int
+	for _, list := range [][]int{s.AssignedSlots, s.StagingSlots, s.ProductionSlots} {
+		for _, slot := range list {
+			if _, ok := seen[slot]; ok {
+				continue
+			}
+			seen[slot] = struct{}{}
+			slots = append(slots, slot)
+		}
+	}
+	return slots
+}
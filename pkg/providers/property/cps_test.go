@@ -0,0 +1,92 @@
+package property
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockcps struct {
+	mock.Mock
+}
+
+func (c *mockcps) GetEnrollmentSlots(ctx context.Context, r GetEnrollmentSlotsRequest) (*EnrollmentSlots, error) {
+	args := c.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*EnrollmentSlots), args.Error(1)
+}
+
+func TestGetEnrollmentSlots(t *testing.T) {
+	tests := map[string]struct {
+		request          GetEnrollmentSlotsRequest
+		responseStatus   int
+		responseBody     string
+		expectedResponse *EnrollmentSlots
+		withError        func(*testing.T, error)
+	}{
+		"200 OK": {
+			request:        GetEnrollmentSlotsRequest{EnrollmentID: 123},
+			responseStatus: http.StatusOK,
+			responseBody: `{
+				"id": 123,
+				"assignedSlots": [12],
+				"stagingSlots": [12, 13],
+				"productionSlots": [12]
+			}`,
+			expectedResponse: &EnrollmentSlots{
+				AssignedSlots:   []int{12},
+				StagingSlots:    []int{12, 13},
+				ProductionSlots: []int{12},
+			},
+		},
+		"404 not found": {
+			request:        GetEnrollmentSlotsRequest{EnrollmentID: 123},
+			responseStatus: http.StatusNotFound,
+			responseBody:   `{"type": "not_found", "title": "Not Found", "status": 404}`,
+			withError: func(t *testing.T, err error) {
+				want := &papi.Error{Type: "not_found", Title: "Not Found", StatusCode: http.StatusNotFound}
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+			},
+		},
+		"validation error": {
+			request: GetEnrollmentSlotsRequest{},
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, papi.ErrStructValidation), "want: %s; got: %s", papi.ErrStructValidation, err)
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/cps/v2/enrollments/123", r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, "application/vnd.akamai.cps.enrollment.v11+json", r.Header.Get("Accept"))
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := NewCPSClient(mockAPISession(t, mockServer))
+			result, err := client.GetEnrollmentSlots(context.Background(), test.request)
+			if test.withError != nil {
+				test.withError(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+			assert.Equal(t, []int{12, 13}, result.Slots())
+		})
+	}
+}
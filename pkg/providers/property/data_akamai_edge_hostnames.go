@@ -0,0 +1,270 @@
+package property
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+)
+
+func dataSourceAkamaiEdgeHostnames() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataAkamaiEdgeHostnamesRead,
+		Schema: map[string]*schema.Schema{
+			"contract_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: tools.IsNotBlank,
+			},
+			"group_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: tools.IsNotBlank,
+			},
+			"domain_suffix": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"edgesuite.net", "edgekey.net", "akamaized.net"}, false)),
+				Description:      "Only return edge hostnames with this domain suffix",
+			},
+			"ip_behavior": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+					papi.EHIPVersionV4, papi.EHIPVersionV6Performance, papi.EHIPVersionV6Compliance,
+				}, true)),
+				Description: "Only return edge hostnames with this IP version behavior",
+			},
+			"secure": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Only return secure (true) or non-secure (false) edge hostnames",
+			},
+			"slot_number": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Only return edge hostnames using the certificate deployed on this slot number",
+			},
+			"certificate_enrollment_id": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ConflictsWith: []string{"slot_number"},
+				Description:   "Only return edge hostnames using a slot of this CPS certificate enrollment",
+			},
+			"edge_hostnames": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of edge hostnames matching the filters",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"edge_hostname_id": {Type: schema.TypeString, Computed: true},
+						"edge_hostname":    {Type: schema.TypeString, Computed: true},
+						"domain_prefix":    {Type: schema.TypeString, Computed: true},
+						"domain_suffix":    {Type: schema.TypeString, Computed: true},
+						"product_id":       {Type: schema.TypeString, Computed: true},
+						"ip_behavior":      {Type: schema.TypeString, Computed: true},
+						"secure":           {Type: schema.TypeBool, Computed: true},
+						"status":           {Type: schema.TypeString, Computed: true},
+						"ttl":              {Type: schema.TypeInt, Computed: true},
+						"slot_number":      {Type: schema.TypeInt, Computed: true},
+						"properties": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Properties whose latest version has a hostname CNAMEd to the edge hostname",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"property_id":      {Type: schema.TypeString, Computed: true},
+									"property_name":    {Type: schema.TypeString, Computed: true},
+									"property_version": {Type: schema.TypeInt, Computed: true},
+									"cname_from":       {Type: schema.TypeString, Computed: true},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+type edgeHostnameFilter struct {
+	domainSuffix string
+	ipBehavior   string
+	secure       *bool
+	slotNumbers  map[int]struct{}
+}
+
+func dataAkamaiEdgeHostnamesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	client := inst.Client(meta)
+	log := meta.Log("PAPI", "dataAkamaiEdgeHostnamesRead")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(log),
+	)
+	log.Debug("Listing Edge Hostnames")
+
+	// groupID / contractID is string as per schema.
+	groupID, err := tools.GetStringValue("group_id", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	groupID = tools.AddPrefix(groupID, "grp_")
+	contractID, err := tools.GetStringValue("contract_id", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	contractID = tools.AddPrefix(contractID, "ctr_")
+
+	var filter edgeHostnameFilter
+	filter.domainSuffix = d.Get("domain_suffix").(string)
+	filter.ipBehavior = strings.ToUpper(d.Get("ip_behavior").(string))
+	if slotNumber := d.Get("slot_number").(int); slotNumber != 0 {
+		filter.slotNumbers = map[int]struct{}{slotNumber: {}}
+	}
+	if enrollmentID := d.Get("certificate_enrollment_id").(int); enrollmentID != 0 {
+		enrollment, err := inst.CPSClient(meta).GetEnrollmentSlots(ctx, GetEnrollmentSlotsRequest{EnrollmentID: enrollmentID})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		// an enrollment without slots matches no edge hostname
+		filter.slotNumbers = make(map[int]struct{})
+		for _, slot := range enrollment.Slots() {
+			filter.slotNumbers[slot] = struct{}{}
+		}
+	}
+	// the secure filter has to distinguish false from not set
+	if secure, ok := d.GetOkExists("secure"); ok {
+		s := secure.(bool)
+		filter.secure = &s
+	}
+
+	edgeHostnames, err := client.GetEdgeHostnames(ctx, papi.GetEdgeHostnamesRequest{
+		ContractID: contractID,
+		GroupID:    groupID,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// TTL and slot number are only exposed by the Edge Hostnames API
+	details, err := inst.HAPIClient(meta).ListEdgeHostnames(ctx, ListEdgeHostnamesRequest{DNSZone: filter.domainSuffix})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	detailsByDomain := make(map[string]EdgeHostnameDetail, len(details.EdgeHostnames))
+	for _, detail := range details.EdgeHostnames {
+		detailsByDomain[fmt.Sprintf("%s.%s", detail.RecordName, detail.DNSZone)] = detail
+	}
+
+	var found []papi.EdgeHostnameGetItem
+	for _, eh := range edgeHostnames.EdgeHostnames.Items {
+		if filter.matches(eh, detailsByDomain[eh.Domain]) {
+			found = append(found, eh)
+		}
+	}
+
+	var cnames map[string][]map[string]interface{}
+	if len(found) > 0 {
+		cnames, err = propertiesByEdgeHostname(ctx, client, meta, contractID, groupID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	result := make([]interface{}, 0, len(found))
+	for _, eh := range found {
+		detail := detailsByDomain[eh.Domain]
+		properties := cnames[eh.ID]
+		if properties == nil {
+			properties = make([]map[string]interface{}, 0)
+		}
+		result = append(result, map[string]interface{}{
+			"edge_hostname_id": eh.ID,
+			"edge_hostname":    eh.Domain,
+			"domain_prefix":    eh.DomainPrefix,
+			"domain_suffix":    eh.DomainSuffix,
+			"product_id":       eh.ProductID,
+			"ip_behavior":      eh.IPVersionBehavior,
+			"secure":           eh.Secure,
+			"status":           eh.Status,
+			"ttl":              detail.TTL,
+			"slot_number":      detail.SlotNumber,
+			"properties":       properties,
+		})
+	}
+
+	if err := d.Set("contract_id", contractID); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+	if err := d.Set("group_id", groupID); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+	if err := d.Set("edge_hostnames", result); err != nil {
+		return diag.Errorf("error setting edge hostnames: %s", err)
+	}
+	d.SetId(contractID + groupID)
+
+	return nil
+}
+
+func (f edgeHostnameFilter) matches(eh papi.EdgeHostnameGetItem, detail EdgeHostnameDetail) bool {
+	if f.domainSuffix != "" && eh.DomainSuffix != f.domainSuffix {
+		return false
+	}
+	if f.ipBehavior != "" && eh.IPVersionBehavior != f.ipBehavior {
+		return false
+	}
+	if f.secure != nil && eh.Secure != *f.secure {
+		return false
+	}
+	if f.slotNumbers != nil {
+		if _, ok := f.slotNumbers[detail.SlotNumber]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// propertiesByEdgeHostname maps edge hostname IDs to the properties whose latest version has a hostname CNAMEd to them
+func propertiesByEdgeHostname(ctx context.Context, client papi.PAPI, meta akamai.OperationMeta, contractID, groupID string) (map[string][]map[string]interface{}, error) {
+	properties, err := getProperties(ctx, groupID, contractID, meta)
+	if err != nil {
+		return nil, fmt.Errorf("error listing properties: %w", err)
+	}
+
+	cnames := make(map[string][]map[string]interface{})
+	for _, property := range properties.Properties.Items {
+		hostnames, err := client.GetPropertyVersionHostnames(ctx, papi.GetPropertyVersionHostnamesRequest{
+			PropertyID:      property.PropertyID,
+			PropertyVersion: property.LatestVersion,
+			ContractID:      contractID,
+			GroupID:         groupID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, hostname := range hostnames.Hostnames.Items {
+			if hostname.EdgeHostnameID == "" {
+				continue
+			}
+			cnames[hostname.EdgeHostnameID] = append(cnames[hostname.EdgeHostnameID], map[string]interface{}{
+				"property_id":      property.PropertyID,
+				"property_name":    property.PropertyName,
+				"property_version": property.LatestVersion,
+				"cname_from":       hostname.CnameFrom,
+			})
+		}
+	}
+
+	return cnames, nil
+}
@@ -0,0 +1,207 @@
+package property
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDataEdgeHostnames(t *testing.T) {
+	edgeHostnames := &papi.GetEdgeHostnamesResponse{
+		ContractID: "ctr_1",
+		GroupID:    "grp_2",
+		EdgeHostnames: papi.EdgeHostnameItems{Items: []papi.EdgeHostnameGetItem{
+			{
+				ID:                "ehn_1",
+				Domain:            "www.example.com.edgekey.net",
+				ProductID:         "prd_Fresca",
+				DomainPrefix:      "www.example.com",
+				DomainSuffix:      "edgekey.net",
+				Secure:            true,
+				IPVersionBehavior: papi.EHIPVersionV6Compliance,
+			},
+			{
+				ID:                "ehn_2",
+				Domain:            "api.example.com.edgekey.net",
+				ProductID:         "prd_Fresca",
+				DomainPrefix:      "api.example.com",
+				DomainSuffix:      "edgekey.net",
+				Secure:            true,
+				IPVersionBehavior: papi.EHIPVersionV6Compliance,
+			},
+			{
+				ID:                "ehn_3",
+				Domain:            "static.example.com.edgesuite.net",
+				ProductID:         "prd_Site_Accel",
+				DomainPrefix:      "static.example.com",
+				DomainSuffix:      "edgesuite.net",
+				IPVersionBehavior: papi.EHIPVersionV4,
+			},
+		}},
+	}
+	details := []EdgeHostnameDetail{
+		{EdgeHostnameID: 1, RecordName: "www.example.com", DNSZone: "edgekey.net", TTL: 300, SlotNumber: 12},
+		{EdgeHostnameID: 2, RecordName: "api.example.com", DNSZone: "edgekey.net", TTL: 21600, SlotNumber: 13},
+		{EdgeHostnameID: 3, RecordName: "static.example.com", DNSZone: "edgesuite.net", TTL: 21600},
+	}
+	expectProperties := func(m *mockpapi) {
+		m.On("GetProperties", mock.Anything, papi.GetPropertiesRequest{ContractID: "ctr_1", GroupID: "grp_2"}).
+			Return(&papi.GetPropertiesResponse{Properties: papi.PropertiesItems{Items: []*papi.Property{
+				{PropertyID: "prp_1", PropertyName: "www", LatestVersion: 3},
+				{PropertyID: "prp_2", PropertyName: "static", LatestVersion: 1},
+			}}}, nil)
+		m.On("GetPropertyVersionHostnames", mock.Anything, papi.GetPropertyVersionHostnamesRequest{
+			PropertyID: "prp_1", PropertyVersion: 3, ContractID: "ctr_1", GroupID: "grp_2",
+		}).Return(&papi.GetPropertyVersionHostnamesResponse{Hostnames: papi.HostnameResponseItems{Items: []papi.Hostname{
+			{CnameFrom: "www.example.com", CnameTo: "www.example.com.edgekey.net", EdgeHostnameID: "ehn_1"},
+			{CnameFrom: "example.com", CnameTo: "www.example.com.edgekey.net", EdgeHostnameID: "ehn_1"},
+		}}}, nil)
+		m.On("GetPropertyVersionHostnames", mock.Anything, papi.GetPropertyVersionHostnamesRequest{
+			PropertyID: "prp_2", PropertyVersion: 1, ContractID: "ctr_1", GroupID: "grp_2",
+		}).Return(&papi.GetPropertyVersionHostnamesResponse{Hostnames: papi.HostnameResponseItems{Items: []papi.Hostname{
+			{CnameFrom: "static.example.com", CnameTo: "static.example.com.edgesuite.net", EdgeHostnameID: "ehn_3"},
+		}}}, nil)
+	}
+
+	tests := map[string]struct {
+		init       func(*mockpapi, *mockhapi, *mockcps)
+		configPath string
+		checks     resource.TestCheckFunc
+		withError  *regexp.Regexp
+	}{
+		"list all edge hostnames": {
+			init: func(p *mockpapi, h *mockhapi, c *mockcps) {
+				p.On("GetEdgeHostnames", mock.Anything, papi.GetEdgeHostnamesRequest{ContractID: "ctr_1", GroupID: "grp_2"}).
+					Return(edgeHostnames, nil)
+				h.On("ListEdgeHostnames", mock.Anything, ListEdgeHostnamesRequest{}).
+					Return(&ListEdgeHostnamesResponse{EdgeHostnames: details}, nil)
+				expectProperties(p)
+			},
+			configPath: "testdata/TestDataEdgeHostnames/edge_hostnames.tf",
+			checks: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "id", "ctr_1grp_2"),
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.#", "3"),
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.0.edge_hostname_id", "ehn_1"),
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.0.edge_hostname", "www.example.com.edgekey.net"),
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.0.product_id", "prd_Fresca"),
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.0.ttl", "300"),
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.0.slot_number", "12"),
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.0.properties.#", "2"),
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.0.properties.0.property_id", "prp_1"),
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.0.properties.0.property_version", "3"),
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.0.properties.1.cname_from", "example.com"),
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.1.properties.#", "0"),
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.2.secure", "false"),
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.2.properties.0.property_name", "static"),
+			),
+		},
+		"filter by suffix, IP behavior, secure flag and slot number": {
+			init: func(p *mockpapi, h *mockhapi, c *mockcps) {
+				p.On("GetEdgeHostnames", mock.Anything, papi.GetEdgeHostnamesRequest{ContractID: "ctr_1", GroupID: "grp_2"}).
+					Return(edgeHostnames, nil)
+				h.On("ListEdgeHostnames", mock.Anything, ListEdgeHostnamesRequest{DNSZone: "edgekey.net"}).
+					Return(&ListEdgeHostnamesResponse{EdgeHostnames: details[:2]}, nil)
+				expectProperties(p)
+			},
+			configPath: "testdata/TestDataEdgeHostnames/filtered.tf",
+			checks: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "contract_id", "ctr_1"),
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.#", "1"),
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.0.edge_hostname_id", "ehn_1"),
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.0.properties.#", "2"),
+			),
+		},
+		"filter by certificate enrollment": {
+			init: func(p *mockpapi, h *mockhapi, c *mockcps) {
+				p.On("GetEdgeHostnames", mock.Anything, papi.GetEdgeHostnamesRequest{ContractID: "ctr_1", GroupID: "grp_2"}).
+					Return(edgeHostnames, nil)
+				h.On("ListEdgeHostnames", mock.Anything, ListEdgeHostnamesRequest{}).
+					Return(&ListEdgeHostnamesResponse{EdgeHostnames: details}, nil)
+				c.On("GetEnrollmentSlots", mock.Anything, GetEnrollmentSlotsRequest{EnrollmentID: 123}).
+					Return(&EnrollmentSlots{AssignedSlots: []int{13}, StagingSlots: []int{13, 14}}, nil)
+				expectProperties(p)
+			},
+			configPath: "testdata/TestDataEdgeHostnames/enrollment.tf",
+			checks: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.#", "1"),
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.0.edge_hostname_id", "ehn_2"),
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.0.slot_number", "13"),
+			),
+		},
+		"certificate enrollment not found": {
+			init: func(p *mockpapi, h *mockhapi, c *mockcps) {
+				c.On("GetEnrollmentSlots", mock.Anything, GetEnrollmentSlotsRequest{EnrollmentID: 123}).
+					Return(nil, fmt.Errorf("%s: %w", ErrGetEnrollmentSlots, &papi.Error{Title: "Not Found", StatusCode: http.StatusNotFound}))
+			},
+			configPath: "testdata/TestDataEdgeHostnames/enrollment.tf",
+			withError:  regexp.MustCompile("fetching enrollment slots"),
+		},
+		"slot number and certificate enrollment conflict": {
+			configPath: "testdata/TestDataEdgeHostnames/slot_and_enrollment.tf",
+			withError:  regexp.MustCompile("Conflicting configuration arguments"),
+		},
+		"filter non-secure edge hostnames": {
+			init: func(p *mockpapi, h *mockhapi, c *mockcps) {
+				p.On("GetEdgeHostnames", mock.Anything, papi.GetEdgeHostnamesRequest{ContractID: "ctr_1", GroupID: "grp_2"}).
+					Return(edgeHostnames, nil)
+				h.On("ListEdgeHostnames", mock.Anything, ListEdgeHostnamesRequest{}).
+					Return(&ListEdgeHostnamesResponse{EdgeHostnames: details}, nil)
+				expectProperties(p)
+			},
+			configPath: "testdata/TestDataEdgeHostnames/not_secure.tf",
+			checks: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.#", "1"),
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.0.edge_hostname_id", "ehn_3"),
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.0.ttl", "21600"),
+				resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.0.slot_number", "0"),
+			),
+		},
+		"no properties are listed when nothing matches": {
+			init: func(p *mockpapi, h *mockhapi, c *mockcps) {
+				p.On("GetEdgeHostnames", mock.Anything, papi.GetEdgeHostnamesRequest{ContractID: "ctr_1", GroupID: "grp_2"}).
+					Return(&papi.GetEdgeHostnamesResponse{}, nil)
+				h.On("ListEdgeHostnames", mock.Anything, ListEdgeHostnamesRequest{}).
+					Return(&ListEdgeHostnamesResponse{}, nil)
+			},
+			configPath: "testdata/TestDataEdgeHostnames/edge_hostnames.tf",
+			checks:     resource.TestCheckResourceAttr("data.akamai_edge_hostnames.test", "edge_hostnames.#", "0"),
+		},
+		"invalid domain suffix": {
+			configPath: "testdata/TestDataEdgeHostnames/invalid_suffix.tf",
+			withError:  regexp.MustCompile(`expected domain_suffix to be one of`),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &mockpapi{}
+			hapiClient := &mockhapi{}
+			cpsClient := &mockcps{}
+			if test.init != nil {
+				test.init(client, hapiClient, cpsClient)
+			}
+			useClient(client, func() {
+				useHAPIClient(hapiClient, func() {
+					useCPSClient(cpsClient, func() {
+						resource.UnitTest(t, resource.TestCase{
+							Providers: testAccProviders,
+							Steps: []resource.TestStep{{
+								Config:      loadFixtureString(test.configPath),
+								Check:       test.checks,
+								ExpectError: test.withError,
+							}},
+						})
+					})
+				})
+			})
+			client.AssertExpectations(t)
+			hapiClient.AssertExpectations(t)
+			cpsClient.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package property
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+)
+
+func dataSourceAkamaiPropertyActivations() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataAkamaiPropertyActivationsRead,
+		Schema: map[string]*schema.Schema{
+			"property_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: tools.IsNotBlank,
+			},
+			"contract_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"group_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"network": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return activations on this network",
+			},
+			"submitted_after": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+				Description:      "Only return activations submitted at or after this RFC 3339 time",
+			},
+			"submitted_before": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+				Description:      "Only return activations submitted before this RFC 3339 time",
+			},
+			// there is no submitting user attribute, PAPI activations, listed or fetched one by one, don't include the submitter
+			"activations": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Activations and deactivations of the property, most recently submitted first",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"activation_id":   {Type: schema.TypeString, Computed: true},
+						"activation_type": {Type: schema.TypeString, Computed: true},
+						"version":         {Type: schema.TypeInt, Computed: true},
+						"network":         {Type: schema.TypeString, Computed: true},
+						"status":          {Type: schema.TypeString, Computed: true},
+						"submit_date":     {Type: schema.TypeString, Computed: true},
+						"update_date":     {Type: schema.TypeString, Computed: true},
+						"note":            {Type: schema.TypeString, Computed: true},
+						"fast_push":       {Type: schema.TypeBool, Computed: true},
+						"notify_emails": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataAkamaiPropertyActivationsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	client := inst.Client(meta)
+	log := meta.Log("PAPI", "dataAkamaiPropertyActivationsRead")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(log),
+	)
+
+	propertyID := tools.AddPrefix(d.Get("property_id").(string), "prp_")
+	contractID := d.Get("contract_id").(string)
+	if contractID != "" {
+		contractID = tools.AddPrefix(contractID, "ctr_")
+	}
+	groupID := d.Get("group_id").(string)
+	if groupID != "" {
+		groupID = tools.AddPrefix(groupID, "grp_")
+	}
+
+	var network string
+	if v := d.Get("network").(string); v != "" {
+		var err error
+		if network, err = NetworkAlias(v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	var after, before time.Time
+	if v := d.Get("submitted_after").(string); v != "" {
+		after, _ = time.Parse(time.RFC3339, v)
+	}
+	if v := d.Get("submitted_before").(string); v != "" {
+		before, _ = time.Parse(time.RFC3339, v)
+	}
+
+	log.Debugf("Listing activations of %s", propertyID)
+	activations, err := client.GetActivations(ctx, papi.GetActivationsRequest{
+		PropertyID: propertyID,
+		ContractID: contractID,
+		GroupID:    groupID,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	type submitted struct {
+		activation *papi.Activation
+		date       time.Time
+	}
+	var found []submitted
+	for _, a := range activations.Activations.Items {
+		if network != "" && string(a.Network) != network {
+			continue
+		}
+		date, err := tools.ParseDate(tools.DateTimeFormat, a.SubmitDate)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if (!after.IsZero() && date.Before(after)) || (!before.IsZero() && !date.Before(before)) {
+			continue
+		}
+		found = append(found, submitted{activation: a, date: date})
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].date.After(found[j].date)
+	})
+
+	result := make([]interface{}, 0, len(found))
+	for _, f := range found {
+		result = append(result, map[string]interface{}{
+			"activation_id":   f.activation.ActivationID,
+			"activation_type": string(f.activation.ActivationType),
+			"version":         f.activation.PropertyVersion,
+			"network":         string(f.activation.Network),
+			"status":          string(f.activation.Status),
+			"submit_date":     f.activation.SubmitDate,
+			"update_date":     f.activation.UpdateDate,
+			"note":            f.activation.Note,
+			"fast_push":       f.activation.FastPush,
+			"notify_emails":   f.activation.NotifyEmails,
+		})
+	}
+
+	if err := d.Set("activations", result); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+	d.SetId(propertyID)
+
+	return nil
+}
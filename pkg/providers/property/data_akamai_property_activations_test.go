@@ -0,0 +1,136 @@
+package property
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDataPropertyActivations(t *testing.T) {
+	activations := &papi.GetActivationsResponse{Activations: papi.ActivationsItems{Items: []*papi.Activation{
+		{
+			ActivationID:    "atv_1",
+			ActivationType:  papi.ActivationTypeActivate,
+			PropertyVersion: 1,
+			Network:         papi.ActivationNetworkStaging,
+			Status:          papi.ActivationStatusInactive,
+			SubmitDate:      "2021-12-20T10:00:00Z",
+			UpdateDate:      "2021-12-20T10:10:00Z",
+			NotifyEmails:    []string{"dev@example.com"},
+		},
+		{
+			ActivationID:    "atv_3",
+			ActivationType:  papi.ActivationTypeDeactivate,
+			PropertyVersion: 2,
+			Network:         papi.ActivationNetworkProduction,
+			Status:          papi.ActivationStatusDeactivated,
+			SubmitDate:      "2022-01-15T08:00:00Z",
+			UpdateDate:      "2022-01-15T08:30:00Z",
+			Note:            "rollback",
+			FastPush:        true,
+			NotifyEmails:    []string{"ops@example.com"},
+		},
+		{
+			ActivationID:    "atv_2",
+			ActivationType:  papi.ActivationTypeActivate,
+			PropertyVersion: 2,
+			Network:         papi.ActivationNetworkProduction,
+			Status:          papi.ActivationStatusActive,
+			SubmitDate:      "2022-01-10T12:00:00Z",
+			UpdateDate:      "2022-01-10T12:45:00Z",
+			Note:            "release 2",
+			NotifyEmails:    []string{"ops@example.com"},
+		},
+		{
+			ActivationID:    "atv_4",
+			ActivationType:  papi.ActivationTypeActivate,
+			PropertyVersion: 3,
+			Network:         papi.ActivationNetworkProduction,
+			Status:          papi.ActivationStatusPending,
+			SubmitDate:      "2022-02-01T00:00:00Z",
+			UpdateDate:      "2022-02-01T00:00:00Z",
+			NotifyEmails:    []string{"ops@example.com"},
+		},
+	}}}
+
+	tests := map[string]struct {
+		init       func(*mockpapi)
+		configPath string
+		checks     resource.TestCheckFunc
+		withError  *regexp.Regexp
+	}{
+		"all activations, most recent first": {
+			init: func(m *mockpapi) {
+				m.On("GetActivations", mock.Anything, papi.GetActivationsRequest{PropertyID: "prp_1"}).Return(activations, nil)
+			},
+			configPath: "testdata/TestDataPropertyActivations/all.tf",
+			checks: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("data.akamai_property_activations.test", "id", "prp_1"),
+				resource.TestCheckResourceAttr("data.akamai_property_activations.test", "activations.#", "4"),
+				resource.TestCheckResourceAttr("data.akamai_property_activations.test", "activations.0.activation_id", "atv_4"),
+				resource.TestCheckResourceAttr("data.akamai_property_activations.test", "activations.1.activation_id", "atv_3"),
+				resource.TestCheckResourceAttr("data.akamai_property_activations.test", "activations.1.activation_type", "DEACTIVATE"),
+				resource.TestCheckResourceAttr("data.akamai_property_activations.test", "activations.1.version", "2"),
+				resource.TestCheckResourceAttr("data.akamai_property_activations.test", "activations.1.network", "PRODUCTION"),
+				resource.TestCheckResourceAttr("data.akamai_property_activations.test", "activations.1.status", "DEACTIVATED"),
+				resource.TestCheckResourceAttr("data.akamai_property_activations.test", "activations.1.submit_date", "2022-01-15T08:00:00Z"),
+				resource.TestCheckResourceAttr("data.akamai_property_activations.test", "activations.1.update_date", "2022-01-15T08:30:00Z"),
+				resource.TestCheckResourceAttr("data.akamai_property_activations.test", "activations.1.note", "rollback"),
+				resource.TestCheckResourceAttr("data.akamai_property_activations.test", "activations.1.fast_push", "true"),
+				resource.TestCheckResourceAttr("data.akamai_property_activations.test", "activations.1.notify_emails.0", "ops@example.com"),
+				resource.TestCheckResourceAttr("data.akamai_property_activations.test", "activations.2.activation_id", "atv_2"),
+				resource.TestCheckResourceAttr("data.akamai_property_activations.test", "activations.3.activation_id", "atv_1"),
+			),
+		},
+		"filter by network and submit time": {
+			init: func(m *mockpapi) {
+				m.On("GetActivations", mock.Anything, papi.GetActivationsRequest{
+					PropertyID: "prp_1",
+					ContractID: "ctr_1",
+					GroupID:    "grp_2",
+				}).Return(activations, nil)
+			},
+			configPath: "testdata/TestDataPropertyActivations/filtered.tf",
+			checks: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("data.akamai_property_activations.test", "activations.#", "2"),
+				resource.TestCheckResourceAttr("data.akamai_property_activations.test", "activations.0.activation_id", "atv_3"),
+				resource.TestCheckResourceAttr("data.akamai_property_activations.test", "activations.1.activation_id", "atv_2"),
+			),
+		},
+		"API error": {
+			init: func(m *mockpapi) {
+				m.On("GetActivations", mock.Anything, papi.GetActivationsRequest{PropertyID: "prp_1"}).
+					Return(nil, &papi.Error{StatusCode: 403, Title: "Forbidden"})
+			},
+			configPath: "testdata/TestDataPropertyActivations/all.tf",
+			withError:  regexp.MustCompile("Forbidden"),
+		},
+		"invalid time": {
+			configPath: "testdata/TestDataPropertyActivations/invalid_time.tf",
+			withError:  regexp.MustCompile(`expected "submitted_after" to be a valid RFC3339 date`),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &mockpapi{}
+			if test.init != nil {
+				test.init(client)
+			}
+			useClient(client, func() {
+				resource.UnitTest(t, resource.TestCase{
+					Providers: testAccProviders,
+					Steps: []resource.TestStep{{
+						Config:      loadFixtureString(test.configPath),
+						Check:       test.checks,
+						ExpectError: test.withError,
+					}},
+				})
+			})
+			client.AssertExpectations(t)
+		})
+	}
+}
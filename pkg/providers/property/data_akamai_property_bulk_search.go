@@ -0,0 +1,275 @@
+package property
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+)
+
+const (
+	// BulkSearchVersionsAll keeps every property version returned by the bulk search
+	BulkSearchVersionsAll = "ALL"
+	// BulkSearchVersionsLatest keeps only the latest property versions
+	BulkSearchVersionsLatest = "LATEST"
+	// BulkSearchVersionsActive keeps property versions active on staging or production
+	BulkSearchVersionsActive = "ACTIVE"
+	// BulkSearchVersionsActiveStaging keeps property versions active on staging
+	BulkSearchVersionsActiveStaging = "ACTIVE_STAGING"
+	// BulkSearchVersionsActiveProduction keeps property versions active on production
+	BulkSearchVersionsActiveProduction = "ACTIVE_PRODUCTION"
+)
+
+var (
+	// BulkSearchPollInterval is the interval for polling the bulk search status
+	BulkSearchPollInterval = 5 * time.Second
+
+	// ErrBulkSearchFailed is returned when PAPI could not complete the bulk search
+	ErrBulkSearchFailed = errors.New("bulk search failed")
+)
+
+func dataSourceAkamaiPropertyBulkSearch() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataAkamaiPropertyBulkSearchRead,
+		Schema: map[string]*schema.Schema{
+			"contract_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				StateFunc:   addPrefixToState("ctr_"),
+				Description: "Limits the search to properties of this contract",
+			},
+			"group_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				StateFunc:   addPrefixToState("grp_"),
+				Description: "Limits the search to properties of this group",
+			},
+			"match": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: tools.IsNotBlank,
+				Description:      "JSONPath expression run against the rule tree of each property version",
+			},
+			"bulk_search_qualifiers": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "JSONPath expressions which must all match the rule tree for the property version to be searched",
+			},
+			"versions": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  BulkSearchVersionsAll,
+				ValidateDiagFunc: tools.ValidateStringInSlice([]string{
+					BulkSearchVersionsAll, BulkSearchVersionsLatest, BulkSearchVersionsActive,
+					BulkSearchVersionsActiveStaging, BulkSearchVersionsActiveProduction,
+				}),
+				Description: "Which of the searched property versions to return: ALL, LATEST, ACTIVE, ACTIVE_STAGING or ACTIVE_PRODUCTION",
+			},
+			"bulk_search_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"results": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Property versions matching the search",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"property_id":        {Type: schema.TypeString, Computed: true},
+						"property_name":      {Type: schema.TypeString, Computed: true},
+						"property_version":   {Type: schema.TypeInt, Computed: true},
+						"is_latest":          {Type: schema.TypeBool, Computed: true},
+						"staging_status":     {Type: schema.TypeString, Computed: true},
+						"production_status":  {Type: schema.TypeString, Computed: true},
+						"last_modified_time": {Type: schema.TypeString, Computed: true},
+						"match_locations": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataAkamaiPropertyBulkSearchRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "dataAkamaiPropertyBulkSearchRead")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+	logger.Debug("Running property bulk search")
+
+	contractID, err := tools.GetStringValue("contract_id", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	if contractID != "" {
+		contractID = tools.AddPrefix(contractID, "ctr_")
+	}
+	groupID, err := tools.GetStringValue("group_id", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	if groupID != "" {
+		groupID = tools.AddPrefix(groupID, "grp_")
+	}
+	match, err := tools.GetStringValue("match", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	qualifiers, err := tools.GetListValue("bulk_search_qualifiers", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	versions, err := tools.GetStringValue("versions", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	request := CreateBulkSearchRequest{
+		ContractID: contractID,
+		GroupID:    groupID,
+		BulkSearchQuery: BulkSearchQuery{
+			Syntax: BulkSearchSyntaxJSONPath,
+			Match:  match,
+		},
+	}
+	for _, q := range qualifiers {
+		request.BulkSearchQuery.BulkSearchQualifiers = append(request.BulkSearchQuery.BulkSearchQualifiers, q.(string))
+	}
+
+	search, err := getBulkSearch(ctx, meta, request)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("bulk_search_id", search.BulkSearchID); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+	if err := d.Set("results", flattenBulkSearchResults(filterBulkSearchResults(search.Results, versions))); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+
+	id, err := tools.GetMd5Sum(struct {
+		Request  CreateBulkSearchRequest
+		Versions string
+	}{request, versions})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+
+	return nil
+}
+
+// getBulkSearch submits the bulk search and polls until it completes, the results are cached so that the same
+// search is run only once per terraform operation
+func getBulkSearch(ctx context.Context, meta akamai.OperationMeta, request CreateBulkSearchRequest) (*BulkSearchResponse, error) {
+	logger := meta.Log("PAPI", "getBulkSearch")
+	client := inst.BulkClient(meta)
+
+	key, err := tools.GetMd5Sum(request)
+	if err != nil {
+		return nil, err
+	}
+	cacheKey := fmt.Sprintf("bulk_search:%s", key)
+
+	search := &BulkSearchResponse{}
+	err = meta.CacheGet(inst, cacheKey, search)
+	if err == nil {
+		logger.Debugf("bulk search %d found in cache", search.BulkSearchID)
+		return search, nil
+	}
+	if !akamai.IsNotFoundError(err) && !errors.Is(err, akamai.ErrCacheDisabled) {
+		return nil, err
+	}
+
+	created, err := client.CreateBulkSearch(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	search, err = client.GetBulkSearch(ctx, GetBulkSearchRequest{BulkSearchID: created.BulkSearchID})
+	if err != nil {
+		return nil, err
+	}
+
+	for search.SearchTargetStatus != BulkStatusComplete {
+		if search.SearchTargetStatus == BulkStatusError {
+			return nil, fmt.Errorf("%w: bulk search %d finished with status %s", ErrBulkSearchFailed, search.BulkSearchID, search.SearchTargetStatus)
+		}
+		select {
+		case <-time.After(BulkSearchPollInterval):
+			search, err = client.GetBulkSearch(ctx, GetBulkSearchRequest{BulkSearchID: created.BulkSearchID})
+			if err != nil {
+				return nil, err
+			}
+
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for bulk search %d: %w", created.BulkSearchID, ctx.Err())
+		}
+	}
+
+	if err := meta.CacheSet(inst, cacheKey, search); err != nil && !errors.Is(err, akamai.ErrCacheDisabled) {
+		return nil, err
+	}
+
+	return search, nil
+}
+
+func filterBulkSearchResults(results []BulkSearchResult, versions string) []BulkSearchResult {
+	filtered := make([]BulkSearchResult, 0, len(results))
+	for _, r := range results {
+		stagingActive := r.StagingStatus == "ACTIVE"
+		productionActive := r.ProductionStatus == "ACTIVE"
+		switch versions {
+		case BulkSearchVersionsLatest:
+			if !r.IsLatest {
+				continue
+			}
+		case BulkSearchVersionsActive:
+			if !stagingActive && !productionActive {
+				continue
+			}
+		case BulkSearchVersionsActiveStaging:
+			if !stagingActive {
+				continue
+			}
+		case BulkSearchVersionsActiveProduction:
+			if !productionActive {
+				continue
+			}
+		}
+		filtered = append(filtered, r)
+	}
+	return filtered
+}
+
+func flattenBulkSearchResults(results []BulkSearchResult) []interface{} {
+	flattened := make([]interface{}, 0, len(results))
+	for _, r := range results {
+		flattened = append(flattened, map[string]interface{}{
+			"property_id":        r.PropertyID,
+			"property_name":      r.PropertyName,
+			"property_version":   r.PropertyVersion,
+			"is_latest":          r.IsLatest,
+			"staging_status":     r.StagingStatus,
+			"production_status":  r.ProductionStatus,
+			"last_modified_time": r.LastModifiedTime,
+			"match_locations":    r.MatchLocations,
+		})
+	}
+	return flattened
+}
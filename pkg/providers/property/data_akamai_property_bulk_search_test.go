@@ -0,0 +1,147 @@
+package property
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDataPropertyBulkSearch(t *testing.T) {
+	BulkSearchPollInterval = time.Millisecond
+
+	results := []BulkSearchResult{
+		{
+			PropertyID:       "prp_1",
+			PropertyName:     "www.example.com",
+			PropertyVersion:  4,
+			IsLatest:         true,
+			StagingStatus:    "ACTIVE",
+			ProductionStatus: "INACTIVE",
+			LastModifiedTime: "2021-08-10T12:00:00Z",
+			MatchLocations:   []string{"/rules/behaviors/0", "/rules/children/1/behaviors/0"},
+		},
+		{
+			PropertyID:       "prp_1",
+			PropertyName:     "www.example.com",
+			PropertyVersion:  3,
+			StagingStatus:    "INACTIVE",
+			ProductionStatus: "ACTIVE",
+			LastModifiedTime: "2021-08-01T12:00:00Z",
+			MatchLocations:   []string{"/rules/behaviors/0"},
+		},
+	}
+
+	tests := map[string]struct {
+		init       func(*mockbulkpapi)
+		configPath string
+		checks     resource.TestCheckFunc
+		withError  *regexp.Regexp
+	}{
+		"search with contract, group and qualifiers": {
+			init: func(m *mockbulkpapi) {
+				m.On("CreateBulkSearch", mock.Anything, CreateBulkSearchRequest{
+					ContractID: "ctr_1",
+					GroupID:    "grp_2",
+					BulkSearchQuery: BulkSearchQuery{
+						Syntax:               BulkSearchSyntaxJSONPath,
+						Match:                "$..features[?(@.name == 'origin')].options[?(@.hostname == 'origin.example.com')]",
+						BulkSearchQualifiers: []string{"$.options[?(@.secure=='true')]"},
+					},
+				}).Return(&CreateBulkSearchResponse{BulkSearchID: 10}, nil).Once()
+				m.On("GetBulkSearch", mock.Anything, GetBulkSearchRequest{BulkSearchID: 10}).
+					Return(&BulkSearchResponse{BulkSearchID: 10, SearchTargetStatus: BulkStatusPending}, nil).Once()
+				m.On("GetBulkSearch", mock.Anything, GetBulkSearchRequest{BulkSearchID: 10}).
+					Return(&BulkSearchResponse{BulkSearchID: 10, SearchTargetStatus: BulkStatusInProgress}, nil).Once()
+				m.On("GetBulkSearch", mock.Anything, GetBulkSearchRequest{BulkSearchID: 10}).
+					Return(&BulkSearchResponse{BulkSearchID: 10, SearchTargetStatus: BulkStatusComplete, Results: results}, nil).Once()
+			},
+			configPath: "testdata/TestDataPropertyBulkSearch/bulk_search.tf",
+			checks: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("data.akamai_property_bulk_search.test", "contract_id", "ctr_1"),
+				resource.TestCheckResourceAttr("data.akamai_property_bulk_search.test", "bulk_search_id", "10"),
+				resource.TestCheckResourceAttr("data.akamai_property_bulk_search.test", "results.#", "2"),
+				resource.TestCheckResourceAttr("data.akamai_property_bulk_search.test", "results.0.property_id", "prp_1"),
+				resource.TestCheckResourceAttr("data.akamai_property_bulk_search.test", "results.0.property_version", "4"),
+				resource.TestCheckResourceAttr("data.akamai_property_bulk_search.test", "results.0.is_latest", "true"),
+				resource.TestCheckResourceAttr("data.akamai_property_bulk_search.test", "results.0.match_locations.#", "2"),
+				resource.TestCheckResourceAttr("data.akamai_property_bulk_search.test", "results.0.match_locations.1", "/rules/children/1/behaviors/0"),
+				resource.TestCheckResourceAttr("data.akamai_property_bulk_search.test", "results.1.property_version", "3"),
+				resource.TestCheckResourceAttr("data.akamai_property_bulk_search.test", "results.1.production_status", "ACTIVE"),
+			),
+		},
+		"search filtered to active production versions": {
+			init: func(m *mockbulkpapi) {
+				m.On("CreateBulkSearch", mock.Anything, CreateBulkSearchRequest{
+					BulkSearchQuery: BulkSearchQuery{
+						Syntax: BulkSearchSyntaxJSONPath,
+						Match:  "$..behaviors[?(@.name == 'caching')]",
+					},
+				}).Return(&CreateBulkSearchResponse{BulkSearchID: 11}, nil).Once()
+				m.On("GetBulkSearch", mock.Anything, GetBulkSearchRequest{BulkSearchID: 11}).
+					Return(&BulkSearchResponse{BulkSearchID: 11, SearchTargetStatus: BulkStatusComplete, Results: results}, nil).Once()
+			},
+			configPath: "testdata/TestDataPropertyBulkSearch/bulk_search_active_production.tf",
+			checks: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("data.akamai_property_bulk_search.test", "results.#", "1"),
+				resource.TestCheckResourceAttr("data.akamai_property_bulk_search.test", "results.0.property_version", "3"),
+			),
+		},
+		"invalid versions": {
+			configPath: "testdata/TestDataPropertyBulkSearch/bulk_search_invalid_versions.tf",
+			withError:  regexp.MustCompile(`expected versions to be one of`),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &mockbulkpapi{}
+			if test.init != nil {
+				test.init(client)
+			}
+			useBulkClient(client, func() {
+				resource.UnitTest(t, resource.TestCase{
+					Providers: testAccProviders,
+					Steps: []resource.TestStep{{
+						Config:      loadFixtureString(test.configPath),
+						Check:       test.checks,
+						ExpectError: test.withError,
+					}},
+				})
+			})
+			client.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFilterBulkSearchResults(t *testing.T) {
+	results := []BulkSearchResult{
+		{PropertyVersion: 1, ProductionStatus: "ACTIVE"},
+		{PropertyVersion: 2, StagingStatus: "ACTIVE"},
+		{PropertyVersion: 3, IsLatest: true},
+	}
+	versionsOf := func(results []BulkSearchResult) []int {
+		var versions []int
+		for _, r := range results {
+			versions = append(versions, r.PropertyVersion)
+		}
+		return versions
+	}
+
+	tests := map[string][]int{
+		BulkSearchVersionsAll:              {1, 2, 3},
+		BulkSearchVersionsLatest:           {3},
+		BulkSearchVersionsActive:           {1, 2},
+		BulkSearchVersionsActiveStaging:    {2},
+		BulkSearchVersionsActiveProduction: {1},
+	}
+	for versions, expected := range tests {
+		t.Run(versions, func(t *testing.T) {
+			assert.Equal(t, expected, versionsOf(filterBulkSearchResults(results, versions)), fmt.Sprintf("versions: %s", versions))
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package property
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+)
+
+func dataSourceAkamaiPropertyHostnamesInventory() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataAkamaiPropertyHostnamesInventoryRead,
+		Schema: map[string]*schema.Schema{
+			"contract_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: tools.IsNotBlank,
+			},
+			"group_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list the properties of this group, all groups of the contract are listed by default",
+			},
+			"network": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list hostnames active on this network",
+			},
+			"hostnames": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Hostnames of the active property versions, sorted by hostname and network",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cname_from":             {Type: schema.TypeString, Computed: true},
+						"cname_to":               {Type: schema.TypeString, Computed: true},
+						"cname_type":             {Type: schema.TypeString, Computed: true},
+						"edge_hostname_id":       {Type: schema.TypeString, Computed: true},
+						"cert_provisioning_type": {Type: schema.TypeString, Computed: true},
+						"cert_status": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     certStatus,
+						},
+						"network":       {Type: schema.TypeString, Computed: true},
+						"property_id":   {Type: schema.TypeString, Computed: true},
+						"property_name": {Type: schema.TypeString, Computed: true},
+						"version":       {Type: schema.TypeInt, Computed: true},
+						"group_id":      {Type: schema.TypeString, Computed: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataAkamaiPropertyHostnamesInventoryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	log := meta.Log("PAPI", "dataAkamaiPropertyHostnamesInventoryRead")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(log),
+	)
+
+	contractID := tools.AddPrefix(d.Get("contract_id").(string), "ctr_")
+	var groupIDs []string
+	if groupID := d.Get("group_id").(string); groupID != "" {
+		groupIDs = []string{tools.AddPrefix(groupID, "grp_")}
+	} else {
+		log.Debugf("Listing groups of %s", contractID)
+		groups, err := getGroups(ctx, meta)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("%w: %s", ErrFetchingGroups, err.Error()))
+		}
+		for _, g := range groups.Groups.Items {
+			for _, id := range g.ContractIDs {
+				if id == contractID {
+					groupIDs = append(groupIDs, g.GroupID)
+					break
+				}
+			}
+		}
+	}
+
+	var network string
+	if v := d.Get("network").(string); v != "" {
+		var err error
+		if network, err = NetworkAlias(v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	var inventory []map[string]interface{}
+	for _, groupID := range groupIDs {
+		log.Debugf("Listing properties of %s", groupID)
+		properties, err := getProperties(ctx, groupID, contractID, meta)
+		if err != nil {
+			return diag.Errorf("error listing properties: %v", err)
+		}
+		for _, property := range properties.Properties.Items {
+			for _, active := range []struct {
+				network string
+				version int
+			}{
+				{string(papi.ActivationNetworkStaging), decodeVersion(property.StagingVersion)},
+				{string(papi.ActivationNetworkProduction), decodeVersion(property.ProductionVersion)},
+			} {
+				if active.version == 0 || (network != "" && network != active.network) {
+					continue
+				}
+				hostnames, err := getActiveVersionHostnames(ctx, meta, property, active.version)
+				if err != nil {
+					return diag.FromErr(err)
+				}
+				for _, hostname := range flattenHostnames(hostnames) {
+					hostname["network"] = active.network
+					hostname["property_id"] = property.PropertyID
+					hostname["property_name"] = property.PropertyName
+					hostname["version"] = active.version
+					hostname["group_id"] = groupID
+					inventory = append(inventory, hostname)
+				}
+			}
+		}
+	}
+	sort.SliceStable(inventory, func(i, j int) bool {
+		if inventory[i]["cname_from"] != inventory[j]["cname_from"] {
+			return inventory[i]["cname_from"].(string) < inventory[j]["cname_from"].(string)
+		}
+		return inventory[i]["network"].(string) > inventory[j]["network"].(string)
+	})
+
+	if err := d.Set("hostnames", inventory); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+	d.SetId(fmt.Sprintf("%s:%s:%s", contractID, d.Get("group_id").(string), network))
+
+	return nil
+}
+
+// getActiveVersionHostnames fetches the hostnames of an active property version, active versions can't be edited
+// so they are cached in memory, which only avoids fetching them again within a single run of the provider
+func getActiveVersionHostnames(ctx context.Context, meta akamai.OperationMeta, property *papi.Property, version int) ([]papi.Hostname, error) {
+	cacheKey := fmt.Sprintf("hostnames:%s:%d", property.PropertyID, version)
+
+	var hostnames []papi.Hostname
+	err := meta.CacheGet(inst, cacheKey, &hostnames)
+	if err == nil {
+		return hostnames, nil
+	}
+	if !akamai.IsNotFoundError(err) && !errors.Is(err, akamai.ErrCacheDisabled) {
+		return nil, err
+	}
+
+	res, err := inst.Client(meta).GetPropertyVersionHostnames(ctx, papi.GetPropertyVersionHostnamesRequest{
+		PropertyID:        property.PropertyID,
+		PropertyVersion:   version,
+		ContractID:        property.ContractID,
+		GroupID:           property.GroupID,
+		IncludeCertStatus: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	hostnames = res.Hostnames.Items
+
+	if err := meta.CacheSet(inst, cacheKey, hostnames); err != nil && !errors.Is(err, akamai.ErrCacheDisabled) {
+		return nil, err
+	}
+
+	return hostnames, nil
+}
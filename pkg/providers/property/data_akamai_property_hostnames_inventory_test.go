@@ -0,0 +1,132 @@
+package property
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDataPropertyHostnamesInventory(t *testing.T) {
+	version := func(v int) *int { return &v }
+	hostname := func(from, to, status string) papi.Hostname {
+		return papi.Hostname{
+			CnameType:            "EDGE_HOSTNAME",
+			CnameFrom:            from,
+			CnameTo:              to,
+			CertProvisioningType: "CPS_MANAGED",
+			EdgeHostnameID:       "ehn_" + from,
+			CertStatus: papi.CertStatusItem{
+				Staging:    []papi.StatusItem{{Status: status}},
+				Production: []papi.StatusItem{{Status: status}},
+			},
+		}
+	}
+	expectHostnames := func(m *mockpapi, propertyID, groupID string, version int, hostnames ...papi.Hostname) *mock.Call {
+		return m.On("GetPropertyVersionHostnames", mock.Anything, papi.GetPropertyVersionHostnamesRequest{
+			PropertyID:        propertyID,
+			PropertyVersion:   version,
+			ContractID:        "ctr_1",
+			GroupID:           groupID,
+			IncludeCertStatus: true,
+		}).Return(&papi.GetPropertyVersionHostnamesResponse{Hostnames: papi.HostnameResponseItems{Items: hostnames}}, nil)
+	}
+
+	tests := map[string]struct {
+		init       func(*mockpapi)
+		configPath string
+		checks     resource.TestCheckFunc
+		withError  *regexp.Regexp
+	}{
+		"hostnames of a group are fetched once per active version": {
+			init: func(m *mockpapi) {
+				m.On("GetProperties", mock.Anything, papi.GetPropertiesRequest{ContractID: "ctr_1", GroupID: "grp_1"}).
+					Return(&papi.GetPropertiesResponse{Properties: papi.PropertiesItems{Items: []*papi.Property{
+						{PropertyID: "prp_inventory_1", PropertyName: "www", ContractID: "ctr_1", GroupID: "grp_1", StagingVersion: version(3), ProductionVersion: version(2)},
+						{PropertyID: "prp_inventory_2", PropertyName: "new", ContractID: "ctr_1", GroupID: "grp_1", LatestVersion: 1},
+					}}}, nil)
+				expectHostnames(m, "prp_inventory_1", "grp_1", 3,
+					hostname("www.example.com", "www.example.com.edgekey.net", "DEPLOYED"),
+					hostname("api.example.com", "api.example.com.edgekey.net", "PENDING"),
+				).Once()
+				expectHostnames(m, "prp_inventory_1", "grp_1", 2,
+					hostname("www.example.com", "www.example.com.edgesuite.net", "DEPLOYED"),
+				).Once()
+			},
+			configPath: "testdata/TestDataPropertyHostnamesInventory/group.tf",
+			checks: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("data.akamai_property_hostnames_inventory.test", "hostnames.#", "3"),
+				resource.TestCheckResourceAttr("data.akamai_property_hostnames_inventory.test", "hostnames.0.cname_from", "api.example.com"),
+				resource.TestCheckResourceAttr("data.akamai_property_hostnames_inventory.test", "hostnames.0.network", "STAGING"),
+				resource.TestCheckResourceAttr("data.akamai_property_hostnames_inventory.test", "hostnames.0.cert_status.0.staging_status", "PENDING"),
+				resource.TestCheckResourceAttr("data.akamai_property_hostnames_inventory.test", "hostnames.1.cname_from", "www.example.com"),
+				resource.TestCheckResourceAttr("data.akamai_property_hostnames_inventory.test", "hostnames.1.network", "STAGING"),
+				resource.TestCheckResourceAttr("data.akamai_property_hostnames_inventory.test", "hostnames.1.cname_to", "www.example.com.edgekey.net"),
+				resource.TestCheckResourceAttr("data.akamai_property_hostnames_inventory.test", "hostnames.1.version", "3"),
+				resource.TestCheckResourceAttr("data.akamai_property_hostnames_inventory.test", "hostnames.2.cname_from", "www.example.com"),
+				resource.TestCheckResourceAttr("data.akamai_property_hostnames_inventory.test", "hostnames.2.network", "PRODUCTION"),
+				resource.TestCheckResourceAttr("data.akamai_property_hostnames_inventory.test", "hostnames.2.cname_to", "www.example.com.edgesuite.net"),
+				resource.TestCheckResourceAttr("data.akamai_property_hostnames_inventory.test", "hostnames.2.edge_hostname_id", "ehn_www.example.com"),
+				resource.TestCheckResourceAttr("data.akamai_property_hostnames_inventory.test", "hostnames.2.property_id", "prp_inventory_1"),
+				resource.TestCheckResourceAttr("data.akamai_property_hostnames_inventory.test", "hostnames.2.property_name", "www"),
+				resource.TestCheckResourceAttr("data.akamai_property_hostnames_inventory.test", "hostnames.2.group_id", "grp_1"),
+			),
+		},
+		"production hostnames of all groups in a contract": {
+			init: func(m *mockpapi) {
+				m.On("GetGroups", mock.Anything).Return(&papi.GetGroupsResponse{Groups: papi.GroupItems{Items: []*papi.Group{
+					{GroupID: "grp_1", ContractIDs: []string{"ctr_1"}},
+					{GroupID: "grp_2", ContractIDs: []string{"ctr_2"}},
+					{GroupID: "grp_3", ContractIDs: []string{"ctr_2", "ctr_1"}},
+				}}}, nil)
+				m.On("GetProperties", mock.Anything, papi.GetPropertiesRequest{ContractID: "ctr_1", GroupID: "grp_1"}).
+					Return(&papi.GetPropertiesResponse{Properties: papi.PropertiesItems{Items: []*papi.Property{
+						{PropertyID: "prp_1", PropertyName: "www", ContractID: "ctr_1", GroupID: "grp_1", StagingVersion: version(3), ProductionVersion: version(2)},
+					}}}, nil)
+				m.On("GetProperties", mock.Anything, papi.GetPropertiesRequest{ContractID: "ctr_1", GroupID: "grp_3"}).
+					Return(&papi.GetPropertiesResponse{Properties: papi.PropertiesItems{Items: []*papi.Property{
+						{PropertyID: "prp_3", PropertyName: "shop", ContractID: "ctr_1", GroupID: "grp_3", ProductionVersion: version(7)},
+					}}}, nil)
+				expectHostnames(m, "prp_1", "grp_1", 2, hostname("www.example.com", "www.example.com.edgesuite.net", "DEPLOYED"))
+				expectHostnames(m, "prp_3", "grp_3", 7, hostname("shop.example.com", "shop.example.com.edgesuite.net", "DEPLOYED"))
+			},
+			configPath: "testdata/TestDataPropertyHostnamesInventory/contract.tf",
+			checks: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("data.akamai_property_hostnames_inventory.test", "hostnames.#", "2"),
+				resource.TestCheckResourceAttr("data.akamai_property_hostnames_inventory.test", "hostnames.0.cname_from", "shop.example.com"),
+				resource.TestCheckResourceAttr("data.akamai_property_hostnames_inventory.test", "hostnames.0.group_id", "grp_3"),
+				resource.TestCheckResourceAttr("data.akamai_property_hostnames_inventory.test", "hostnames.0.version", "7"),
+				resource.TestCheckResourceAttr("data.akamai_property_hostnames_inventory.test", "hostnames.1.cname_from", "www.example.com"),
+				resource.TestCheckResourceAttr("data.akamai_property_hostnames_inventory.test", "hostnames.1.network", "PRODUCTION"),
+			),
+		},
+		"API error": {
+			init: func(m *mockpapi) {
+				m.On("GetProperties", mock.Anything, papi.GetPropertiesRequest{ContractID: "ctr_1", GroupID: "grp_1"}).
+					Return(nil, &papi.Error{StatusCode: 403, Title: "Forbidden"})
+			},
+			configPath: "testdata/TestDataPropertyHostnamesInventory/group.tf",
+			withError:  regexp.MustCompile("Forbidden"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &mockpapi{}
+			test.init(client)
+			useClient(client, func() {
+				resource.UnitTest(t, resource.TestCase{
+					Providers: testAccProviders,
+					Steps: []resource.TestStep{{
+						Config:      loadFixtureString(test.configPath),
+						Check:       test.checks,
+						ExpectError: test.withError,
+					}},
+				})
+			})
+			client.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,463 @@
+package property
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+)
+
+func dataSourceAkamaiPropertyRulesComposition() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataAkamaiPropertyRulesCompositionRead,
+		Schema: map[string]*schema.Schema{
+			"base": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: tools.ValidateJSON,
+				Description:      "Base property rules as JSON",
+			},
+			"overlay": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Patches applied to the base rules in the given order",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  OverlayTypeMergePatch,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+								OverlayTypeMergePatch, OverlayTypeJSONPatch,
+							}, false)),
+						},
+						"patch": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsJSON),
+							Description:      "JSON merge patch object or list of JSON patch operations",
+						},
+					},
+				},
+			},
+			"json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Property rules as JSON, with all overlays applied",
+			},
+		},
+	}
+}
+
+const (
+	// OverlayTypeMergePatch is an overlay merged into the rules as an RFC 7386 JSON merge patch
+	OverlayTypeMergePatch = "merge_patch"
+	// OverlayTypeJSONPatch is an overlay applied to the rules as a list of RFC 6902 JSON patch operations
+	OverlayTypeJSONPatch = "json_patch"
+)
+
+var (
+	// ErrRulesPatchPath is returned when a patch path does not exist in the rules
+	ErrRulesPatchPath = errors.New("path not found")
+	// ErrRulesPatchOperation is returned when a JSON patch operation is not valid
+	ErrRulesPatchOperation = errors.New("invalid patch operation")
+	// ErrRulesPatchTest is returned when the value of a JSON patch test operation does not match
+	ErrRulesPatchTest = errors.New("test failed")
+)
+
+// namedRuleArrays are the rule tree arrays whose items are addressed by name instead of index
+var namedRuleArrays = map[string]bool{
+	"children":  true,
+	"behaviors": true,
+	"criteria":  true,
+	"variables": true,
+}
+
+// rulesPatchOperation is a single RFC 6902 operation, whose path can use names to address rule tree array items
+type rulesPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+func dataAkamaiPropertyRulesCompositionRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "dataAkamaiPropertyRulesCompositionRead")
+
+	var rules interface{}
+	if err := json.Unmarshal([]byte(d.Get("base").(string)), &rules); err != nil {
+		return diag.Errorf("base rules are not valid JSON: %s", err)
+	}
+
+	for i, item := range d.Get("overlay").([]interface{}) {
+		overlay := item.(map[string]interface{})
+		logger.Debugf("Applying overlay %d of type %s", i, overlay["type"])
+		var err error
+		switch overlay["type"].(string) {
+		case OverlayTypeJSONPatch:
+			var operations []rulesPatchOperation
+			if err := json.Unmarshal([]byte(overlay["patch"].(string)), &operations); err != nil {
+				return diag.Errorf("overlay %d: JSON patch must be a list of operations: %s", i, err)
+			}
+			rules, err = applyRulesJSONPatch(rules, operations)
+		default:
+			var patch interface{}
+			if err := json.Unmarshal([]byte(overlay["patch"].(string)), &patch); err != nil {
+				return diag.Errorf("overlay %d: %s", i, err)
+			}
+			rules = mergeRulesPatch(rules, patch)
+		}
+		if err != nil {
+			return diag.Errorf("overlay %d: %s", i, err)
+		}
+	}
+
+	composed, err := json.MarshalIndent(rules, "", "  ")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var ruleTree papi.RulesUpdate
+	if err := json.Unmarshal(composed, &ruleTree); err != nil {
+		logger.Debugf("Composing rules resulted in an invalid rule tree: %s", composed)
+		return diag.Errorf("composed rules are not a valid rule tree: %s", err)
+	}
+
+	h := sha1.New()
+	h.Write(composed)
+	d.SetId(hex.EncodeToString(h.Sum(nil)))
+	if err := d.Set("json", string(composed)); err != nil {
+		return diag.Errorf("%v: %s", tools.ErrValueSet, err.Error())
+	}
+	return nil
+}
+
+// mergeRulesPatch merges patch into target following RFC 7386, except that items of rule tree arrays
+// (children, behaviors, criteria and variables) are merged with the target item of the same name instead of replacing the array
+func mergeRulesPatch(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = make(map[string]interface{})
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetItems, targetIsArray := targetObject[key].([]interface{})
+		patchItems, patchIsArray := value.([]interface{})
+		if namedRuleArrays[key] && targetIsArray && patchIsArray {
+			targetObject[key] = mergeNamedItems(targetItems, patchItems)
+			continue
+		}
+		targetObject[key] = mergeRulesPatch(targetObject[key], value)
+	}
+
+	return targetObject
+}
+
+// mergeNamedItems merges each patch item into the target item of the same name, items with new names are appended
+func mergeNamedItems(target, patch []interface{}) []interface{} {
+	index := make(map[string]int, len(target))
+	for i, key := range namedItemKeys(target) {
+		if key != "" {
+			index[key] = i
+		}
+	}
+
+	counts := make(map[string]int)
+	for _, item := range patch {
+		name, ok := itemName(item)
+		if !ok {
+			target = append(target, item)
+			continue
+		}
+		if i, ok := index[occurrenceKey(name, counts)]; ok {
+			target[i] = mergeRulesPatch(target[i], item)
+			continue
+		}
+		target = append(target, mergeRulesPatch(nil, item))
+	}
+
+	return target
+}
+
+// applyRulesJSONPatch applies the operations to the rules following RFC 6902
+func applyRulesJSONPatch(rules interface{}, operations []rulesPatchOperation) (interface{}, error) {
+	for i, op := range operations {
+		var err error
+		rules, err = applyRulesPatchOperation(rules, op)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+	return rules, nil
+}
+
+func applyRulesPatchOperation(rules interface{}, op rulesPatchOperation) (interface{}, error) {
+	path, err := parseRulesPointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case "add", "replace", "test":
+		if len(op.Value) == 0 {
+			return nil, fmt.Errorf("%w: value is required", ErrRulesPatchOperation)
+		}
+		var value interface{}
+		if err := json.Unmarshal(op.Value, &value); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrRulesPatchOperation, err)
+		}
+		switch op.Op {
+		case "add":
+			return addRulesValue(rules, path, value)
+		case "replace":
+			return replaceRulesValue(rules, path, value)
+		default:
+			current, err := getRulesValue(rules, path)
+			if err != nil {
+				return nil, err
+			}
+			if !reflect.DeepEqual(current, value) {
+				return nil, ErrRulesPatchTest
+			}
+			return rules, nil
+		}
+	case "remove":
+		return removeRulesValue(rules, path)
+	case "move", "copy":
+		from, err := parseRulesPointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		value, err := getRulesValue(rules, from)
+		if err != nil {
+			return nil, fmt.Errorf("from: %w", err)
+		}
+		if op.Op == "move" {
+			if rules, err = removeRulesValue(rules, from); err != nil {
+				return nil, err
+			}
+		} else if value, err = copyRulesValue(value); err != nil {
+			return nil, err
+		}
+		return addRulesValue(rules, path, value)
+	}
+
+	return nil, fmt.Errorf("%w: unknown op %q", ErrRulesPatchOperation, op.Op)
+}
+
+// parseRulesPointer splits a JSON pointer into its unescaped reference tokens
+func parseRulesPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: path %q must start with /", ErrRulesPatchOperation, pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+	return tokens, nil
+}
+
+func getRulesValue(rules interface{}, path []string) (interface{}, error) {
+	current := rules
+	for _, token := range path {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("%w: %q", ErrRulesPatchPath, token)
+			}
+			current = value
+		case []interface{}:
+			i, err := arrayItemIndex(node, token, false)
+			if err != nil {
+				return nil, err
+			}
+			current = node[i]
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrRulesPatchPath, token)
+		}
+	}
+	return current, nil
+}
+
+func addRulesValue(rules interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return updateRulesParent(rules, path, func(parent interface{}, token string) (interface{}, error) {
+		switch node := parent.(type) {
+		case map[string]interface{}:
+			node[token] = value
+			return node, nil
+		case []interface{}:
+			i, err := arrayItemIndex(node, token, true)
+			if err != nil {
+				return nil, err
+			}
+			node = append(node, nil)
+			copy(node[i+1:], node[i:])
+			node[i] = value
+			return node, nil
+		}
+		return nil, fmt.Errorf("%w: %q", ErrRulesPatchPath, token)
+	})
+}
+
+func replaceRulesValue(rules interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return updateRulesParent(rules, path, func(parent interface{}, token string) (interface{}, error) {
+		switch node := parent.(type) {
+		case map[string]interface{}:
+			if _, ok := node[token]; !ok {
+				return nil, fmt.Errorf("%w: %q", ErrRulesPatchPath, token)
+			}
+			node[token] = value
+			return node, nil
+		case []interface{}:
+			i, err := arrayItemIndex(node, token, false)
+			if err != nil {
+				return nil, err
+			}
+			node[i] = value
+			return node, nil
+		}
+		return nil, fmt.Errorf("%w: %q", ErrRulesPatchPath, token)
+	})
+}
+
+func removeRulesValue(rules interface{}, path []string) (interface{}, error) {
+	if len(path) == 0 {
+		return nil, fmt.Errorf("%w: cannot remove the whole document", ErrRulesPatchOperation)
+	}
+	return updateRulesParent(rules, path, func(parent interface{}, token string) (interface{}, error) {
+		switch node := parent.(type) {
+		case map[string]interface{}:
+			if _, ok := node[token]; !ok {
+				return nil, fmt.Errorf("%w: %q", ErrRulesPatchPath, token)
+			}
+			delete(node, token)
+			return node, nil
+		case []interface{}:
+			i, err := arrayItemIndex(node, token, false)
+			if err != nil {
+				return nil, err
+			}
+			return append(node[:i], node[i+1:]...), nil
+		}
+		return nil, fmt.Errorf("%w: %q", ErrRulesPatchPath, token)
+	})
+}
+
+// updateRulesParent walks down to the parent of the last path token and replaces it with the result of update
+// arrays are replaced on the way back up as adding or removing items may reallocate them
+func updateRulesParent(node interface{}, path []string, update func(parent interface{}, token string) (interface{}, error)) (interface{}, error) {
+	if len(path) == 1 {
+		return update(node, path[0])
+	}
+
+	token := path[0]
+	switch n := node.(type) {
+	case map[string]interface{}:
+		child, ok := n[token]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrRulesPatchPath, token)
+		}
+		child, err := updateRulesParent(child, path[1:], update)
+		if err != nil {
+			return nil, err
+		}
+		n[token] = child
+		return n, nil
+	case []interface{}:
+		i, err := arrayItemIndex(n, token, false)
+		if err != nil {
+			return nil, err
+		}
+		child, err := updateRulesParent(n[i], path[1:], update)
+		if err != nil {
+			return nil, err
+		}
+		n[i] = child
+		return n, nil
+	}
+
+	return nil, fmt.Errorf("%w: %q", ErrRulesPatchPath, token)
+}
+
+// arrayItemIndex resolves a path token to an array index
+// The token is first matched against item names, where "name#2" addresses the second item with a repeated name,
+// then used as a numeric index. When adding, "-" and the array length address the end of the array.
+func arrayItemIndex(items []interface{}, token string, adding bool) (int, error) {
+	for i, key := range namedItemKeys(items) {
+		if key != "" && key == token {
+			return i, nil
+		}
+	}
+	if adding && token == "-" {
+		return len(items), nil
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || i > len(items) || (i == len(items) && !adding) {
+		return 0, fmt.Errorf("%w: no item named or at index %q", ErrRulesPatchPath, token)
+	}
+	return i, nil
+}
+
+// namedItemKeys returns the occurrence keys of named items, unnamed items get an empty key
+func namedItemKeys(items []interface{}) []string {
+	keys := make([]string, len(items))
+	counts := make(map[string]int)
+	for i, item := range items {
+		if name, ok := itemName(item); ok {
+			keys[i] = occurrenceKey(name, counts)
+		}
+	}
+	return keys
+}
+
+func itemName(item interface{}) (string, bool) {
+	object, ok := item.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	name, ok := object["name"].(string)
+	return name, ok
+}
+
+func copyRulesValue(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var copied interface{}
+	if err := json.Unmarshal(data, &copied); err != nil {
+		return nil, err
+	}
+	return copied, nil
+}
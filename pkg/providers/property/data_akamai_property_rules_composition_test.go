@@ -0,0 +1,208 @@
+package property
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataPropertyRulesComposition(t *testing.T) {
+	// behaviors are listed in a different order than the composition produces them
+	expectedRules := `{"rules": {
+		"name": "default",
+		"behaviors": [
+			{"name": "caching", "options": {"behavior": "MAX_AGE", "ttl": "1h"}},
+			{"name": "origin", "options": {"hostname": "staging-origin.example.com", "httpPort": 80}}
+		],
+		"children": [
+			{"name": "Performance", "behaviors": [
+				{"name": "http2", "options": {}},
+				{"name": "prefetch", "options": {"enabled": true}}
+			]},
+			{"name": "Staging", "criteria": [{"name": "hostname", "options": {"values": ["staging.example.com"]}}]}
+		]
+	}}`
+
+	tests := map[string]struct {
+		configPath string
+		checks     resource.TestCheckFunc
+		withError  *regexp.Regexp
+	}{
+		"merge and JSON patch overlays": {
+			configPath: "testdata/TestDataPropertyRulesComposition/overlays.tf",
+			checks: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttrSet("data.akamai_property_rules_composition.test", "id"),
+				func(s *terraform.State) error {
+					rs := s.RootModule().Resources["data.akamai_property_rules_composition.test"]
+					if !compareRulesJSON(expectedRules, rs.Primary.Attributes["json"]) {
+						return fmt.Errorf("unexpected composed rules: %s", rs.Primary.Attributes["json"])
+					}
+					return nil
+				},
+			),
+		},
+		"JSON patch path not found": {
+			configPath: "testdata/TestDataPropertyRulesComposition/missing_path.tf",
+			withError:  regexp.MustCompile(`overlay 0: operation 0 \(remove /rules/children/Static\): path not found: "children"`),
+		},
+		"composed rules are not a rule tree": {
+			configPath: "testdata/TestDataPropertyRulesComposition/invalid_rule_tree.tf",
+			withError:  regexp.MustCompile("composed rules are not a valid rule tree"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{{
+					Config:      loadFixtureString(test.configPath),
+					Check:       test.checks,
+					ExpectError: test.withError,
+				}},
+			})
+		})
+	}
+}
+
+func TestMergeRulesPatch(t *testing.T) {
+	base := `{"rules": {
+		"name": "default",
+		"comments": "base",
+		"behaviors": [
+			{"name": "modifyOutgoingResponseHeader", "options": {"action": "ADD", "customHeaderName": "X-A"}},
+			{"name": "modifyOutgoingResponseHeader", "options": {"action": "ADD", "customHeaderName": "X-B"}}
+		],
+		"options": {"is_secure": false}
+	}}`
+	patch := `{"rules": {
+		"comments": null,
+		"behaviors": [
+			{"name": "modifyOutgoingResponseHeader"},
+			{"name": "modifyOutgoingResponseHeader", "options": {"customHeaderName": "X-C"}},
+			{"name": "gzipResponse", "options": {"behavior": "ALWAYS", "unused": null}}
+		],
+		"options": {"is_secure": true}
+	}}`
+	expected := `{"rules": {
+		"name": "default",
+		"behaviors": [
+			{"name": "modifyOutgoingResponseHeader", "options": {"action": "ADD", "customHeaderName": "X-A"}},
+			{"name": "modifyOutgoingResponseHeader", "options": {"action": "ADD", "customHeaderName": "X-C"}},
+			{"name": "gzipResponse", "options": {"behavior": "ALWAYS"}}
+		],
+		"options": {"is_secure": true}
+	}}`
+
+	var baseRules, patchRules interface{}
+	require.NoError(t, json.Unmarshal([]byte(base), &baseRules))
+	require.NoError(t, json.Unmarshal([]byte(patch), &patchRules))
+	merged, err := json.Marshal(mergeRulesPatch(baseRules, patchRules))
+	require.NoError(t, err)
+	assert.JSONEq(t, expected, string(merged))
+}
+
+func TestApplyRulesJSONPatch(t *testing.T) {
+	base := `{"rules": {
+		"name": "default",
+		"behaviors": [
+			{"name": "origin", "options": {"hostname": "origin.example.com"}},
+			{"name": "caching", "options": {"ttl": "1d"}},
+			{"name": "caching", "options": {"ttl": "7d"}}
+		],
+		"children": [
+			{"name": "Images/Video", "behaviors": []},
+			{"name": "Static"}
+		]
+	}}`
+
+	tests := map[string]struct {
+		patch     string
+		expected  string
+		withError error
+	}{
+		"address repeated names and escaped names": {
+			patch: `[
+				{"op": "replace", "path": "/rules/behaviors/caching#2/options/ttl", "value": "1h"},
+				{"op": "add", "path": "/rules/children/Images~1Video/behaviors/0", "value": {"name": "imageManager"}}
+			]`,
+			expected: `{"rules": {
+				"name": "default",
+				"behaviors": [
+					{"name": "origin", "options": {"hostname": "origin.example.com"}},
+					{"name": "caching", "options": {"ttl": "1d"}},
+					{"name": "caching", "options": {"ttl": "1h"}}
+				],
+				"children": [
+					{"name": "Images/Video", "behaviors": [{"name": "imageManager"}]},
+					{"name": "Static"}
+				]
+			}}`,
+		},
+		"move, copy and test": {
+			patch: `[
+				{"op": "test", "path": "/rules/behaviors/origin/options/hostname", "value": "origin.example.com"},
+				{"op": "move", "from": "/rules/children/Static", "path": "/rules/children/Images~1Video"},
+				{"op": "copy", "from": "/rules/behaviors/origin", "path": "/rules/children/Static/behaviors"},
+				{"op": "remove", "path": "/rules/behaviors/caching"}
+			]`,
+			expected: `{"rules": {
+				"name": "default",
+				"behaviors": [
+					{"name": "origin", "options": {"hostname": "origin.example.com"}},
+					{"name": "caching", "options": {"ttl": "7d"}}
+				],
+				"children": [
+					{"name": "Static", "behaviors": {"name": "origin", "options": {"hostname": "origin.example.com"}}},
+					{"name": "Images/Video", "behaviors": []}
+				]
+			}}`,
+		},
+		"failed test": {
+			patch:     `[{"op": "test", "path": "/rules/name", "value": "other"}]`,
+			withError: ErrRulesPatchTest,
+		},
+		"unknown name": {
+			patch:     `[{"op": "replace", "path": "/rules/behaviors/gzipResponse/options", "value": {}}]`,
+			withError: ErrRulesPatchPath,
+		},
+		"index out of range": {
+			patch:     `[{"op": "remove", "path": "/rules/children/2"}]`,
+			withError: ErrRulesPatchPath,
+		},
+		"missing value": {
+			patch:     `[{"op": "add", "path": "/rules/comments"}]`,
+			withError: ErrRulesPatchOperation,
+		},
+		"unknown op": {
+			patch:     `[{"op": "merge", "path": "/rules"}]`,
+			withError: ErrRulesPatchOperation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var rules interface{}
+			var operations []rulesPatchOperation
+			require.NoError(t, json.Unmarshal([]byte(base), &rules))
+			require.NoError(t, json.Unmarshal([]byte(test.patch), &operations))
+
+			patched, err := applyRulesJSONPatch(rules, operations)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			result, err := json.Marshal(patched)
+			require.NoError(t, err)
+			assert.JSONEq(t, test.expected, string(result))
+		})
+	}
+}
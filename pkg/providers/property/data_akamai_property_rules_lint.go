@@ -0,0 +1,425 @@
+package property
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+)
+
+func dataSourceAkamaiPropertyRulesLint() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataAkamaiPropertyRulesLintRead,
+		Schema: map[string]*schema.Schema{
+			"rules": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: tools.ValidateJSON,
+				Description:      "Property rules as JSON",
+			},
+			"skip_checks": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Built-in checks which are not run",
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validateLintCheck,
+				},
+			},
+			"assertion": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "JSONPath assertions run against the rules",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: tools.IsNotBlank,
+						},
+						"path": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validateJSONPath,
+							Description:      "JSONPath expression evaluated against the rules",
+						},
+						"expect": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  LintExpectPresent,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+								LintExpectPresent, LintExpectAbsent,
+							}, false)),
+							Description: "Whether the path must match at least one value or none",
+						},
+						"severity": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  LintSeverityError,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+								LintSeverityError, LintSeverityWarning,
+							}, false)),
+						},
+						"message": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"findings": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Warnings found in the rules, errors fail the read instead",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"check":     {Type: schema.TypeString, Computed: true},
+						"severity":  {Type: schema.TypeString, Computed: true},
+						"rule_path": {Type: schema.TypeString, Computed: true},
+						"message":   {Type: schema.TypeString, Computed: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+const (
+	// LintSeverityError findings fail the data source read
+	LintSeverityError = "error"
+	// LintSeverityWarning findings are reported as warnings
+	LintSeverityWarning = "warning"
+
+	// LintExpectPresent assertions fail when their path matches nothing
+	LintExpectPresent = "present"
+	// LintExpectAbsent assertions fail when their path matches anything
+	LintExpectAbsent = "absent"
+
+	lintDuplicateRuleNames = "duplicate_rule_names"
+	lintUnreachableChild   = "unreachable_children"
+	lintMissingCPCode      = "missing_cp_code"
+	lintNonTLSOrigin       = "non_tls_origin"
+)
+
+var lintBuiltinChecks = []string{lintDuplicateRuleNames, lintUnreachableChild, lintMissingCPCode, lintNonTLSOrigin}
+
+// lintFinding is a single problem found in a rule tree
+type lintFinding struct {
+	check    string
+	severity string
+	rulePath string
+	message  string
+}
+
+func dataAkamaiPropertyRulesLintRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "dataAkamaiPropertyRulesLintRead")
+
+	rulesJSON := d.Get("rules").(string)
+	var rules papi.RulesUpdate
+	if err := json.Unmarshal([]byte(rulesJSON), &rules); err != nil {
+		return diag.Errorf("rules are not a valid rule tree: %s", err)
+	}
+	var document interface{}
+	if err := json.Unmarshal([]byte(rulesJSON), &document); err != nil {
+		return diag.Errorf("rules are not valid JSON: %s", err)
+	}
+
+	skip := make(map[string]bool)
+	for _, check := range d.Get("skip_checks").(*schema.Set).List() {
+		skip[check.(string)] = true
+	}
+	findings := lintRules(&rules.Rules, skip)
+
+	for _, item := range d.Get("assertion").([]interface{}) {
+		assertion := item.(map[string]interface{})
+		finding, err := lintAssertion(document, assertion)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if finding != nil {
+			findings = append(findings, *finding)
+		}
+	}
+	logger.Debugf("Found %d problems in rules", len(findings))
+
+	var diags diag.Diagnostics
+	warnings := make([]interface{}, 0, len(findings))
+	for _, f := range findings {
+		diagnostic := diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("%s: %s", f.check, f.message),
+		}
+		if f.rulePath != "" {
+			diagnostic.Detail = fmt.Sprintf("in rule %s", f.rulePath)
+		}
+		if f.severity == LintSeverityError {
+			diagnostic.Severity = diag.Error
+			diags = append(diags, diagnostic)
+			continue
+		}
+		diags = append(diags, diagnostic)
+		warnings = append(warnings, map[string]interface{}{
+			"check":     f.check,
+			"severity":  f.severity,
+			"rule_path": f.rulePath,
+			"message":   f.message,
+		})
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	h := sha1.New()
+	h.Write([]byte(rulesJSON))
+	d.SetId(hex.EncodeToString(h.Sum(nil)))
+	if err := d.Set("findings", warnings); err != nil {
+		return diag.Errorf("%v: %s", tools.ErrValueSet, err.Error())
+	}
+	return diags
+}
+
+// lintAssertion returns a finding when the assertion path does not match as expected
+func lintAssertion(document interface{}, assertion map[string]interface{}) (*lintFinding, error) {
+	name := assertion["name"].(string)
+	path := assertion["path"].(string)
+	segments, err := parseJSONPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("assertion %q: %w", name, err)
+	}
+
+	matched := len(evalJSONPath(segments, document))
+	var message string
+	switch assertion["expect"].(string) {
+	case LintExpectAbsent:
+		if matched == 0 {
+			return nil, nil
+		}
+		message = fmt.Sprintf("%s matched %d values", path, matched)
+	default:
+		if matched > 0 {
+			return nil, nil
+		}
+		message = fmt.Sprintf("%s matched nothing", path)
+	}
+	if custom := assertion["message"].(string); custom != "" {
+		message = custom
+	}
+
+	return &lintFinding{check: name, severity: assertion["severity"].(string), message: message}, nil
+}
+
+// lintRules runs the built-in checks which are not skipped against the rule tree
+func lintRules(rules *papi.Rules, skip map[string]bool) []lintFinding {
+	var findings []lintFinding
+	if !skip[lintMissingCPCode] && !hasBehavior(rules, "cpCode") {
+		findings = append(findings, lintFinding{
+			check:    lintMissingCPCode,
+			severity: LintSeverityError,
+			rulePath: rules.Name,
+			message:  "the default rule has no cpCode behavior",
+		})
+	}
+	return append(findings, lintRule(rules.Name, rules, nil, skip)...)
+}
+
+func lintRule(path string, rule *papi.Rules, ancestors []*papi.Rules, skip map[string]bool) []lintFinding {
+	var findings []lintFinding
+
+	if !skip[lintNonTLSOrigin] {
+		for _, behavior := range rule.Behaviors {
+			switch behavior.Name {
+			case "origin":
+				if behavior.Options["originType"] == "NET_STORAGE" {
+					continue
+				}
+				if port, ok := behavior.Options["httpsPort"].(float64); !ok || port == 0 {
+					findings = append(findings, lintFinding{
+						check:    lintNonTLSOrigin,
+						severity: LintSeverityWarning,
+						rulePath: path,
+						message:  fmt.Sprintf("origin %v has no HTTPS port", behavior.Options["hostname"]),
+					})
+				}
+			case "allowHttpsDowngrade":
+				if enabled, _ := behavior.Options["enabled"].(bool); enabled {
+					findings = append(findings, lintFinding{
+						check:    lintNonTLSOrigin,
+						severity: LintSeverityWarning,
+						rulePath: path,
+						message:  "allowHttpsDowngrade sends HTTPS requests to the origin over HTTP",
+					})
+				}
+			}
+		}
+	}
+
+	if !skip[lintDuplicateRuleNames] {
+		counts := make(map[string]int)
+		var names []string
+		for _, child := range rule.Children {
+			if counts[child.Name] == 1 {
+				names = append(names, child.Name)
+			}
+			counts[child.Name]++
+		}
+		for _, name := range names {
+			findings = append(findings, lintFinding{
+				check:    lintDuplicateRuleNames,
+				severity: LintSeverityWarning,
+				rulePath: path,
+				message:  fmt.Sprintf("%d child rules are named %q", counts[name], name),
+			})
+		}
+	}
+
+	ancestors = append(ancestors, rule)
+	for i := range rule.Children {
+		child := &rule.Children[i]
+		childPath := path + "/" + child.Name
+		if !skip[lintUnreachableChild] {
+			if reason := unreachableReason(child, ancestors); reason != "" {
+				findings = append(findings, lintFinding{
+					check:    lintUnreachableChild,
+					severity: LintSeverityWarning,
+					rulePath: childPath,
+					message:  reason,
+				})
+			}
+		}
+		findings = append(findings, lintRule(childPath, child, ancestors, skip)...)
+	}
+
+	return findings
+}
+
+// unreachableReason explains why the rule can never match, or returns an empty string
+// A rule is unreachable when a criterion it requires only accepts values that a criterion required by an ancestor rejects
+func unreachableReason(rule *papi.Rules, ancestors []*papi.Rules) string {
+	for _, criterion := range requiredCriteria(rule) {
+		values, ok := criterionValues(criterion)
+		if !ok {
+			continue
+		}
+		for _, ancestor := range ancestors {
+			for _, ancestorCriterion := range requiredCriteria(ancestor) {
+				if ancestorCriterion.Name != criterion.Name {
+					continue
+				}
+				ancestorValues, ok := criterionValues(ancestorCriterion)
+				if ok && !valuesOverlap(ancestorValues, values) {
+					return fmt.Sprintf("%s criterion never matches within rule %q", criterion.Name, ancestor.Name)
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// requiredCriteria returns the criteria which all have to match for the rule to apply
+func requiredCriteria(rule *papi.Rules) []papi.RuleBehavior {
+	if len(rule.Criteria) == 1 || rule.CriteriaMustSatisfy != papi.RuleCriteriaMustSatisfyAny {
+		return rule.Criteria
+	}
+	return nil
+}
+
+// criterionValues returns the values of a criterion matching one of a list of values
+func criterionValues(criterion papi.RuleBehavior) ([]string, bool) {
+	if criterion.Options["matchOperator"] != "IS_ONE_OF" {
+		return nil, false
+	}
+	list, ok := criterion.Options["values"].([]interface{})
+	if !ok || len(list) == 0 {
+		return nil, false
+	}
+	values := make([]string, 0, len(list))
+	for _, v := range list {
+		value, ok := v.(string)
+		if !ok {
+			return nil, false
+		}
+		values = append(values, strings.ToLower(value))
+	}
+	return values, true
+}
+
+// valuesOverlap reports whether a request could match both lists of values, values may use * and ? wildcards
+func valuesOverlap(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			xWildcard := strings.ContainsAny(x, "*?")
+			yWildcard := strings.ContainsAny(y, "*?")
+			switch {
+			case xWildcard && yWildcard:
+				if patternsOverlap(x, y) {
+					return true
+				}
+			case xWildcard:
+				if wildcardRegexp(x).MatchString(y) {
+					return true
+				}
+			case yWildcard:
+				if wildcardRegexp(y).MatchString(x) {
+					return true
+				}
+			case x == y:
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// patternsOverlap compares the literal text before the first and after the last wildcard of two patterns,
+// patterns whose literal prefixes or suffixes differ cannot match the same value
+func patternsOverlap(x, y string) bool {
+	xPrefix, yPrefix := x[:strings.IndexAny(x, "*?")], y[:strings.IndexAny(y, "*?")]
+	xSuffix, ySuffix := x[strings.LastIndexAny(x, "*?")+1:], y[strings.LastIndexAny(y, "*?")+1:]
+	return (strings.HasPrefix(xPrefix, yPrefix) || strings.HasPrefix(yPrefix, xPrefix)) &&
+		(strings.HasSuffix(xSuffix, ySuffix) || strings.HasSuffix(ySuffix, xSuffix))
+}
+
+func wildcardRegexp(pattern string) *regexp.Regexp {
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.NewReplacer(`\*`, ".*", `\?`, ".").Replace(expr)
+	return regexp.MustCompile("^" + expr + "$")
+}
+
+func hasBehavior(rule *papi.Rules, name string) bool {
+	for _, behavior := range rule.Behaviors {
+		if behavior.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func validateJSONPath(v interface{}, _ cty.Path) diag.Diagnostics {
+	if _, err := parseJSONPath(v.(string)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// validateLintCheck checks a set element, for which validation.ToDiagFunc cannot build the attribute path
+func validateLintCheck(v interface{}, _ cty.Path) diag.Diagnostics {
+	for _, check := range lintBuiltinChecks {
+		if v.(string) == check {
+			return nil
+		}
+	}
+	return diag.Errorf("unknown check %q, expected one of %s", v, strings.Join(lintBuiltinChecks, ", "))
+}
@@ -0,0 +1,114 @@
+package property
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataPropertyRulesLint(t *testing.T) {
+	tests := map[string]struct {
+		configPath string
+		checks     resource.TestCheckFunc
+		withError  *regexp.Regexp
+	}{
+		"built-in checks and assertion warnings": {
+			configPath: "testdata/TestDataPropertyRulesLint/warnings.tf",
+			checks: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttrSet("data.akamai_property_rules_lint.test", "id"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_lint.test", "findings.#", "4"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_lint.test", "findings.0.check", "non_tls_origin"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_lint.test", "findings.0.severity", "warning"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_lint.test", "findings.0.rule_path", "default"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_lint.test", "findings.0.message", "origin origin.example.com has no HTTPS port"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_lint.test", "findings.1.check", "duplicate_rule_names"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_lint.test", "findings.1.message", `2 child rules are named "Offload"`),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_lint.test", "findings.2.check", "unreachable_children"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_lint.test", "findings.2.rule_path", "default/Static/Images"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_lint.test", "findings.3.check", "hsts"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_lint.test", "findings.3.rule_path", ""),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_lint.test", "findings.3.message", "HSTS must be enabled"),
+			),
+		},
+		"skipped checks": {
+			configPath: "testdata/TestDataPropertyRulesLint/skip_checks.tf",
+			checks:     resource.TestCheckResourceAttr("data.akamai_property_rules_lint.test", "findings.#", "0"),
+		},
+		"errors fail the read": {
+			configPath: "testdata/TestDataPropertyRulesLint/errors.tf",
+			withError:  regexp.MustCompile(`(?s)missing_cp_code: the default rule has no cpCode behavior.*static_caching: .* matched 1 values`),
+		},
+		"invalid JSONPath": {
+			configPath: "testdata/TestDataPropertyRulesLint/invalid_path.tf",
+			withError:  regexp.MustCompile(`invalid JSONPath expression: "rules.behaviors" must start with \$`),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{{
+					Config:      loadFixtureString(test.configPath),
+					Check:       test.checks,
+					ExpectError: test.withError,
+				}},
+			})
+		})
+	}
+}
+
+func TestUnreachableReason(t *testing.T) {
+	pathCriterion := func(values ...interface{}) papi.RuleBehavior {
+		return papi.RuleBehavior{Name: "path", Options: papi.RuleOptionsMap{"matchOperator": "IS_ONE_OF", "values": values}}
+	}
+	hostCriterion := papi.RuleBehavior{Name: "hostname", Options: papi.RuleOptionsMap{"matchOperator": "IS_ONE_OF", "values": []interface{}{"www.example.com"}}}
+	parent := &papi.Rules{Name: "Static", Criteria: []papi.RuleBehavior{pathCriterion("/static/*", "/assets/*")}}
+
+	tests := map[string]struct {
+		rule      papi.Rules
+		ancestors []*papi.Rules
+		expected  string
+	}{
+		"disjoint values": {
+			rule:      papi.Rules{Criteria: []papi.RuleBehavior{pathCriterion("/api/*")}},
+			ancestors: []*papi.Rules{{Name: "default"}, parent},
+			expected:  `path criterion never matches within rule "Static"`,
+		},
+		"literal matching a wildcard": {
+			rule:      papi.Rules{Criteria: []papi.RuleBehavior{pathCriterion("/assets/logo.png")}},
+			ancestors: []*papi.Rules{parent},
+		},
+		"child criteria are alternatives": {
+			rule: papi.Rules{
+				Criteria:            []papi.RuleBehavior{pathCriterion("/api/*"), hostCriterion},
+				CriteriaMustSatisfy: papi.RuleCriteriaMustSatisfyAny,
+			},
+			ancestors: []*papi.Rules{parent},
+		},
+		"ancestor criteria are alternatives": {
+			rule: papi.Rules{Criteria: []papi.RuleBehavior{pathCriterion("/api/*")}},
+			ancestors: []*papi.Rules{{
+				Name:                "Static",
+				Criteria:            []papi.RuleBehavior{pathCriterion("/static/*"), hostCriterion},
+				CriteriaMustSatisfy: papi.RuleCriteriaMustSatisfyAny,
+			}},
+		},
+		"other match operator": {
+			rule: papi.Rules{Criteria: []papi.RuleBehavior{{
+				Name:    "path",
+				Options: papi.RuleOptionsMap{"matchOperator": "IS_NOT_ONE_OF", "values": []interface{}{"/api/*"}},
+			}}},
+			ancestors: []*papi.Rules{parent},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, unreachableReason(&test.rule, test.ancestors))
+		})
+	}
+}
@@ -0,0 +1,320 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+)
+
+func dataSourceAkamaiPropertyRulesUpgrade() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataAkamaiPropertyRulesUpgradeRead,
+		Schema: map[string]*schema.Schema{
+			"property_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: tools.IsNotBlank,
+				Description:      "The property used to convert the rules, no changes are saved to it",
+			},
+			"contract_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"group_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"version": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The property version used to convert the rules, defaults to the latest version",
+			},
+			"rules": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: tools.ValidateJSON,
+				Description:      "Property rules as JSON, in the source rule format",
+			},
+			"source_rule_format": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateRuleFormat,
+			},
+			"target_rule_format": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateRuleFormat,
+			},
+			"upgraded_rules": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Property rules as JSON, converted to the target rule format",
+			},
+			"changes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Rules, behaviors and criteria changed by the conversion",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"rule_path": {Type: schema.TypeString, Computed: true},
+						"type":      {Type: schema.TypeString, Computed: true},
+						"name":      {Type: schema.TypeString, Computed: true},
+						"change":    {Type: schema.TypeString, Computed: true},
+						"options": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+const (
+	// RuleChangeAdded is reported for rules, behaviors and criteria added by a rule format upgrade
+	RuleChangeAdded = "added"
+	// RuleChangeRemoved is reported for rules, behaviors and criteria removed by a rule format upgrade
+	RuleChangeRemoved = "removed"
+	// RuleChangeModified is reported for behaviors and criteria whose options were changed by a rule format upgrade
+	RuleChangeModified = "modified"
+)
+
+// ruleChange is a single difference between two rule trees
+type ruleChange struct {
+	rulePath string
+	kind     string
+	name     string
+	change   string
+	options  []string
+}
+
+func dataAkamaiPropertyRulesUpgradeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	client := inst.Client(meta)
+	log := meta.Log("PAPI", "dataAkamaiPropertyRulesUpgradeRead")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(log),
+	)
+
+	propertyID := tools.AddPrefix(d.Get("property_id").(string), "prp_")
+	contractID := d.Get("contract_id").(string)
+	if contractID != "" {
+		contractID = tools.AddPrefix(contractID, "ctr_")
+	}
+	groupID := d.Get("group_id").(string)
+	if groupID != "" {
+		groupID = tools.AddPrefix(groupID, "grp_")
+	}
+	sourceFormat := d.Get("source_rule_format").(string)
+	targetFormat := d.Get("target_rule_format").(string)
+
+	var rules papi.RulesUpdate
+	if err := json.Unmarshal([]byte(d.Get("rules").(string)), &rules); err != nil {
+		return diag.Errorf("rules are not valid JSON: %s", err)
+	}
+
+	version := d.Get("version").(int)
+	if version == 0 {
+		latest, err := client.GetLatestVersion(ctx, papi.GetLatestVersionRequest{
+			PropertyID: propertyID,
+			ContractID: contractID,
+			GroupID:    groupID,
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		version = latest.Version.PropertyVersion
+		contractID = latest.ContractID
+		groupID = latest.GroupID
+	}
+
+	// the dry run only converts the rules from the format in Content-Type to the one in Accept, nothing is saved
+	log.Debugf("Converting rules of %s version %d from %s to %s", propertyID, version, sourceFormat, targetFormat)
+	convertCtx := session.ContextWithOptions(ctx, session.WithContextHeaders(http.Header{
+		"Content-Type": []string{fmt.Sprintf("application/vnd.akamai.papirules.%s+json", sourceFormat)},
+		"Accept":       []string{fmt.Sprintf("application/vnd.akamai.papirules.%s+json", targetFormat)},
+	}))
+	converted, err := client.UpdateRuleTree(convertCtx, papi.UpdateRulesRequest{
+		PropertyID:      propertyID,
+		PropertyVersion: version,
+		ContractID:      contractID,
+		GroupID:         groupID,
+		DryRun:          true,
+		ValidateRules:   true,
+		Rules:           rules,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	upgraded, err := json.MarshalIndent(papi.RulesUpdate{Comments: rules.Comments, Rules: converted.Rules}, "", "  ")
+	if err != nil {
+		return diag.Errorf("received rules that could not be rendered to JSON: %s", err)
+	}
+
+	changes := diffRuleTrees(rules.Rules.Name, &rules.Rules, &converted.Rules)
+	changesAttr := make([]interface{}, 0, len(changes))
+	for _, c := range changes {
+		changesAttr = append(changesAttr, map[string]interface{}{
+			"rule_path": c.rulePath,
+			"type":      c.kind,
+			"name":      c.name,
+			"change":    c.change,
+			"options":   c.options,
+		})
+	}
+
+	attrs := map[string]interface{}{
+		"contract_id":    contractID,
+		"group_id":       groupID,
+		"version":        version,
+		"upgraded_rules": string(upgraded),
+		"changes":        changesAttr,
+	}
+	for key, val := range attrs {
+		if err := d.Set(key, val); err != nil {
+			return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+		}
+	}
+	d.SetId(fmt.Sprintf("%s:%d:%s:%s", propertyID, version, sourceFormat, targetFormat))
+
+	var diags diag.Diagnostics
+	for _, ruleErr := range converted.Errors {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("rules converted to %s are not valid: %s", targetFormat, ruleErr.Title),
+			Detail:   ruleErr.Detail,
+		})
+	}
+	return diags
+}
+
+// diffRuleTrees lists the rules, behaviors and criteria which differ between two rule trees
+// Rules, behaviors and criteria are matched by name, so reordering them is not reported
+func diffRuleTrees(path string, old, new *papi.Rules) []ruleChange {
+	changes := diffRuleBehaviors(path, "behavior", old.Behaviors, new.Behaviors)
+	changes = append(changes, diffRuleBehaviors(path, "criterion", old.Criteria, new.Criteria)...)
+
+	oldChildren, oldKeys := indexRulesByName(old.Children)
+	newChildren, newKeys := indexRulesByName(new.Children)
+	for _, key := range mergeKeys(oldKeys, newKeys) {
+		oldChild, inOld := oldChildren[key]
+		newChild, inNew := newChildren[key]
+		switch {
+		case !inNew:
+			changes = append(changes, ruleChange{rulePath: path, kind: "rule", name: oldChild.Name, change: RuleChangeRemoved})
+		case !inOld:
+			changes = append(changes, ruleChange{rulePath: path, kind: "rule", name: newChild.Name, change: RuleChangeAdded})
+		default:
+			changes = append(changes, diffRuleTrees(path+"/"+oldChild.Name, oldChild, newChild)...)
+		}
+	}
+
+	return changes
+}
+
+func diffRuleBehaviors(path, kind string, old, new []papi.RuleBehavior) []ruleChange {
+	var changes []ruleChange
+	oldBehaviors, oldKeys := indexBehaviorsByName(old)
+	newBehaviors, newKeys := indexBehaviorsByName(new)
+	for _, key := range mergeKeys(oldKeys, newKeys) {
+		oldBehavior, inOld := oldBehaviors[key]
+		newBehavior, inNew := newBehaviors[key]
+		switch {
+		case !inNew:
+			changes = append(changes, ruleChange{rulePath: path, kind: kind, name: oldBehavior.Name, change: RuleChangeRemoved})
+		case !inOld:
+			changes = append(changes, ruleChange{rulePath: path, kind: kind, name: newBehavior.Name, change: RuleChangeAdded})
+		default:
+			if options := diffRuleOptions(oldBehavior.Options, newBehavior.Options); len(options) > 0 {
+				changes = append(changes, ruleChange{rulePath: path, kind: kind, name: oldBehavior.Name, change: RuleChangeModified, options: options})
+			}
+		}
+	}
+
+	return changes
+}
+
+// diffRuleOptions returns the sorted names of options which were added, removed or changed
+func diffRuleOptions(old, new papi.RuleOptionsMap) []string {
+	var options []string
+	for name, oldValue := range old {
+		if newValue, ok := new[name]; !ok || !reflect.DeepEqual(oldValue, newValue) {
+			options = append(options, name)
+		}
+	}
+	for name := range new {
+		if _, ok := old[name]; !ok {
+			options = append(options, name)
+		}
+	}
+	sort.Strings(options)
+	return options
+}
+
+// indexRulesByName keys rules by their name, repeated names are suffixed with their occurrence number
+func indexRulesByName(rules []papi.Rules) (map[string]*papi.Rules, []string) {
+	index := make(map[string]*papi.Rules, len(rules))
+	keys := make([]string, 0, len(rules))
+	counts := make(map[string]int)
+	for i := range rules {
+		key := occurrenceKey(rules[i].Name, counts)
+		index[key] = &rules[i]
+		keys = append(keys, key)
+	}
+	return index, keys
+}
+
+// indexBehaviorsByName keys behaviors or criteria by their name, repeated names are suffixed with their occurrence number
+func indexBehaviorsByName(behaviors []papi.RuleBehavior) (map[string]papi.RuleBehavior, []string) {
+	index := make(map[string]papi.RuleBehavior, len(behaviors))
+	keys := make([]string, 0, len(behaviors))
+	counts := make(map[string]int)
+	for _, b := range behaviors {
+		key := occurrenceKey(b.Name, counts)
+		index[key] = b
+		keys = append(keys, key)
+	}
+	return index, keys
+}
+
+func occurrenceKey(name string, counts map[string]int) string {
+	counts[name]++
+	if counts[name] == 1 {
+		return name
+	}
+	return fmt.Sprintf("%s#%d", name, counts[name])
+}
+
+// mergeKeys returns the old keys in their order, followed by the keys which only exist in the new ones
+func mergeKeys(old, new []string) []string {
+	seen := make(map[string]struct{}, len(old))
+	keys := append([]string{}, old...)
+	for _, key := range old {
+		seen[key] = struct{}{}
+	}
+	for _, key := range new {
+		if _, ok := seen[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
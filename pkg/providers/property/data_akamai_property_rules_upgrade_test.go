@@ -0,0 +1,184 @@
+package property
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDataPropertyRulesUpgrade(t *testing.T) {
+	sourceRules := papi.RulesUpdate{Rules: papi.Rules{
+		Name: "default",
+		Behaviors: []papi.RuleBehavior{
+			{Name: "origin", Options: papi.RuleOptionsMap{"hostname": "origin.example.com", "httpPort": float64(80)}},
+			{Name: "caching", Options: papi.RuleOptionsMap{"behavior": "MAX_AGE", "ttl": "1d"}},
+		},
+		Children: []papi.Rules{{
+			Name: "Static",
+			Criteria: []papi.RuleBehavior{
+				{Name: "fileExtension", Options: papi.RuleOptionsMap{"values": []interface{}{"css", "js"}}},
+			},
+		}},
+	}}
+	convertedRules := &papi.UpdateRulesResponse{
+		Rules: papi.Rules{
+			Name: "default",
+			Behaviors: []papi.RuleBehavior{
+				{Name: "origin", Options: papi.RuleOptionsMap{"hostname": "origin.example.com", "httpPort": float64(80), "ipv6": false}},
+				{Name: "caching", Options: papi.RuleOptionsMap{"behavior": "MAX_AGE", "ttl": "1d"}},
+				{Name: "http2", Options: papi.RuleOptionsMap{}},
+			},
+			Children: []papi.Rules{{Name: "Static"}},
+		},
+		Errors: []papi.RuleError{{
+			Type:   "https://problems.example.net/papi/v0/validation/attribute_required",
+			Title:  "Missing required attribute",
+			Detail: "The `http2` behavior requires an `enabled` option",
+		}},
+	}
+
+	tests := map[string]struct {
+		init       func(*mockpapi)
+		configPath string
+		checks     resource.TestCheckFunc
+		withError  *regexp.Regexp
+	}{
+		"upgrade rules using the latest version": {
+			init: func(m *mockpapi) {
+				m.On("GetLatestVersion", mock.Anything, papi.GetLatestVersionRequest{PropertyID: "prp_1"}).
+					Return(&papi.GetPropertyVersionsResponse{
+						ContractID: "ctr_1",
+						GroupID:    "grp_2",
+						Version:    papi.PropertyVersionGetItem{PropertyVersion: 5},
+					}, nil)
+				m.On("UpdateRuleTree", mock.Anything, papi.UpdateRulesRequest{
+					PropertyID:      "prp_1",
+					PropertyVersion: 5,
+					ContractID:      "ctr_1",
+					GroupID:         "grp_2",
+					DryRun:          true,
+					ValidateRules:   true,
+					Rules:           sourceRules,
+				}).Return(convertedRules, nil)
+			},
+			configPath: "testdata/TestDataPropertyRulesUpgrade/upgrade.tf",
+			checks: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.test", "id", "prp_1:5:v2020-03-04:v2021-01-21"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.test", "version", "5"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.test", "contract_id", "ctr_1"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.test", "group_id", "grp_2"),
+				resource.TestCheckResourceAttrSet("data.akamai_property_rules_upgrade.test", "upgraded_rules"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.test", "changes.#", "3"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.test", "changes.0.rule_path", "default"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.test", "changes.0.type", "behavior"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.test", "changes.0.name", "origin"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.test", "changes.0.change", RuleChangeModified),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.test", "changes.0.options.#", "1"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.test", "changes.0.options.0", "ipv6"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.test", "changes.1.name", "http2"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.test", "changes.1.change", RuleChangeAdded),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.test", "changes.2.rule_path", "default/Static"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.test", "changes.2.type", "criterion"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.test", "changes.2.name", "fileExtension"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.test", "changes.2.change", RuleChangeRemoved),
+			),
+		},
+		"upgrade rules using an explicit version": {
+			init: func(m *mockpapi) {
+				m.On("UpdateRuleTree", mock.Anything, papi.UpdateRulesRequest{
+					PropertyID:      "prp_1",
+					PropertyVersion: 3,
+					ContractID:      "ctr_1",
+					GroupID:         "grp_2",
+					DryRun:          true,
+					ValidateRules:   true,
+					Rules:           sourceRules,
+				}).Return(&papi.UpdateRulesResponse{Rules: sourceRules.Rules}, nil)
+			},
+			configPath: "testdata/TestDataPropertyRulesUpgrade/explicit_version.tf",
+			checks: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.test", "id", "prp_1:3:v2020-03-04:v2021-01-21"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.test", "contract_id", "ctr_1"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.test", "changes.#", "0"),
+			),
+		},
+		"conversion fails": {
+			init: func(m *mockpapi) {
+				m.On("GetLatestVersion", mock.Anything, papi.GetLatestVersionRequest{PropertyID: "prp_1"}).
+					Return(&papi.GetPropertyVersionsResponse{
+						ContractID: "ctr_1",
+						GroupID:    "grp_2",
+						Version:    papi.PropertyVersionGetItem{PropertyVersion: 5},
+					}, nil)
+				m.On("UpdateRuleTree", mock.Anything, mock.Anything).Return(nil, &papi.Error{
+					StatusCode: 400,
+					Title:      "Unsupported rule format",
+				})
+			},
+			configPath: "testdata/TestDataPropertyRulesUpgrade/upgrade.tf",
+			withError:  regexp.MustCompile("Unsupported rule format"),
+		},
+		"invalid rule format": {
+			configPath: "testdata/TestDataPropertyRulesUpgrade/invalid_rule_format.tf",
+			withError:  regexp.MustCompile(`"target_rule_format" must be of the form vYYYY-MM-DD`),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &mockpapi{}
+			if test.init != nil {
+				test.init(client)
+			}
+			useClient(client, func() {
+				resource.UnitTest(t, resource.TestCase{
+					Providers: testAccProviders,
+					Steps: []resource.TestStep{{
+						Config:      loadFixtureString(test.configPath),
+						Check:       test.checks,
+						ExpectError: test.withError,
+					}},
+				})
+			})
+			client.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDiffRuleTrees(t *testing.T) {
+	old := papi.Rules{
+		Name: "default",
+		Behaviors: []papi.RuleBehavior{
+			{Name: "cpCode", Options: papi.RuleOptionsMap{"value": map[string]interface{}{"id": float64(1)}}},
+			{Name: "modifyOutgoingResponseHeader", Options: papi.RuleOptionsMap{"action": "ADD"}},
+			{Name: "modifyOutgoingResponseHeader", Options: papi.RuleOptionsMap{"action": "DELETE"}},
+		},
+		Children: []papi.Rules{
+			{Name: "Performance"},
+			{Name: "Legacy"},
+		},
+	}
+	new := papi.Rules{
+		Name: "default",
+		Behaviors: []papi.RuleBehavior{
+			{Name: "modifyOutgoingResponseHeader", Options: papi.RuleOptionsMap{"action": "ADD"}},
+			{Name: "cpCode", Options: papi.RuleOptionsMap{"value": map[string]interface{}{"id": float64(1)}}},
+			{Name: "modifyOutgoingResponseHeader", Options: papi.RuleOptionsMap{"action": "MODIFY", "standard": "OTHER"}},
+		},
+		Children: []papi.Rules{
+			{Name: "Performance", Behaviors: []papi.RuleBehavior{{Name: "http2"}}},
+			{Name: "Offload"},
+		},
+	}
+
+	assert.Equal(t, []ruleChange{
+		{rulePath: "default", kind: "behavior", name: "modifyOutgoingResponseHeader", change: RuleChangeModified, options: []string{"action", "standard"}},
+		{rulePath: "default/Performance", kind: "behavior", name: "http2", change: RuleChangeAdded},
+		{rulePath: "default", kind: "rule", name: "Legacy", change: RuleChangeRemoved},
+		{rulePath: "default", kind: "rule", name: "Offload", change: RuleChangeAdded},
+	}, diffRuleTrees("default", &old, &new))
+}
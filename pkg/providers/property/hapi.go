@@ -0,0 +1,287 @@
+package property
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/hapi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// HAPI contains the Edge Hostnames API operations used to read, update and delete edge hostnames, PAPI can only
+	// create and list them
+	// See: https://developer.akamai.com/api/core_features/edge_hostnames/v1.html
+	HAPI interface {
+		hapi.HAPI
+
+		// ListEdgeHostnames lists the edge hostnames of the account, optionally filtered by DNS zone and record name
+		//
+		// See: https://developer.akamai.com/api/core_features/edge_hostnames/v1.html#getedgehostnames
+		ListEdgeHostnames(context.Context, ListEdgeHostnamesRequest) (*ListEdgeHostnamesResponse, error)
+
+		// GetEdgeHostname fetches an edge hostname by its DNS zone and record name
+		//
+		// See: https://developer.akamai.com/api/core_features/edge_hostnames/v1.html#getedgehostnamebyname
+		GetEdgeHostname(context.Context, GetEdgeHostnameRequest) (*EdgeHostnameDetail, error)
+
+		// UpdateEdgeHostname applies JSON patch operations to an edge hostname
+		//
+		// See: https://developer.akamai.com/api/core_features/edge_hostnames/v1.html#patchedgehostnamebyname
+		UpdateEdgeHostname(context.Context, UpdateEdgeHostnameRequest) (*EdgeHostnameChange, error)
+
+		// GetChangeRequest fetches the status of a change request
+		//
+		// See: https://developer.akamai.com/api/core_features/edge_hostnames/v1.html#getchangerequest
+		GetChangeRequest(context.Context, GetChangeRequestRequest) (*EdgeHostnameChange, error)
+	}
+
+	hapiClient struct {
+		hapi.HAPI
+		session.Session
+	}
+
+	// ListEdgeHostnamesRequest contains the optional filters of the edge hostnames list
+	ListEdgeHostnamesRequest struct {
+		DNSZone             string
+		RecordNameSubstring string
+	}
+
+	// ListEdgeHostnamesResponse contains the edge hostnames of the account
+	ListEdgeHostnamesResponse struct {
+		EdgeHostnames []EdgeHostnameDetail `json:"edgeHostnames"`
+	}
+
+	// GetEdgeHostnameRequest contains the DNS zone and record name of an edge hostname
+	GetEdgeHostnameRequest struct {
+		DNSZone    string
+		RecordName string
+	}
+
+	// UpdateEdgeHostnameRequest contains the JSON patch operations to apply to an edge hostname
+	UpdateEdgeHostnameRequest struct {
+		DNSZone           string
+		RecordName        string
+		StatusUpdateEmail []string
+		Comments          string
+		Body              []EdgeHostnamePatch
+	}
+
+	// EdgeHostnamePatch is a single JSON patch operation on an edge hostname
+	EdgeHostnamePatch struct {
+		Op    string      `json:"op"`
+		Path  string      `json:"path"`
+		Value interface{} `json:"value"`
+	}
+
+	// GetChangeRequestRequest contains the ID of a change request to fetch
+	GetChangeRequestRequest struct {
+		ChangeID int
+	}
+
+	// EdgeHostnameDetail is an edge hostname as represented by the Edge Hostnames API
+	EdgeHostnameDetail struct {
+		EdgeHostnameID    int    `json:"edgeHostnameId"`
+		RecordName        string `json:"recordName"`
+		DNSZone           string `json:"dnsZone"`
+		SecurityType      string `json:"securityType"`
+		UseDefaultTTL     bool   `json:"useDefaultTtl"`
+		UseDefaultMap     bool   `json:"useDefaultMap"`
+		TTL               int    `json:"ttl"`
+		Map               string `json:"map,omitempty"`
+		SlotNumber        int    `json:"slotNumber,omitempty"`
+		IPVersionBehavior string `json:"ipVersionBehavior"`
+		ProductID         string `json:"productId,omitempty"`
+		Comments          string `json:"comments,omitempty"`
+	}
+
+	// EdgeHostnameChange is a change request returned by the Edge Hostnames API when an edge hostname is modified
+	EdgeHostnameChange struct {
+		Action        string `json:"action"`
+		ChangeID      int    `json:"changeId"`
+		Comments      string `json:"comments,omitempty"`
+		Status        string `json:"status"`
+		StatusMessage string `json:"statusMessage,omitempty"`
+		SubmitDate    string `json:"submitDate,omitempty"`
+		Submitter     string `json:"submitter,omitempty"`
+	}
+)
+
+const (
+	// EdgeHostnameChangeStatusPending indicates a change request is still being processed
+	EdgeHostnameChangeStatusPending = "PENDING"
+	// EdgeHostnameChangeStatusSucceeded indicates a change request has been applied
+	EdgeHostnameChangeStatusSucceeded = "SUCCEEDED"
+	// EdgeHostnameChangeStatusFailed indicates a change request has failed
+	EdgeHostnameChangeStatusFailed = "FAILED"
+	// EdgeHostnameChangeStatusRejected indicates a change request has been rejected before being applied
+	EdgeHostnameChangeStatusRejected = "REJECTED"
+	// EdgeHostnameChangeStatusCancelled indicates a change request has been cancelled before being applied
+	EdgeHostnameChangeStatusCancelled = "CANCELLED"
+)
+
+var (
+	// ErrListHAPIEdgeHostnames represents error when listing edge hostnames from the Edge Hostnames API fails
+	ErrListHAPIEdgeHostnames = errors.New("listing edge hostnames")
+	// ErrGetHAPIEdgeHostname represents error when fetching an edge hostname from the Edge Hostnames API fails
+	ErrGetHAPIEdgeHostname = errors.New("fetching edge hostname details")
+	// ErrUpdateHAPIEdgeHostname represents error when updating an edge hostname fails
+	ErrUpdateHAPIEdgeHostname = errors.New("updating edge hostname")
+	// ErrGetChangeRequest represents error when fetching an edge hostname change request fails
+	ErrGetChangeRequest = errors.New("fetching edge hostname change request")
+)
+
+// NewHAPIClient returns a HAPI client using the given session
+func NewHAPIClient(sess session.Session) HAPI {
+	return &hapiClient{HAPI: hapi.Client(sess), Session: sess}
+}
+
+// Validate validates GetEdgeHostnameRequest
+func (r GetEdgeHostnameRequest) Validate() error {
+	return validation.Errors{
+		"DNSZone":    validation.Validate(r.DNSZone, validation.Required),
+		"RecordName": validation.Validate(r.RecordName, validation.Required),
+	}.Filter()
+}
+
+// Validate validates UpdateEdgeHostnameRequest
+func (r UpdateEdgeHostnameRequest) Validate() error {
+	return validation.Errors{
+		"DNSZone":    validation.Validate(r.DNSZone, validation.Required),
+		"RecordName": validation.Validate(r.RecordName, validation.Required),
+		"Body":       validation.Validate(r.Body, validation.Required),
+	}.Filter()
+}
+
+// Validate validates GetChangeRequestRequest
+func (r GetChangeRequestRequest) Validate() error {
+	return validation.Errors{
+		"ChangeID": validation.Validate(r.ChangeID, validation.Required),
+	}.Filter()
+}
+
+func (c *hapiClient) ListEdgeHostnames(ctx context.Context, params ListEdgeHostnamesRequest) (*ListEdgeHostnamesResponse, error) {
+	logger := c.Log(ctx)
+	logger.Debug("ListEdgeHostnames")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/hapi/v1/edge-hostnames", nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrListHAPIEdgeHostnames, err)
+	}
+
+	q := req.URL.Query()
+	if params.DNSZone != "" {
+		q.Add("dnsZone", params.DNSZone)
+	}
+	if params.RecordNameSubstring != "" {
+		q.Add("recordNameSubstring", params.RecordNameSubstring)
+	}
+	req.URL.RawQuery = q.Encode()
+
+	var rval ListEdgeHostnamesResponse
+	resp, err := c.Exec(req, &rval)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrListHAPIEdgeHostnames, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrListHAPIEdgeHostnames, parseAPIError(c.Session, resp))
+	}
+
+	return &rval, nil
+}
+
+func (c *hapiClient) GetEdgeHostname(ctx context.Context, params GetEdgeHostnameRequest) (*EdgeHostnameDetail, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetHAPIEdgeHostname, hapi.ErrStructValidation, err)
+	}
+
+	logger := c.Log(ctx)
+	logger.Debug("GetEdgeHostname")
+
+	uri := fmt.Sprintf("/hapi/v1/dns-zones/%s/edge-hostnames/%s", params.DNSZone, params.RecordName)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetHAPIEdgeHostname, err)
+	}
+
+	var rval EdgeHostnameDetail
+	resp, err := c.Exec(req, &rval)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetHAPIEdgeHostname, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetHAPIEdgeHostname, parseAPIError(c.Session, resp))
+	}
+
+	return &rval, nil
+}
+
+func (c *hapiClient) UpdateEdgeHostname(ctx context.Context, params UpdateEdgeHostnameRequest) (*EdgeHostnameChange, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrUpdateHAPIEdgeHostname, hapi.ErrStructValidation, err)
+	}
+
+	logger := c.Log(ctx)
+	logger.Debug("UpdateEdgeHostname")
+
+	uri := fmt.Sprintf("/hapi/v1/dns-zones/%s/edge-hostnames/%s", params.DNSZone, params.RecordName)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrUpdateHAPIEdgeHostname, err)
+	}
+	req.Header.Set("Content-Type", "application/json-patch+json")
+
+	q := req.URL.Query()
+	if len(params.StatusUpdateEmail) > 0 {
+		q.Add("statusUpdateEmail", strings.Join(params.StatusUpdateEmail, ","))
+	}
+	if params.Comments != "" {
+		q.Add("comments", params.Comments)
+	}
+	req.URL.RawQuery = q.Encode()
+
+	var rval EdgeHostnameChange
+	resp, err := c.Exec(req, &rval, params.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrUpdateHAPIEdgeHostname, err)
+	}
+
+	if resp.StatusCode != http.StatusAccepted {
+		return nil, fmt.Errorf("%s: %w", ErrUpdateHAPIEdgeHostname, parseAPIError(c.Session, resp))
+	}
+
+	return &rval, nil
+}
+
+func (c *hapiClient) GetChangeRequest(ctx context.Context, params GetChangeRequestRequest) (*EdgeHostnameChange, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetChangeRequest, hapi.ErrStructValidation, err)
+	}
+
+	logger := c.Log(ctx)
+	logger.Debug("GetChangeRequest")
+
+	uri := fmt.Sprintf("/hapi/v1/change-requests/%d", params.ChangeID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetChangeRequest, err)
+	}
+
+	var rval EdgeHostnameChange
+	resp, err := c.Exec(req, &rval)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetChangeRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetChangeRequest, parseAPIError(c.Session, resp))
+	}
+
+	return &rval, nil
+}
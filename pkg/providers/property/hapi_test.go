@@ -0,0 +1,225 @@
+package property
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/hapi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockhapi struct {
+	mock.Mock
+}
+
+func (h *mockhapi) DeleteEdgeHostname(ctx context.Context, r hapi.DeleteEdgeHostnameRequest) (*hapi.DeleteEdgeHostnameResponse, error) {
+	args := h.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*hapi.DeleteEdgeHostnameResponse), args.Error(1)
+}
+
+func (h *mockhapi) ListEdgeHostnames(ctx context.Context, r ListEdgeHostnamesRequest) (*ListEdgeHostnamesResponse, error) {
+	args := h.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*ListEdgeHostnamesResponse), args.Error(1)
+}
+
+func (h *mockhapi) GetEdgeHostname(ctx context.Context, r GetEdgeHostnameRequest) (*EdgeHostnameDetail, error) {
+	args := h.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*EdgeHostnameDetail), args.Error(1)
+}
+
+func (h *mockhapi) UpdateEdgeHostname(ctx context.Context, r UpdateEdgeHostnameRequest) (*EdgeHostnameChange, error) {
+	args := h.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*EdgeHostnameChange), args.Error(1)
+}
+
+func (h *mockhapi) GetChangeRequest(ctx context.Context, r GetChangeRequestRequest) (*EdgeHostnameChange, error) {
+	args := h.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*EdgeHostnameChange), args.Error(1)
+}
+
+func TestListHAPIEdgeHostnames(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/hapi/v1/edge-hostnames?dnsZone=edgekey.net", r.URL.String())
+		assert.Equal(t, http.MethodGet, r.Method)
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"edgeHostnames": [
+			{"edgeHostnameId": 1, "recordName": "www", "dnsZone": "edgekey.net", "ttl": 300, "slotNumber": 12, "ipVersionBehavior": "IPV4"}
+		]}`))
+		assert.NoError(t, err)
+	}))
+	defer mockServer.Close()
+	client := NewHAPIClient(mockAPISession(t, mockServer))
+
+	result, err := client.ListEdgeHostnames(context.Background(), ListEdgeHostnamesRequest{DNSZone: "edgekey.net"})
+	require.NoError(t, err)
+	assert.Equal(t, &ListEdgeHostnamesResponse{EdgeHostnames: []EdgeHostnameDetail{{
+		EdgeHostnameID:    1,
+		RecordName:        "www",
+		DNSZone:           "edgekey.net",
+		TTL:               300,
+		SlotNumber:        12,
+		IPVersionBehavior: "IPV4",
+	}}}, result)
+}
+
+func TestGetHAPIEdgeHostname(t *testing.T) {
+	tests := map[string]struct {
+		request          GetEdgeHostnameRequest
+		responseStatus   int
+		responseBody     string
+		expectedResponse *EdgeHostnameDetail
+		withError        func(*testing.T, error)
+	}{
+		"200 OK": {
+			request:        GetEdgeHostnameRequest{DNSZone: "edgesuite.net", RecordName: "test"},
+			responseStatus: http.StatusOK,
+			responseBody: `{
+				"edgeHostnameId": 123,
+				"recordName": "test",
+				"dnsZone": "edgesuite.net",
+				"securityType": "STANDARD-TLS",
+				"useDefaultTtl": false,
+				"useDefaultMap": true,
+				"ttl": 300,
+				"map": "a1.w10.akamai.net",
+				"ipVersionBehavior": "IPV6_IPV4_DUALSTACK",
+				"productId": "DSA"
+			}`,
+			expectedResponse: &EdgeHostnameDetail{
+				EdgeHostnameID:    123,
+				RecordName:        "test",
+				DNSZone:           "edgesuite.net",
+				SecurityType:      "STANDARD-TLS",
+				UseDefaultMap:     true,
+				TTL:               300,
+				Map:               "a1.w10.akamai.net",
+				IPVersionBehavior: "IPV6_IPV4_DUALSTACK",
+				ProductID:         "DSA",
+			},
+		},
+		"404 not found": {
+			request:        GetEdgeHostnameRequest{DNSZone: "edgesuite.net", RecordName: "test"},
+			responseStatus: http.StatusNotFound,
+			responseBody:   `{"type": "not_found", "title": "Not Found", "status": 404}`,
+			withError: func(t *testing.T, err error) {
+				want := &papi.Error{Type: "not_found", Title: "Not Found", StatusCode: http.StatusNotFound}
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+			},
+		},
+		"validation error": {
+			request: GetEdgeHostnameRequest{DNSZone: "edgesuite.net"},
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, hapi.ErrStructValidation), "want: %s; got: %s", hapi.ErrStructValidation, err)
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/hapi/v1/dns-zones/edgesuite.net/edge-hostnames/test", r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := NewHAPIClient(mockAPISession(t, mockServer))
+			result, err := client.GetEdgeHostname(context.Background(), test.request)
+			if test.withError != nil {
+				test.withError(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestUpdateHAPIEdgeHostname(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/hapi/v1/dns-zones/edgekey.net/edge-hostnames/test?comments=update&statusUpdateEmail=a%40example.com%2Cb%40example.com", r.URL.String())
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "application/json-patch+json", r.Header.Get("Content-Type"))
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `[
+			{"op": "replace", "path": "/ttl", "value": 600},
+			{"op": "replace", "path": "/ipVersionBehavior", "value": "IPV4"}
+		]`, string(body))
+		w.WriteHeader(http.StatusAccepted)
+		_, err = w.Write([]byte(`{"action": "EDIT", "changeId": 66, "status": "PENDING"}`))
+		assert.NoError(t, err)
+	}))
+	defer mockServer.Close()
+	client := NewHAPIClient(mockAPISession(t, mockServer))
+
+	result, err := client.UpdateEdgeHostname(context.Background(), UpdateEdgeHostnameRequest{
+		DNSZone:           "edgekey.net",
+		RecordName:        "test",
+		StatusUpdateEmail: []string{"a@example.com", "b@example.com"},
+		Comments:          "update",
+		Body: []EdgeHostnamePatch{
+			{Op: "replace", Path: "/ttl", Value: 600},
+			{Op: "replace", Path: "/ipVersionBehavior", Value: "IPV4"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &EdgeHostnameChange{Action: "EDIT", ChangeID: 66, Status: EdgeHostnameChangeStatusPending}, result)
+
+	_, err = client.UpdateEdgeHostname(context.Background(), UpdateEdgeHostnameRequest{DNSZone: "edgekey.net", RecordName: "test"})
+	assert.True(t, errors.Is(err, hapi.ErrStructValidation))
+}
+
+func TestGetChangeRequest(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/hapi/v1/change-requests/66", r.URL.String())
+		assert.Equal(t, http.MethodGet, r.Method)
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"action": "DELETE", "changeId": 66, "status": "FAILED", "statusMessage": "hostname in use"}`))
+		assert.NoError(t, err)
+	}))
+	defer mockServer.Close()
+	client := NewHAPIClient(mockAPISession(t, mockServer))
+
+	result, err := client.GetChangeRequest(context.Background(), GetChangeRequestRequest{ChangeID: 66})
+	require.NoError(t, err)
+	assert.Equal(t, &EdgeHostnameChange{
+		Action:        "DELETE",
+		ChangeID:      66,
+		Status:        EdgeHostnameChangeStatusFailed,
+		StatusMessage: "hostname in use",
+	}, result)
+}
@@ -0,0 +1,431 @@
+package property
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrJSONPath is returned when a JSONPath expression cannot be parsed
+var ErrJSONPath = errors.New("invalid JSONPath expression")
+
+type (
+	// jsonPathSegment is a single step of a JSONPath expression selecting children of the current nodes
+	jsonPathSegment struct {
+		recursive bool
+		wildcard  bool
+		names     []string
+		indexes   []int
+		filter    [][]jsonPathComparison
+	}
+
+	// jsonPathComparison is a single comparison of a filter expression, filters are a disjunction of conjunctions
+	jsonPathComparison struct {
+		left  jsonPathOperand
+		op    string
+		right jsonPathOperand
+	}
+
+	// jsonPathOperand is either a path relative to the filtered node or a literal value
+	jsonPathOperand struct {
+		path    []jsonPathSegment
+		literal interface{}
+		isPath  bool
+	}
+
+	jsonPathParser struct {
+		expr string
+		pos  int
+	}
+)
+
+// parseJSONPath parses the subset of JSONPath used to query rule trees: $, .name, ['name'], [n], [*], ..name and
+// filters like [?(@.name == 'caching' && @.options.ttl != '0s')] comparing relative paths with literals
+func parseJSONPath(expr string) ([]jsonPathSegment, error) {
+	p := &jsonPathParser{expr: strings.TrimSpace(expr)}
+	if !p.consume("$") {
+		return nil, fmt.Errorf("%w: %q must start with $", ErrJSONPath, expr)
+	}
+	var segments []jsonPathSegment
+	for !p.done() {
+		segment, err := p.segment(true)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %s", ErrJSONPath, expr, err)
+		}
+		segments = append(segments, segment)
+	}
+	return segments, nil
+}
+
+// evalJSONPath returns the values selected by the path segments in document order
+func evalJSONPath(segments []jsonPathSegment, root interface{}) []interface{} {
+	nodes := []interface{}{root}
+	for _, segment := range segments {
+		var next []interface{}
+		for _, node := range nodes {
+			if !segment.recursive {
+				next = append(next, segment.selectChildren(node)...)
+				continue
+			}
+			for _, descendant := range jsonDescendants(node) {
+				next = append(next, segment.selectChildren(descendant)...)
+			}
+		}
+		nodes = next
+	}
+	return nodes
+}
+
+func (s jsonPathSegment) selectChildren(node interface{}) []interface{} {
+	var selected []interface{}
+	switch n := node.(type) {
+	case map[string]interface{}:
+		if len(s.names) > 0 {
+			for _, name := range s.names {
+				if value, ok := n[name]; ok {
+					selected = append(selected, value)
+				}
+			}
+			return selected
+		}
+		if s.wildcard || s.filter != nil {
+			for _, key := range sortedJSONKeys(n) {
+				if s.wildcard || s.matches(n[key]) {
+					selected = append(selected, n[key])
+				}
+			}
+		}
+	case []interface{}:
+		for _, i := range s.indexes {
+			if i < 0 {
+				i += len(n)
+			}
+			if i >= 0 && i < len(n) {
+				selected = append(selected, n[i])
+			}
+		}
+		if s.wildcard || s.filter != nil {
+			for _, item := range n {
+				if s.wildcard || s.matches(item) {
+					selected = append(selected, item)
+				}
+			}
+		}
+	}
+	return selected
+}
+
+func (s jsonPathSegment) matches(node interface{}) bool {
+	for _, conjunction := range s.filter {
+		matched := true
+		for _, comparison := range conjunction {
+			if !comparison.eval(node) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+func (c jsonPathComparison) eval(node interface{}) bool {
+	left, leftOK := c.left.value(node)
+	if c.op == "" {
+		return leftOK
+	}
+	right, rightOK := c.right.value(node)
+	if !leftOK || !rightOK {
+		return false
+	}
+
+	switch c.op {
+	case "==":
+		return reflect.DeepEqual(left, right)
+	case "!=":
+		return !reflect.DeepEqual(left, right)
+	}
+	if l, ok := left.(float64); ok {
+		if r, ok := right.(float64); ok {
+			return compareOrdered(c.op, l < r, l == r)
+		}
+	}
+	if l, ok := left.(string); ok {
+		if r, ok := right.(string); ok {
+			return compareOrdered(c.op, l < r, l == r)
+		}
+	}
+	return false
+}
+
+func compareOrdered(op string, less, equal bool) bool {
+	switch op {
+	case "<":
+		return less
+	case "<=":
+		return less || equal
+	case ">":
+		return !less && !equal
+	case ">=":
+		return !less
+	}
+	return false
+}
+
+func (o jsonPathOperand) value(node interface{}) (interface{}, bool) {
+	if !o.isPath {
+		return o.literal, true
+	}
+	values := evalJSONPath(o.path, node)
+	if len(values) == 0 {
+		return nil, false
+	}
+	return values[0], true
+}
+
+// jsonDescendants returns the node and all nested values in document order
+func jsonDescendants(node interface{}) []interface{} {
+	descendants := []interface{}{node}
+	switch n := node.(type) {
+	case map[string]interface{}:
+		for _, key := range sortedJSONKeys(n) {
+			descendants = append(descendants, jsonDescendants(n[key])...)
+		}
+	case []interface{}:
+		for _, item := range n {
+			descendants = append(descendants, jsonDescendants(item)...)
+		}
+	}
+	return descendants
+}
+
+func sortedJSONKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (p *jsonPathParser) segment(allowRecursive bool) (jsonPathSegment, error) {
+	switch {
+	case allowRecursive && p.consume(".."):
+		var segment jsonPathSegment
+		var err error
+		if p.peek("[") {
+			segment, err = p.bracket()
+		} else {
+			segment, err = p.dotName()
+		}
+		segment.recursive = true
+		return segment, err
+	case p.consume("."):
+		return p.dotName()
+	case p.peek("["):
+		return p.bracket()
+	}
+	return jsonPathSegment{}, fmt.Errorf("unexpected %q at position %d", p.expr[p.pos], p.pos)
+}
+
+func (p *jsonPathParser) dotName() (jsonPathSegment, error) {
+	if p.consume("*") {
+		return jsonPathSegment{wildcard: true}, nil
+	}
+	start := p.pos
+	for !p.done() && !strings.ContainsRune(".[ =!<>&|)", rune(p.expr[p.pos])) {
+		p.pos++
+	}
+	if start == p.pos {
+		return jsonPathSegment{}, fmt.Errorf("missing name at position %d", start)
+	}
+	return jsonPathSegment{names: []string{p.expr[start:p.pos]}}, nil
+}
+
+func (p *jsonPathParser) bracket() (jsonPathSegment, error) {
+	var segment jsonPathSegment
+	p.consume("[")
+	p.skipSpaces()
+	switch {
+	case p.consume("*"):
+		segment.wildcard = true
+	case p.consume("?"):
+		p.skipSpaces()
+		if !p.consume("(") {
+			return segment, fmt.Errorf("filter must be enclosed in ?() at position %d", p.pos)
+		}
+		filter, err := p.filter()
+		if err != nil {
+			return segment, err
+		}
+		p.skipSpaces()
+		if !p.consume(")") {
+			return segment, fmt.Errorf("missing ) at position %d", p.pos)
+		}
+		segment.filter = filter
+	default:
+		for {
+			p.skipSpaces()
+			if p.peek("'") || p.peek(`"`) {
+				name, err := p.quoted()
+				if err != nil {
+					return segment, err
+				}
+				segment.names = append(segment.names, name)
+			} else {
+				index, err := p.integer()
+				if err != nil {
+					return segment, err
+				}
+				segment.indexes = append(segment.indexes, index)
+			}
+			p.skipSpaces()
+			if !p.consume(",") {
+				break
+			}
+		}
+	}
+	p.skipSpaces()
+	if !p.consume("]") {
+		return segment, fmt.Errorf("missing ] at position %d", p.pos)
+	}
+	return segment, nil
+}
+
+func (p *jsonPathParser) filter() ([][]jsonPathComparison, error) {
+	var disjunction [][]jsonPathComparison
+	for {
+		var conjunction []jsonPathComparison
+		for {
+			comparison, err := p.comparison()
+			if err != nil {
+				return nil, err
+			}
+			conjunction = append(conjunction, comparison)
+			p.skipSpaces()
+			if !p.consume("&&") {
+				break
+			}
+		}
+		disjunction = append(disjunction, conjunction)
+		if !p.consume("||") {
+			return disjunction, nil
+		}
+	}
+}
+
+func (p *jsonPathParser) comparison() (jsonPathComparison, error) {
+	var comparison jsonPathComparison
+	var err error
+	if comparison.left, err = p.operand(); err != nil {
+		return comparison, err
+	}
+	p.skipSpaces()
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.consume(op) {
+			comparison.op = op
+			comparison.right, err = p.operand()
+			return comparison, err
+		}
+	}
+	if !comparison.left.isPath {
+		return comparison, fmt.Errorf("missing comparison operator at position %d", p.pos)
+	}
+	return comparison, nil
+}
+
+func (p *jsonPathParser) operand() (jsonPathOperand, error) {
+	p.skipSpaces()
+	switch {
+	case p.consume("@"):
+		operand := jsonPathOperand{isPath: true}
+		for p.peek(".") || p.peek("[") {
+			segment, err := p.segment(false)
+			if err != nil {
+				return operand, err
+			}
+			operand.path = append(operand.path, segment)
+		}
+		return operand, nil
+	case p.peek("'") || p.peek(`"`):
+		value, err := p.quoted()
+		return jsonPathOperand{literal: value}, err
+	case p.consume("true"):
+		return jsonPathOperand{literal: true}, nil
+	case p.consume("false"):
+		return jsonPathOperand{literal: false}, nil
+	case p.consume("null"):
+		return jsonPathOperand{literal: nil}, nil
+	}
+	start := p.pos
+	for !p.done() && strings.ContainsRune("+-.0123456789eE", rune(p.expr[p.pos])) {
+		p.pos++
+	}
+	number, err := strconv.ParseFloat(p.expr[start:p.pos], 64)
+	if err != nil {
+		return jsonPathOperand{}, fmt.Errorf("invalid value at position %d", start)
+	}
+	return jsonPathOperand{literal: number}, nil
+}
+
+func (p *jsonPathParser) quoted() (string, error) {
+	quote := p.expr[p.pos]
+	p.pos++
+	var value strings.Builder
+	for !p.done() {
+		c := p.expr[p.pos]
+		p.pos++
+		switch {
+		case c == '\\' && !p.done():
+			value.WriteByte(p.expr[p.pos])
+			p.pos++
+		case c == quote:
+			return value.String(), nil
+		default:
+			value.WriteByte(c)
+		}
+	}
+	return "", fmt.Errorf("unterminated string")
+}
+
+func (p *jsonPathParser) integer() (int, error) {
+	start := p.pos
+	if p.peek("-") {
+		p.pos++
+	}
+	for !p.done() && p.expr[p.pos] >= '0' && p.expr[p.pos] <= '9' {
+		p.pos++
+	}
+	index, err := strconv.Atoi(p.expr[start:p.pos])
+	if err != nil {
+		return 0, fmt.Errorf("invalid index at position %d", start)
+	}
+	return index, nil
+}
+
+func (p *jsonPathParser) consume(token string) bool {
+	if strings.HasPrefix(p.expr[p.pos:], token) {
+		p.pos += len(token)
+		return true
+	}
+	return false
+}
+
+func (p *jsonPathParser) peek(token string) bool {
+	return strings.HasPrefix(p.expr[p.pos:], token)
+}
+
+func (p *jsonPathParser) skipSpaces() {
+	for !p.done() && p.expr[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+func (p *jsonPathParser) done() bool {
+	return p.pos >= len(p.expr)
+}
@@ -0,0 +1,86 @@
+package property
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvalJSONPath(t *testing.T) {
+	var document interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"rules": {
+		"name": "default",
+		"behaviors": [
+			{"name": "origin", "options": {"hostname": "origin.example.com", "httpsPort": 443}},
+			{"name": "caching", "options": {"behavior": "MAX_AGE", "ttl": "1d"}}
+		],
+		"children": [
+			{"name": "Static", "behaviors": [{"name": "caching", "options": {"behavior": "NO_STORE"}}]},
+			{"name": "API", "criteria": [{"name": "path", "options": {"values": ["/api/*"]}}]}
+		]
+	}}`), &document))
+
+	tests := map[string]struct {
+		path     string
+		expected []interface{}
+	}{
+		"child names": {
+			path:     "$.rules.children[*].name",
+			expected: []interface{}{"Static", "API"},
+		},
+		"bracket names and negative index": {
+			path:     "$['rules']['children'][-1].name",
+			expected: []interface{}{"API"},
+		},
+		"index list": {
+			path:     "$.rules.behaviors[0,1].name",
+			expected: []interface{}{"origin", "caching"},
+		},
+		"recursive descent with filter": {
+			path:     "$..behaviors[?(@.name == 'caching')].options.behavior",
+			expected: []interface{}{"MAX_AGE", "NO_STORE"},
+		},
+		"numeric comparison": {
+			path:     `$.rules.behaviors[?(@.options.httpsPort >= 443)].name`,
+			expected: []interface{}{"origin"},
+		},
+		"conjunction and disjunction": {
+			path:     `$.rules.behaviors[?(@.name == "caching" && @.options.ttl == '0s' || @.options.hostname)].name`,
+			expected: []interface{}{"origin"},
+		},
+		"missing values never match": {
+			path:     "$.rules.children[?(@.criteria != null)].name",
+			expected: []interface{}{"API"},
+		},
+		"no match": {
+			path: "$.rules.variables[*]",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			segments, err := parseJSONPath(test.path)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, evalJSONPath(segments, document))
+		})
+	}
+}
+
+func TestParseJSONPathErrors(t *testing.T) {
+	for _, path := range []string{
+		"rules.name",
+		"$.rules[",
+		"$.rules[?(@.name ==)]",
+		"$.rules[?@.name]",
+		"$.rules['name",
+		"$.",
+	} {
+		t.Run(path, func(t *testing.T) {
+			_, err := parseJSONPath(path)
+			assert.True(t, errors.Is(err, ErrJSONPath), "want: %s; got: %s", ErrJSONPath, err)
+		})
+	}
+}
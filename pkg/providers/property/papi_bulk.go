@@ -0,0 +1,549 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// BulkPAPI contains the PAPI bulk operations which are not available in the edgegrid papi client
+	// See: https://developer.akamai.com/api/core_features/property_manager/v1.html#bulkgroup
+	BulkPAPI interface {
+		// CreateBulkSearch submits an asynchronous bulk search request over the rule trees of all accessible properties
+		//
+		// See: https://developer.akamai.com/api/core_features/property_manager/v1.html#postbulksearchrequest
+		CreateBulkSearch(context.Context, CreateBulkSearchRequest) (*CreateBulkSearchResponse, error)
+
+		// GetBulkSearch fetches the status and results of a bulk search request
+		//
+		// See: https://developer.akamai.com/api/core_features/property_manager/v1.html#getbulksearchrequest
+		GetBulkSearch(context.Context, GetBulkSearchRequest) (*BulkSearchResponse, error)
+
+		// CreateBulkVersions submits an asynchronous request creating new versions of many properties at once
+		//
+		// See: https://developer.akamai.com/api/core_features/property_manager/v1.html#postbulkversioning
+		CreateBulkVersions(context.Context, CreateBulkVersionsRequest) (*CreateBulkVersionsResponse, error)
+
+		// GetBulkVersions fetches the status and results of a bulk version creation request
+		//
+		// See: https://developer.akamai.com/api/core_features/property_manager/v1.html#getbulkversioning
+		GetBulkVersions(context.Context, GetBulkVersionsRequest) (*BulkVersionsResponse, error)
+
+		// CreateBulkPatch submits an asynchronous request applying JSON patch operations to the rule trees of many
+		// property versions at once
+		//
+		// See: https://developer.akamai.com/api/core_features/property_manager/v1.html#postbulkpatch
+		CreateBulkPatch(context.Context, CreateBulkPatchRequest) (*CreateBulkPatchResponse, error)
+
+		// GetBulkPatch fetches the status and results of a bulk patch request
+		//
+		// See: https://developer.akamai.com/api/core_features/property_manager/v1.html#getbulkpatch
+		GetBulkPatch(context.Context, GetBulkPatchRequest) (*BulkPatchResponse, error)
+	}
+
+	bulkPAPI struct {
+		session.Session
+	}
+
+	// BulkSearchQuery is a JSONPath query run against property rule trees
+	BulkSearchQuery struct {
+		Syntax               string   `json:"syntax"`
+		Match                string   `json:"match"`
+		BulkSearchQualifiers []string `json:"bulkSearchQualifiers,omitempty"`
+	}
+
+	// CreateBulkSearchRequest contains parameters of a bulk search request
+	CreateBulkSearchRequest struct {
+		ContractID      string
+		GroupID         string
+		BulkSearchQuery BulkSearchQuery
+	}
+
+	// CreateBulkSearchResponse contains the link to a submitted bulk search
+	CreateBulkSearchResponse struct {
+		BulkSearchLink string `json:"bulkSearchLink"`
+		BulkSearchID   int    `json:"-"`
+	}
+
+	// GetBulkSearchRequest contains the ID of a bulk search to fetch
+	GetBulkSearchRequest struct {
+		BulkSearchID int
+	}
+
+	// BulkSearchResponse contains the status and results of a bulk search
+	BulkSearchResponse struct {
+		BulkSearchID       int                `json:"bulkSearchId"`
+		SearchTargetStatus string             `json:"searchTargetStatus"`
+		SearchSubmitDate   string             `json:"searchSubmitDate"`
+		SearchUpdateDate   string             `json:"searchUpdateDate"`
+		BulkSearchQuery    BulkSearchQuery    `json:"bulkSearchQuery"`
+		Results            []BulkSearchResult `json:"results"`
+	}
+
+	// BulkSearchResult is a property version matching a bulk search
+	BulkSearchResult struct {
+		PropertyID       string   `json:"propertyId"`
+		PropertyName     string   `json:"propertyName"`
+		PropertyVersion  int      `json:"propertyVersion"`
+		PropertyType     string   `json:"propertyType"`
+		IsLatest         bool     `json:"isLatest"`
+		IsLocked         bool     `json:"isLocked"`
+		IsSecure         bool     `json:"isSecure"`
+		AccountID        string   `json:"accountId"`
+		LastModifiedTime string   `json:"lastModifiedTime"`
+		ProductionStatus string   `json:"productionStatus"`
+		StagingStatus    string   `json:"stagingStatus"`
+		MatchLocations   []string `json:"matchLocations"`
+	}
+
+	// BulkVersionCreation is a single property version to branch a new version from
+	BulkVersionCreation struct {
+		PropertyID            string `json:"propertyId"`
+		CreateFromVersion     int    `json:"createFromVersion"`
+		CreateFromVersionEtag string `json:"createFromVersionEtag,omitempty"`
+	}
+
+	// CreateBulkVersionsRequest contains the property versions to branch new versions from
+	CreateBulkVersionsRequest struct {
+		CreatePropertyVersions []BulkVersionCreation `json:"createPropertyVersions"`
+	}
+
+	// CreateBulkVersionsResponse contains the link to a submitted bulk version creation
+	CreateBulkVersionsResponse struct {
+		BulkCreateVersionLink string `json:"bulkCreateVersionLink"`
+		BulkCreateID          int    `json:"-"`
+	}
+
+	// GetBulkVersionsRequest contains the ID of a bulk version creation to fetch
+	GetBulkVersionsRequest struct {
+		BulkCreateID int
+	}
+
+	// BulkVersionsResponse contains the status and results of a bulk version creation
+	BulkVersionsResponse struct {
+		BulkCreateID             int                 `json:"bulkCreateId"`
+		BulkCreateVersionsStatus string              `json:"bulkCreateVersionsStatus"`
+		SubmitDate               string              `json:"submitDate"`
+		UpdateDate               string              `json:"updateDate"`
+		CreatePropertyVersions   []BulkVersionResult `json:"createPropertyVersions"`
+	}
+
+	// BulkVersionResult is the outcome of creating a single property version
+	BulkVersionResult struct {
+		PropertyID        string `json:"propertyId"`
+		PropertyName      string `json:"propertyName"`
+		CreateFromVersion int    `json:"createFromVersion"`
+		PropertyVersion   int    `json:"propertyVersion"`
+		Status            string `json:"status"`
+		FailureCause      string `json:"failureCause,omitempty"`
+	}
+
+	// BulkPatchOperation is a single JSON patch operation (RFC 6902) applied to a rule tree
+	BulkPatchOperation struct {
+		Op    string          `json:"op"`
+		Path  string          `json:"path"`
+		From  string          `json:"from,omitempty"`
+		Value json.RawMessage `json:"value,omitempty"`
+	}
+
+	// BulkPatch contains the JSON patch operations applied to a single property version
+	BulkPatch struct {
+		PropertyID      string               `json:"propertyId"`
+		PropertyVersion int                  `json:"propertyVersion"`
+		Etag            string               `json:"etag,omitempty"`
+		Patches         []BulkPatchOperation `json:"patches"`
+	}
+
+	// CreateBulkPatchRequest contains the property versions to patch
+	CreateBulkPatchRequest struct {
+		PatchPropertyVersions []BulkPatch `json:"patchPropertyVersions"`
+	}
+
+	// CreateBulkPatchResponse contains the link to a submitted bulk patch
+	CreateBulkPatchResponse struct {
+		BulkPatchLink string `json:"bulkPatchLink"`
+		BulkPatchID   int    `json:"-"`
+	}
+
+	// GetBulkPatchRequest contains the ID of a bulk patch to fetch
+	GetBulkPatchRequest struct {
+		BulkPatchID int
+	}
+
+	// BulkPatchResponse contains the status and results of a bulk patch
+	BulkPatchResponse struct {
+		BulkPatchID           int               `json:"bulkPatchId"`
+		BulkPatchStatus       string            `json:"bulkPatchStatus"`
+		SubmitDate            string            `json:"submitDate"`
+		UpdateDate            string            `json:"updateDate"`
+		PatchPropertyVersions []BulkPatchResult `json:"patchPropertyVersions"`
+	}
+
+	// BulkPatchResult is the outcome of patching a single property version
+	BulkPatchResult struct {
+		PropertyID      string `json:"propertyId"`
+		PropertyName    string `json:"propertyName"`
+		PropertyVersion int    `json:"propertyVersion"`
+		Etag            string `json:"etag,omitempty"`
+		Status          string `json:"status"`
+		FailureCause    string `json:"failureCause,omitempty"`
+	}
+)
+
+const (
+	// BulkSearchSyntaxJSONPath is the only query syntax supported by bulk search
+	BulkSearchSyntaxJSONPath = "JSONPATH"
+
+	// BulkStatusPending is returned while a bulk request waits to be processed
+	BulkStatusPending = "PENDING"
+	// BulkStatusInProgress is returned while a bulk request is processed
+	BulkStatusInProgress = "IN_PROGRESS"
+	// BulkStatusComplete is returned when a bulk request is finished
+	BulkStatusComplete = "COMPLETE"
+	// BulkStatusError is returned when a bulk request could not be processed
+	BulkStatusError = "ERROR"
+
+	// BulkItemStatusComplete is returned for a single property of a bulk request which was processed successfully
+	BulkItemStatusComplete = "COMPLETE"
+	// BulkItemStatusFailed is returned for a single property of a bulk request which could not be processed
+	BulkItemStatusFailed = "FAILED"
+)
+
+var bulkPatchOperations = []interface{}{"add", "remove", "replace", "move", "copy", "test"}
+
+var (
+	// ErrCreateBulkSearch represents error when submitting a bulk search fails
+	ErrCreateBulkSearch = errors.New("creating bulk search")
+	// ErrGetBulkSearch represents error when fetching a bulk search fails
+	ErrGetBulkSearch = errors.New("fetching bulk search")
+	// ErrCreateBulkVersions represents error when submitting a bulk version creation fails
+	ErrCreateBulkVersions = errors.New("creating bulk versions")
+	// ErrGetBulkVersions represents error when fetching a bulk version creation fails
+	ErrGetBulkVersions = errors.New("fetching bulk versions")
+	// ErrCreateBulkPatch represents error when submitting a bulk patch fails
+	ErrCreateBulkPatch = errors.New("creating bulk patch")
+	// ErrGetBulkPatch represents error when fetching a bulk patch fails
+	ErrGetBulkPatch = errors.New("fetching bulk patch")
+)
+
+// NewBulkClient returns a BulkPAPI client using the given session
+func NewBulkClient(sess session.Session) BulkPAPI {
+	return &bulkPAPI{Session: sess}
+}
+
+// Validate validates CreateBulkSearchRequest
+func (r CreateBulkSearchRequest) Validate() error {
+	return validation.Errors{
+		"BulkSearchQuery.Syntax": validation.Validate(r.BulkSearchQuery.Syntax, validation.Required, validation.In(BulkSearchSyntaxJSONPath)),
+		"BulkSearchQuery.Match":  validation.Validate(r.BulkSearchQuery.Match, validation.Required),
+	}.Filter()
+}
+
+// Validate validates GetBulkSearchRequest
+func (r GetBulkSearchRequest) Validate() error {
+	return validation.Errors{
+		"BulkSearchID": validation.Validate(r.BulkSearchID, validation.Required),
+	}.Filter()
+}
+
+// Validate validates CreateBulkVersionsRequest
+func (r CreateBulkVersionsRequest) Validate() error {
+	return validation.Errors{
+		"CreatePropertyVersions": validation.Validate(r.CreatePropertyVersions, validation.Required),
+	}.Filter()
+}
+
+// Validate validates BulkVersionCreation
+func (v BulkVersionCreation) Validate() error {
+	return validation.Errors{
+		"PropertyID":        validation.Validate(v.PropertyID, validation.Required),
+		"CreateFromVersion": validation.Validate(v.CreateFromVersion, validation.Required),
+	}.Filter()
+}
+
+// Validate validates GetBulkVersionsRequest
+func (r GetBulkVersionsRequest) Validate() error {
+	return validation.Errors{
+		"BulkCreateID": validation.Validate(r.BulkCreateID, validation.Required),
+	}.Filter()
+}
+
+// Validate validates CreateBulkPatchRequest
+func (r CreateBulkPatchRequest) Validate() error {
+	return validation.Errors{
+		"PatchPropertyVersions": validation.Validate(r.PatchPropertyVersions, validation.Required),
+	}.Filter()
+}
+
+// Validate validates BulkPatch
+func (p BulkPatch) Validate() error {
+	return validation.Errors{
+		"PropertyID":      validation.Validate(p.PropertyID, validation.Required),
+		"PropertyVersion": validation.Validate(p.PropertyVersion, validation.Required),
+		"Patches":         validation.Validate(p.Patches, validation.Required),
+	}.Filter()
+}
+
+// Validate validates BulkPatchOperation
+func (o BulkPatchOperation) Validate() error {
+	return validation.Errors{
+		"Op":   validation.Validate(o.Op, validation.Required, validation.In(bulkPatchOperations...)),
+		"Path": validation.Validate(o.Path, validation.Required),
+		"From": validation.Validate(o.From, validation.When(o.Op == "move" || o.Op == "copy", validation.Required)),
+	}.Filter()
+}
+
+// Validate validates GetBulkPatchRequest
+func (r GetBulkPatchRequest) Validate() error {
+	return validation.Errors{
+		"BulkPatchID": validation.Validate(r.BulkPatchID, validation.Required),
+	}.Filter()
+}
+
+func (b *bulkPAPI) CreateBulkSearch(ctx context.Context, params CreateBulkSearchRequest) (*CreateBulkSearchResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrCreateBulkSearch, papi.ErrStructValidation, err)
+	}
+
+	logger := b.Log(ctx)
+	logger.Debug("CreateBulkSearch")
+
+	uri, err := url.Parse("/papi/v1/bulk/rules-search-requests")
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse url: %s", ErrCreateBulkSearch, err)
+	}
+	q := uri.Query()
+	if params.ContractID != "" {
+		q.Add("contractId", params.ContractID)
+	}
+	if params.GroupID != "" {
+		q.Add("groupId", params.GroupID)
+	}
+	uri.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrCreateBulkSearch, err)
+	}
+
+	var rval CreateBulkSearchResponse
+	body := struct {
+		BulkSearchQuery BulkSearchQuery `json:"bulkSearchQuery"`
+	}{params.BulkSearchQuery}
+	resp, err := b.exec(req, &rval, body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrCreateBulkSearch, err)
+	}
+
+	if resp.StatusCode != http.StatusAccepted {
+		return nil, fmt.Errorf("%s: %w", ErrCreateBulkSearch, b.error(resp))
+	}
+
+	if rval.BulkSearchID, err = bulkIDFromLink(rval.BulkSearchLink); err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrCreateBulkSearch, err)
+	}
+
+	return &rval, nil
+}
+
+func (b *bulkPAPI) GetBulkSearch(ctx context.Context, params GetBulkSearchRequest) (*BulkSearchResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetBulkSearch, papi.ErrStructValidation, err)
+	}
+
+	logger := b.Log(ctx)
+	logger.Debug("GetBulkSearch")
+
+	uri := fmt.Sprintf("/papi/v1/bulk/rules-search-requests/%d", params.BulkSearchID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetBulkSearch, err)
+	}
+
+	var rval BulkSearchResponse
+	resp, err := b.exec(req, &rval)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetBulkSearch, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetBulkSearch, b.error(resp))
+	}
+
+	return &rval, nil
+}
+
+func (b *bulkPAPI) CreateBulkVersions(ctx context.Context, params CreateBulkVersionsRequest) (*CreateBulkVersionsResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrCreateBulkVersions, papi.ErrStructValidation, err)
+	}
+
+	logger := b.Log(ctx)
+	logger.Debug("CreateBulkVersions")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/papi/v1/bulk/property-version-creations", nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrCreateBulkVersions, err)
+	}
+
+	var rval CreateBulkVersionsResponse
+	resp, err := b.exec(req, &rval, params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrCreateBulkVersions, err)
+	}
+
+	if resp.StatusCode != http.StatusAccepted {
+		return nil, fmt.Errorf("%s: %w", ErrCreateBulkVersions, b.error(resp))
+	}
+
+	if rval.BulkCreateID, err = bulkIDFromLink(rval.BulkCreateVersionLink); err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrCreateBulkVersions, err)
+	}
+
+	return &rval, nil
+}
+
+func (b *bulkPAPI) GetBulkVersions(ctx context.Context, params GetBulkVersionsRequest) (*BulkVersionsResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetBulkVersions, papi.ErrStructValidation, err)
+	}
+
+	logger := b.Log(ctx)
+	logger.Debug("GetBulkVersions")
+
+	uri := fmt.Sprintf("/papi/v1/bulk/property-version-creations/%d", params.BulkCreateID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetBulkVersions, err)
+	}
+
+	var rval BulkVersionsResponse
+	resp, err := b.exec(req, &rval)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetBulkVersions, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetBulkVersions, b.error(resp))
+	}
+
+	return &rval, nil
+}
+
+func (b *bulkPAPI) CreateBulkPatch(ctx context.Context, params CreateBulkPatchRequest) (*CreateBulkPatchResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrCreateBulkPatch, papi.ErrStructValidation, err)
+	}
+
+	logger := b.Log(ctx)
+	logger.Debug("CreateBulkPatch")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/papi/v1/bulk/rules-patch-requests", nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrCreateBulkPatch, err)
+	}
+
+	var rval CreateBulkPatchResponse
+	resp, err := b.exec(req, &rval, params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrCreateBulkPatch, err)
+	}
+
+	if resp.StatusCode != http.StatusAccepted {
+		return nil, fmt.Errorf("%s: %w", ErrCreateBulkPatch, b.error(resp))
+	}
+
+	if rval.BulkPatchID, err = bulkIDFromLink(rval.BulkPatchLink); err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrCreateBulkPatch, err)
+	}
+
+	return &rval, nil
+}
+
+func (b *bulkPAPI) GetBulkPatch(ctx context.Context, params GetBulkPatchRequest) (*BulkPatchResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetBulkPatch, papi.ErrStructValidation, err)
+	}
+
+	logger := b.Log(ctx)
+	logger.Debug("GetBulkPatch")
+
+	uri := fmt.Sprintf("/papi/v1/bulk/rules-patch-requests/%d", params.BulkPatchID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetBulkPatch, err)
+	}
+
+	var rval BulkPatchResponse
+	resp, err := b.exec(req, &rval)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetBulkPatch, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetBulkPatch, b.error(resp))
+	}
+
+	return &rval, nil
+}
+
+// exec adds the PAPI-Use-Prefixes header the same way the papi client does
+func (b *bulkPAPI) exec(r *http.Request, out interface{}, in ...interface{}) (*http.Response, error) {
+	r.Header.Set("PAPI-Use-Prefixes", "true")
+
+	return b.Session.Exec(r, out, in...)
+}
+
+// error parses a papi.Error from the response
+func (b *bulkPAPI) error(r *http.Response) error {
+	return parseAPIError(b.Session, r)
+}
+
+// parseAPIError parses a papi.Error from the response, it is shared by the clients of APIs which are not
+// available in the edgegrid library
+func parseAPIError(sess session.Session, r *http.Response) error {
+	var e papi.Error
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		sess.Log(r.Request.Context()).Errorf("reading error response body: %s", err)
+		e.StatusCode = r.StatusCode
+		e.Title = "Failed to read error body"
+		e.Detail = err.Error()
+		return &e
+	}
+
+	if err := json.Unmarshal(body, &e); err != nil {
+		sess.Log(r.Request.Context()).Errorf("could not unmarshal API error: %s", err)
+		e.Title = "Failed to unmarshal error body"
+		e.Detail = err.Error()
+	}
+
+	e.StatusCode = r.StatusCode
+
+	return &e
+}
+
+// bulkIDFromLink extracts the ID of a bulk request from the link returned on its submission
+func bulkIDFromLink(link string) (int, error) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return 0, fmt.Errorf("invalid bulk request link '%s': %s", link, err)
+	}
+	id, err := strconv.Atoi(u.Path[strings.LastIndex(u.Path, "/")+1:])
+	if err != nil {
+		return 0, fmt.Errorf("invalid bulk request link '%s': %s", link, err)
+	}
+	return id, nil
+}
@@ -0,0 +1,404 @@
+package property
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/edgegrid"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockbulkpapi struct {
+	mock.Mock
+}
+
+func (p *mockbulkpapi) CreateBulkSearch(ctx context.Context, r CreateBulkSearchRequest) (*CreateBulkSearchResponse, error) {
+	args := p.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*CreateBulkSearchResponse), args.Error(1)
+}
+
+func (p *mockbulkpapi) GetBulkSearch(ctx context.Context, r GetBulkSearchRequest) (*BulkSearchResponse, error) {
+	args := p.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*BulkSearchResponse), args.Error(1)
+}
+
+func (p *mockbulkpapi) CreateBulkVersions(ctx context.Context, r CreateBulkVersionsRequest) (*CreateBulkVersionsResponse, error) {
+	args := p.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*CreateBulkVersionsResponse), args.Error(1)
+}
+
+func (p *mockbulkpapi) GetBulkVersions(ctx context.Context, r GetBulkVersionsRequest) (*BulkVersionsResponse, error) {
+	args := p.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*BulkVersionsResponse), args.Error(1)
+}
+
+func (p *mockbulkpapi) CreateBulkPatch(ctx context.Context, r CreateBulkPatchRequest) (*CreateBulkPatchResponse, error) {
+	args := p.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*CreateBulkPatchResponse), args.Error(1)
+}
+
+func (p *mockbulkpapi) GetBulkPatch(ctx context.Context, r GetBulkPatchRequest) (*BulkPatchResponse, error) {
+	args := p.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*BulkPatchResponse), args.Error(1)
+}
+
+func mockBulkAPIClient(t *testing.T, mockServer *httptest.Server) BulkPAPI {
+	return NewBulkClient(mockAPISession(t, mockServer))
+}
+
+// mockAPISession returns a session sending requests to the given test server
+func mockAPISession(t *testing.T, mockServer *httptest.Server) session.Session {
+	serverURL, err := url.Parse(mockServer.URL)
+	require.NoError(t, err)
+	certPool := x509.NewCertPool()
+	certPool.AddCert(mockServer.Certificate())
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs: certPool,
+			},
+		},
+	}
+	s, err := session.New(session.WithClient(httpClient), session.WithSigner(&edgegrid.Config{Host: serverURL.Host}))
+	require.NoError(t, err)
+	return s
+}
+
+func TestCreateBulkSearch(t *testing.T) {
+	tests := map[string]struct {
+		request          CreateBulkSearchRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedBody     string
+		expectedResponse *CreateBulkSearchResponse
+		withError        func(*testing.T, error)
+	}{
+		"202 accepted": {
+			request: CreateBulkSearchRequest{
+				ContractID: "ctr_1",
+				GroupID:    "grp_2",
+				BulkSearchQuery: BulkSearchQuery{
+					Syntax:               BulkSearchSyntaxJSONPath,
+					Match:                `$..features[?(@.name == "origin")].options.hostname`,
+					BulkSearchQualifiers: []string{`$.options[?(@.secure=="true")]`},
+				},
+			},
+			responseStatus: http.StatusAccepted,
+			responseBody:   `{"bulkSearchLink": "/papi/v1/bulk/rules-search-requests/5?contractId=ctr_1&groupId=grp_2"}`,
+			expectedPath:   "/papi/v1/bulk/rules-search-requests?contractId=ctr_1&groupId=grp_2",
+			expectedBody:   `{"bulkSearchQuery":{"syntax":"JSONPATH","match":"$..features[?(@.name == \"origin\")].options.hostname","bulkSearchQualifiers":["$.options[?(@.secure==\"true\")]"]}}`,
+			expectedResponse: &CreateBulkSearchResponse{
+				BulkSearchLink: "/papi/v1/bulk/rules-search-requests/5?contractId=ctr_1&groupId=grp_2",
+				BulkSearchID:   5,
+			},
+		},
+		"validation error": {
+			request: CreateBulkSearchRequest{BulkSearchQuery: BulkSearchQuery{Syntax: "XPATH"}},
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, papi.ErrStructValidation), "want: %s; got: %s", papi.ErrStructValidation, err)
+			},
+		},
+		"500 internal server error": {
+			request: CreateBulkSearchRequest{
+				BulkSearchQuery: BulkSearchQuery{Syntax: BulkSearchSyntaxJSONPath, Match: "$..behaviors"},
+			},
+			responseStatus: http.StatusInternalServerError,
+			responseBody:   `{"type": "internal_error", "title": "Internal Server Error", "status": 500}`,
+			expectedPath:   "/papi/v1/bulk/rules-search-requests",
+			expectedBody:   `{"bulkSearchQuery":{"syntax":"JSONPATH","match":"$..behaviors"}}`,
+			withError: func(t *testing.T, err error) {
+				want := &papi.Error{Type: "internal_error", Title: "Internal Server Error", StatusCode: http.StatusInternalServerError}
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "true", r.Header.Get("PAPI-Use-Prefixes"))
+				body, err := ioutil.ReadAll(r.Body)
+				require.NoError(t, err)
+				assert.JSONEq(t, test.expectedBody, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockBulkAPIClient(t, mockServer)
+			result, err := client.CreateBulkSearch(context.Background(), test.request)
+			if test.withError != nil {
+				test.withError(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestGetBulkSearch(t *testing.T) {
+	expected := BulkSearchResponse{
+		BulkSearchID:       5,
+		SearchTargetStatus: BulkStatusComplete,
+		SearchSubmitDate:   "2021-08-10T12:00:00Z",
+		SearchUpdateDate:   "2021-08-10T12:01:00Z",
+		BulkSearchQuery:    BulkSearchQuery{Syntax: BulkSearchSyntaxJSONPath, Match: "$..behaviors"},
+		Results: []BulkSearchResult{{
+			PropertyID:       "prp_1",
+			PropertyName:     "example.com",
+			PropertyVersion:  3,
+			IsLatest:         true,
+			StagingStatus:    "ACTIVE",
+			ProductionStatus: "INACTIVE",
+			MatchLocations:   []string{"/rules/behaviors/0"},
+		}},
+	}
+
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/papi/v1/bulk/rules-search-requests/5", r.URL.String())
+		assert.Equal(t, http.MethodGet, r.Method)
+		w.WriteHeader(http.StatusOK)
+		assert.NoError(t, json.NewEncoder(w).Encode(expected))
+	}))
+	defer mockServer.Close()
+	client := mockBulkAPIClient(t, mockServer)
+
+	result, err := client.GetBulkSearch(context.Background(), GetBulkSearchRequest{BulkSearchID: 5})
+	require.NoError(t, err)
+	assert.Equal(t, &expected, result)
+
+	_, err = client.GetBulkSearch(context.Background(), GetBulkSearchRequest{})
+	assert.True(t, errors.Is(err, papi.ErrStructValidation))
+}
+
+func TestCreateBulkVersions(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/papi/v1/bulk/property-version-creations", r.URL.String())
+		assert.Equal(t, http.MethodPost, r.Method)
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"createPropertyVersions":[{"propertyId":"prp_1","createFromVersion":2,"createFromVersionEtag":"a1"},{"propertyId":"prp_2","createFromVersion":5}]}`, string(body))
+		w.WriteHeader(http.StatusAccepted)
+		_, err = w.Write([]byte(`{"bulkCreateVersionLink": "/papi/v1/bulk/property-version-creations/7"}`))
+		assert.NoError(t, err)
+	}))
+	defer mockServer.Close()
+	client := mockBulkAPIClient(t, mockServer)
+
+	result, err := client.CreateBulkVersions(context.Background(), CreateBulkVersionsRequest{
+		CreatePropertyVersions: []BulkVersionCreation{
+			{PropertyID: "prp_1", CreateFromVersion: 2, CreateFromVersionEtag: "a1"},
+			{PropertyID: "prp_2", CreateFromVersion: 5},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &CreateBulkVersionsResponse{BulkCreateVersionLink: "/papi/v1/bulk/property-version-creations/7", BulkCreateID: 7}, result)
+
+	_, err = client.CreateBulkVersions(context.Background(), CreateBulkVersionsRequest{})
+	assert.True(t, errors.Is(err, papi.ErrStructValidation))
+	_, err = client.CreateBulkVersions(context.Background(), CreateBulkVersionsRequest{
+		CreatePropertyVersions: []BulkVersionCreation{{PropertyID: "prp_1"}},
+	})
+	assert.True(t, errors.Is(err, papi.ErrStructValidation))
+}
+
+func TestGetBulkVersions(t *testing.T) {
+	expected := BulkVersionsResponse{
+		BulkCreateID:             7,
+		BulkCreateVersionsStatus: BulkStatusComplete,
+		CreatePropertyVersions: []BulkVersionResult{
+			{PropertyID: "prp_1", PropertyName: "example.com", CreateFromVersion: 2, PropertyVersion: 3, Status: BulkItemStatusComplete},
+			{PropertyID: "prp_2", CreateFromVersion: 5, Status: BulkItemStatusFailed, FailureCause: "version is locked"},
+		},
+	}
+
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/papi/v1/bulk/property-version-creations/7", r.URL.String())
+		assert.Equal(t, http.MethodGet, r.Method)
+		w.WriteHeader(http.StatusOK)
+		assert.NoError(t, json.NewEncoder(w).Encode(expected))
+	}))
+	defer mockServer.Close()
+	client := mockBulkAPIClient(t, mockServer)
+
+	result, err := client.GetBulkVersions(context.Background(), GetBulkVersionsRequest{BulkCreateID: 7})
+	require.NoError(t, err)
+	assert.Equal(t, &expected, result)
+}
+
+func TestCreateBulkPatch(t *testing.T) {
+	tests := map[string]struct {
+		request          CreateBulkPatchRequest
+		responseStatus   int
+		responseBody     string
+		expectedBody     string
+		expectedResponse *CreateBulkPatchResponse
+		withError        func(*testing.T, error)
+	}{
+		"202 accepted": {
+			request: CreateBulkPatchRequest{
+				PatchPropertyVersions: []BulkPatch{{
+					PropertyID:      "prp_1",
+					PropertyVersion: 3,
+					Patches: []BulkPatchOperation{
+						{Op: "replace", Path: "/rules/behaviors/0/options/hostname", Value: json.RawMessage(`"origin2.example.com"`)},
+						{Op: "replace", Path: "/rules/options/is_secure", Value: json.RawMessage(`false`)},
+						{Op: "remove", Path: "/rules/children/1"},
+					},
+				}},
+			},
+			responseStatus: http.StatusAccepted,
+			responseBody:   `{"bulkPatchLink": "/papi/v1/bulk/rules-patch-requests/9"}`,
+			expectedBody: `{"patchPropertyVersions":[{"propertyId":"prp_1","propertyVersion":3,"patches":[
+				{"op":"replace","path":"/rules/behaviors/0/options/hostname","value":"origin2.example.com"},
+				{"op":"replace","path":"/rules/options/is_secure","value":false},
+				{"op":"remove","path":"/rules/children/1"}]}]}`,
+			expectedResponse: &CreateBulkPatchResponse{BulkPatchLink: "/papi/v1/bulk/rules-patch-requests/9", BulkPatchID: 9},
+		},
+		"invalid operation": {
+			request: CreateBulkPatchRequest{
+				PatchPropertyVersions: []BulkPatch{{
+					PropertyID:      "prp_1",
+					PropertyVersion: 3,
+					Patches:         []BulkPatchOperation{{Op: "merge", Path: "/rules"}},
+				}},
+			},
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, papi.ErrStructValidation), "want: %s; got: %s", papi.ErrStructValidation, err)
+			},
+		},
+		"move without from": {
+			request: CreateBulkPatchRequest{
+				PatchPropertyVersions: []BulkPatch{{
+					PropertyID:      "prp_1",
+					PropertyVersion: 3,
+					Patches:         []BulkPatchOperation{{Op: "move", Path: "/rules/children/0"}},
+				}},
+			},
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, papi.ErrStructValidation), "want: %s; got: %s", papi.ErrStructValidation, err)
+			},
+		},
+		"403 forbidden": {
+			request: CreateBulkPatchRequest{
+				PatchPropertyVersions: []BulkPatch{{
+					PropertyID:      "prp_1",
+					PropertyVersion: 3,
+					Patches:         []BulkPatchOperation{{Op: "remove", Path: "/rules/children/1"}},
+				}},
+			},
+			responseStatus: http.StatusForbidden,
+			responseBody:   `{"type": "forbidden", "title": "Forbidden", "status": 403}`,
+			expectedBody:   `{"patchPropertyVersions":[{"propertyId":"prp_1","propertyVersion":3,"patches":[{"op":"remove","path":"/rules/children/1"}]}]}`,
+			withError: func(t *testing.T, err error) {
+				want := &papi.Error{Type: "forbidden", Title: "Forbidden", StatusCode: http.StatusForbidden}
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/papi/v1/bulk/rules-patch-requests", r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				require.NoError(t, err)
+				assert.JSONEq(t, test.expectedBody, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockBulkAPIClient(t, mockServer)
+			result, err := client.CreateBulkPatch(context.Background(), test.request)
+			if test.withError != nil {
+				test.withError(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestGetBulkPatch(t *testing.T) {
+	expected := BulkPatchResponse{
+		BulkPatchID:     9,
+		BulkPatchStatus: BulkStatusComplete,
+		PatchPropertyVersions: []BulkPatchResult{
+			{PropertyID: "prp_1", PropertyName: "example.com", PropertyVersion: 3, Etag: "b2", Status: BulkItemStatusComplete},
+		},
+	}
+
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/papi/v1/bulk/rules-patch-requests/9", r.URL.String())
+		assert.Equal(t, http.MethodGet, r.Method)
+		w.WriteHeader(http.StatusOK)
+		assert.NoError(t, json.NewEncoder(w).Encode(expected))
+	}))
+	defer mockServer.Close()
+	client := mockBulkAPIClient(t, mockServer)
+
+	result, err := client.GetBulkPatch(context.Background(), GetBulkPatchRequest{BulkPatchID: 9})
+	require.NoError(t, err)
+	assert.Equal(t, &expected, result)
+
+	_, err = client.GetBulkPatch(context.Background(), GetBulkPatchRequest{})
+	assert.True(t, errors.Is(err, papi.ErrStructValidation))
+}
+
+func TestBulkIDFromLink(t *testing.T) {
+	id, err := bulkIDFromLink("/papi/v1/bulk/rules-search-requests/42?contractId=ctr_1")
+	require.NoError(t, err)
+	assert.Equal(t, 42, id)
+
+	_, err = bulkIDFromLink("/papi/v1/bulk/rules-search-requests/")
+	assert.Error(t, err)
+}
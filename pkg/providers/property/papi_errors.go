@@ -50,6 +50,8 @@ var (
 	ErrLookingUpCPCode = errors.New("looking up CP Code by name")
 	// ErrCPCodeModify is returned while attempting to modify existing CP code
 	ErrCPCodeModify = errors.New("CP Code with provided name already exists for provided group and contract IDs and it cannot be managed through this API - please contact Customer Support")
+	// ErrCPCodeUpdateNotAllowed is returned when the contract, group or product of existing CP code is changed
+	ErrCPCodeUpdateNotAllowed = errors.New("contract, group and product of existing CP code cannot be changed")
 	// ErrCpCodeNotFound is returned when cp code with provided ID does not exist
 	ErrCpCodeNotFound = errors.New("cp code not found")
 
@@ -74,6 +76,10 @@ var (
 
 	// ErrEdgeHostnameNotFound is returned when no edgehostname were found
 	ErrEdgeHostnameNotFound = errors.New("unable to find edge hostname")
+	// ErrEdgeHostnameUpdateNotAllowed is returned when the contract, group or product of existing edge hostname is changed
+	ErrEdgeHostnameUpdateNotAllowed = errors.New("contract, group and product of existing edge hostname cannot be changed")
+	// ErrEdgeHostnameChangeFailed is returned when an edge hostname change request fails
+	ErrEdgeHostnameChangeFailed = errors.New("edge hostname change failed")
 
 	// DiagWarnActivationTimeout returned on activation poll timeout
 	DiagWarnActivationTimeout = diag.Diagnostic{
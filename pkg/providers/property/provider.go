@@ -21,7 +21,11 @@ type (
 	provider struct {
 		*schema.Provider
 
-		client papi.PAPI
+		client     papi.PAPI
+		bulkClient BulkPAPI
+		cprgClient CPRG
+		hapiClient HAPI
+		cpsClient  CPS
 	}
 
 	// Option is a papi provider option
@@ -72,25 +76,35 @@ func Provider() *schema.Provider {
 			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"akamai_contract":                dataSourcePropertyContract(),
-			"akamai_contracts":               dataSourceAkamaiContracts(),
-			"akamai_cp_code":                 dataSourceCPCode(),
-			"akamai_group":                   dataSourcePropertyGroup(),
-			"akamai_groups":                  dataSourcePropertyMultipleGroups(),
-			"akamai_property_rules":          dataPropertyRules(),
-			"akamai_property_rule_formats":   dataPropertyRuleFormats(),
-			"akamai_property":                dataSourceAkamaiProperty(),
-			"akamai_property_rules_template": dataSourcePropertyRulesTemplate(),
-			"akamai_properties":              dataSourceAkamaiProperties(),
-			"akamai_property_products":       dataSourceAkamaiPropertyProducts(),
-			"akamai_property_hostnames":      dataSourceAkamaiPropertyHostnames(),
+			"akamai_contract":                     dataSourcePropertyContract(),
+			"akamai_contracts":                    dataSourceAkamaiContracts(),
+			"akamai_cp_code":                      dataSourceCPCode(),
+			"akamai_group":                        dataSourcePropertyGroup(),
+			"akamai_groups":                       dataSourcePropertyMultipleGroups(),
+			"akamai_property_rules":               dataPropertyRules(),
+			"akamai_property_rule_formats":        dataPropertyRuleFormats(),
+			"akamai_property":                     dataSourceAkamaiProperty(),
+			"akamai_property_rules_template":      dataSourcePropertyRulesTemplate(),
+			"akamai_properties":                   dataSourceAkamaiProperties(),
+			"akamai_property_products":            dataSourceAkamaiPropertyProducts(),
+			"akamai_property_hostnames":           dataSourceAkamaiPropertyHostnames(),
+			"akamai_property_bulk_search":         dataSourceAkamaiPropertyBulkSearch(),
+			"akamai_edge_hostnames":               dataSourceAkamaiEdgeHostnames(),
+			"akamai_property_rules_upgrade":       dataSourceAkamaiPropertyRulesUpgrade(),
+			"akamai_property_rules_composition":   dataSourceAkamaiPropertyRulesComposition(),
+			"akamai_property_rules_lint":          dataSourceAkamaiPropertyRulesLint(),
+			"akamai_property_activations":         dataSourceAkamaiPropertyActivations(),
+			"akamai_property_hostnames_inventory": dataSourceAkamaiPropertyHostnamesInventory(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"akamai_cp_code":             resourceCPCode(),
-			"akamai_edge_hostname":       resourceSecureEdgeHostName(),
-			"akamai_property":            resourceProperty(),
-			"akamai_property_variables":  resourcePropertyVariables(),
-			"akamai_property_activation": resourcePropertyActivation(),
+			"akamai_cp_code":                   resourceCPCode(),
+			"akamai_edge_hostname":             resourceSecureEdgeHostName(),
+			"akamai_property":                  resourceProperty(),
+			"akamai_property_variables":        resourcePropertyVariables(),
+			"akamai_property_activation":       resourcePropertyActivation(),
+			"akamai_property_activation_batch": resourcePropertyActivationBatch(),
+			"akamai_property_bulk_patch":       resourcePropertyBulkPatch(),
+			"akamai_property_certificate_wait": resourcePropertyCertificateWait(),
 		},
 	}
 	return provider
@@ -103,6 +117,34 @@ func WithClient(c papi.PAPI) Option {
 	}
 }
 
+// WithBulkClient sets the bulk client interface, used for mocking and testing
+func WithBulkClient(c BulkPAPI) Option {
+	return func(p *provider) {
+		p.bulkClient = c
+	}
+}
+
+// WithCPRGClient sets the CP Code and Reporting Group client interface, used for mocking and testing
+func WithCPRGClient(c CPRG) Option {
+	return func(p *provider) {
+		p.cprgClient = c
+	}
+}
+
+// WithHAPIClient sets the Edge Hostnames API client interface, used for mocking and testing
+func WithHAPIClient(c HAPI) Option {
+	return func(p *provider) {
+		p.hapiClient = c
+	}
+}
+
+// WithCPSClient sets the Certificate Provisioning System client interface, used for mocking and testing
+func WithCPSClient(c CPS) Option {
+	return func(p *provider) {
+		p.cpsClient = c
+	}
+}
+
 // Client returns the PAPI interface
 func (p *provider) Client(meta akamai.OperationMeta) papi.PAPI {
 	if p.client != nil {
@@ -111,6 +153,38 @@ func (p *provider) Client(meta akamai.OperationMeta) papi.PAPI {
 	return papi.Client(meta.Session())
 }
 
+// BulkClient returns the BulkPAPI interface
+func (p *provider) BulkClient(meta akamai.OperationMeta) BulkPAPI {
+	if p.bulkClient != nil {
+		return p.bulkClient
+	}
+	return NewBulkClient(meta.Session())
+}
+
+// CPRGClient returns the CPRG interface
+func (p *provider) CPRGClient(meta akamai.OperationMeta) CPRG {
+	if p.cprgClient != nil {
+		return p.cprgClient
+	}
+	return NewCPRGClient(meta.Session())
+}
+
+// HAPIClient returns the HAPI interface
+func (p *provider) HAPIClient(meta akamai.OperationMeta) HAPI {
+	if p.hapiClient != nil {
+		return p.hapiClient
+	}
+	return NewHAPIClient(meta.Session())
+}
+
+// CPSClient returns the CPS interface
+func (p *provider) CPSClient(meta akamai.OperationMeta) CPS {
+	if p.cpsClient != nil {
+		return p.cpsClient
+	}
+	return NewCPSClient(meta.Session())
+}
+
 func getPAPIV1Service(d *schema.ResourceData) error {
 	var inlineConfig *schema.Set
 	for _, key := range []string{"property", "config"} {
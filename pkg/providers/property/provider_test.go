@@ -58,6 +58,74 @@ func useClient(client papi.PAPI, f func()) {
 	f()
 }
 
+// Only allow one test at a time to patch the bulk client via useBulkClient()
+var bulkClientLock sync.Mutex
+
+// useBulkClient swaps out the bulk client on the global instance for the duration of the given func
+func useBulkClient(client BulkPAPI, f func()) {
+	bulkClientLock.Lock()
+	orig := inst.bulkClient
+	inst.bulkClient = client
+
+	defer func() {
+		inst.bulkClient = orig
+		bulkClientLock.Unlock()
+	}()
+
+	f()
+}
+
+// Only allow one test at a time to patch the CPRG client via useCPRGClient()
+var cprgClientLock sync.Mutex
+
+// useCPRGClient swaps out the CPRG client on the global instance for the duration of the given func
+func useCPRGClient(client CPRG, f func()) {
+	cprgClientLock.Lock()
+	orig := inst.cprgClient
+	inst.cprgClient = client
+
+	defer func() {
+		inst.cprgClient = orig
+		cprgClientLock.Unlock()
+	}()
+
+	f()
+}
+
+// Only allow one test at a time to patch the HAPI client via useHAPIClient()
+var hapiClientLock sync.Mutex
+
+// useHAPIClient swaps out the HAPI client on the global instance for the duration of the given func
+func useHAPIClient(client HAPI, f func()) {
+	hapiClientLock.Lock()
+	orig := inst.hapiClient
+	inst.hapiClient = client
+
+	defer func() {
+		inst.hapiClient = orig
+		hapiClientLock.Unlock()
+	}()
+
+	f()
+}
+
+// Only allow one test at a time to patch the CPS client via useCPSClient()
+var cpsClientLock sync.Mutex
+
+// useCPSClient swaps out the CPS client on the global instance for the duration of the given func
+func useCPSClient(client CPS, f func()) {
+	cpsClientLock.Lock()
+	orig := inst.cpsClient
+	inst.cpsClient = client
+
+	defer func() {
+		inst.cpsClient = orig
+		cpsClientLock.Unlock()
+	}()
+
+	f()
+}
+
 // TODO marks a test as being in a "pending" state and logs a message telling the user why. Such tests are expected to
 // fail for the time being and may exist for the sake of unfinished/future features or to document known buggy cases
 // that won't be fixed right away. The failure of a pending test is not considered an error and the test will therefore
@@ -22,6 +22,7 @@ func resourceCPCode() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceCPCodeCreate,
 		ReadContext:   resourceCPCodeRead,
+		UpdateContext: resourceCPCodeUpdate,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceCPCodeImport,
 		},
@@ -33,7 +34,6 @@ func resourceCPCode() *schema.Resource {
 			"name": {
 				Type:             schema.TypeString,
 				Required:         true,
-				ForceNew:         true,
 				ValidateDiagFunc: tools.IsNotBlank,
 			},
 			"contract": {
@@ -79,6 +79,18 @@ func resourceCPCode() *schema.Resource {
 				ConflictsWith: []string{"product"},
 				StateFunc:     addPrefixToState("prd_"),
 			},
+			"time_zone": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Time zone used for the reports of the CP code, managed through the CP Code and Reporting Group API",
+			},
+			"purgeable": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether content of the CP code can be purged, managed through the CP Code and Reporting Group API",
+			},
 		},
 	}
 }
@@ -123,7 +135,7 @@ func resourceCPCodeCreate(ctx context.Context, d *schema.ResourceData, m interfa
 
 	// Because CPCodes can't be deleted, we re-use an existing CPCode if it's there
 	cpCode, err := findCPCode(ctx, name, contractID, groupID, meta)
-	if err != nil && !errors.As(err, &ErrCpCodeNotFound) {
+	if err != nil && !errors.Is(err, ErrCpCodeNotFound) {
 		return diag.FromErr(fmt.Errorf("%s: %w", ErrLookingUpCPCode, err))
 	}
 
@@ -138,6 +150,12 @@ func resourceCPCodeCreate(ctx context.Context, d *schema.ResourceData, m interfa
 		d.SetId(cpCode.ID)
 	}
 
+	if cpCodeDetailsManaged(d) {
+		if err := updateCPCodeDetails(ctx, d, meta); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	logger.Debugf("Resulting CP Code: %#v", cpCode)
 	return resourceCPCodeRead(ctx, d, m)
 }
@@ -184,25 +202,20 @@ func resourceCPCodeRead(ctx context.Context, d *schema.ResourceData, m interface
 
 	// Attempt to find by ID first
 	cpCode, err := findCPCode(ctx, d.Id(), contractID, groupID, meta)
-	if err != nil {
+	if err != nil && !errors.Is(err, ErrCpCodeNotFound) {
 		return diag.FromErr(err)
 	}
 
 	// Otherwise attempt to find by name
 	if cpCode == nil {
-		// FIXME: I'm not clear how this could ever happen. A read couldn't happen until after TF created it and it had
-		//        been assigned an ID by PAPI and that ID was previously set in the resource, right?
-		cpCode, err := findCPCode(ctx, name, contractID, groupID, meta)
-		if err != nil {
+		if cpCode, err = findCPCode(ctx, name, contractID, groupID, meta); err != nil {
 			return diag.FromErr(err)
 		}
-
-		// It really doesn't exist, give up
-		if cpCode == nil {
-			return diag.Errorf("Couldn't find the CP Code")
-		}
 	}
 
+	if name != "" && cpCode.Name != name {
+		logger.Warnf("CP Code %s was renamed outside of terraform from '%s' to '%s'", cpCode.ID, name, cpCode.Name)
+	}
 	if err := d.Set("name", cpCode.Name); err != nil {
 		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
 	}
@@ -217,10 +230,37 @@ func resourceCPCodeRead(ctx context.Context, d *schema.ResourceData, m interface
 		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
 	}
 	d.SetId(cpCode.ID)
+
+	if cpCodeDetailsManaged(d) {
+		if err := readCPCodeDetails(ctx, d, meta); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	logger.Debugf("Read CP Code: %+v", cpCode)
 	return nil
 }
 
+func resourceCPCodeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourceCPCodeUpdate")
+	logger.Debugf("Update CP Code")
+
+	if d.HasChanges("contract_id", "contract", "group_id", "group", "product_id", "product") {
+		diags := tools.RestoreOldValues(d, []string{"contract_id", "contract", "group_id", "group", "product_id", "product"})
+		return append(diags, diag.FromErr(ErrCPCodeUpdateNotAllowed)...)
+	}
+
+	if d.HasChanges("name", "time_zone", "purgeable") {
+		if err := updateCPCodeDetails(ctx, d, meta); err != nil {
+			diags := tools.RestoreOldValues(d, []string{"name", "time_zone", "purgeable"})
+			return append(diags, diag.FromErr(err)...)
+		}
+	}
+
+	return resourceCPCodeRead(ctx, d, m)
+}
+
 func resourceCPCodeImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	meta := akamai.Meta(m)
 	logger := meta.Log("PAPI", "resourceCPCodeImport")
@@ -229,16 +269,17 @@ func resourceCPCodeImport(ctx context.Context, d *schema.ResourceData, m interfa
 	parts := strings.Split(d.Id(), ",")
 
 	if len(parts) < 3 {
-		return nil, fmt.Errorf("comma-separated list of CP code ID, contract ID and group ID has to be supplied in import: %s", d.Id())
+		return nil, fmt.Errorf("comma-separated list of CP code ID or name, contract ID and group ID has to be supplied in import: %s", d.Id())
 	}
-	if parts[0] == "" {
+	// CP code names may contain commas, contract and group are always the last two parts
+	nameOrID := strings.Join(parts[:len(parts)-2], ",")
+	if nameOrID == "" {
 		return nil, errors.New("CP Code is a mandatory parameter")
 	}
-	cpCodeID := tools.AddPrefix(parts[0], "cpc_")
-	contractID := tools.AddPrefix(parts[1], "ctr_")
-	groupID := tools.AddPrefix(parts[2], "grp_")
+	contractID := tools.AddPrefix(parts[len(parts)-2], "ctr_")
+	groupID := tools.AddPrefix(parts[len(parts)-1], "grp_")
 
-	cpCode, err := findCPCode(ctx, cpCodeID, contractID, groupID, meta)
+	cpCode, err := findCPCode(ctx, nameOrID, contractID, groupID, meta)
 	if err != nil {
 		return nil, err
 	}
@@ -283,3 +324,71 @@ func createCPCode(ctx context.Context, name, productID, contractID, groupID stri
 
 	return r.CPCodeID, nil
 }
+
+// cpCodeDetailsManaged tells whether the attributes managed through the CP Code and Reporting Group API are used,
+// the API is only called for them so that CP codes can still be managed with PAPI access only
+func cpCodeDetailsManaged(d *schema.ResourceData) bool {
+	_, purgeable := d.GetOkExists("purgeable")
+	return purgeable || d.Get("time_zone").(string) != ""
+}
+
+// readCPCodeDetails sets the time zone and purgeability of the CP code from the CP Code and Reporting Group API
+func readCPCodeDetails(ctx context.Context, d *schema.ResourceData, meta akamai.OperationMeta) error {
+	cpCodeID, err := tools.GetIntID(d.Id(), "cpc_")
+	if err != nil {
+		return err
+	}
+	detail, err := inst.CPRGClient(meta).GetCPCode(ctx, GetCPCodeRequest{CPCodeID: cpCodeID})
+	if err != nil {
+		return err
+	}
+
+	timeZone := detail.DefaultTimeZone
+	if detail.OverrideTimeZone != nil {
+		timeZone = detail.OverrideTimeZone.TimeZoneID
+	}
+	if err := d.Set("time_zone", timeZone); err != nil {
+		return fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error())
+	}
+	if err := d.Set("purgeable", detail.Purgeable); err != nil {
+		return fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error())
+	}
+	return nil
+}
+
+// updateCPCodeDetails updates the name, time zone and purgeability of the CP code, PAPI does not support CP code
+// updates so the CP Code and Reporting Group API is used
+func updateCPCodeDetails(ctx context.Context, d *schema.ResourceData, meta akamai.OperationMeta) error {
+	logger := meta.Log("PAPI", "updateCPCodeDetails")
+	client := inst.CPRGClient(meta)
+
+	cpCodeID, err := tools.GetIntID(d.Id(), "cpc_")
+	if err != nil {
+		return err
+	}
+	detail, err := client.GetCPCode(ctx, GetCPCodeRequest{CPCodeID: cpCodeID})
+	if err != nil {
+		return err
+	}
+
+	// only the writable fields are sent
+	cpCode := CPCodeDetail{
+		CPCodeName:       d.Get("name").(string),
+		Purgeable:        detail.Purgeable,
+		OverrideTimeZone: detail.OverrideTimeZone,
+		Contracts:        detail.Contracts,
+		Products:         detail.Products,
+	}
+	if timeZone := d.Get("time_zone").(string); timeZone != "" {
+		cpCode.OverrideTimeZone = &CPCodeTimeZone{TimeZoneID: timeZone}
+	}
+	if purgeable, ok := d.GetOkExists("purgeable"); ok {
+		cpCode.Purgeable = purgeable.(bool)
+	}
+
+	logger.Debugf("Updating CP Code %d: %+v", cpCodeID, cpCode)
+	if _, err := client.UpdateCPCode(ctx, UpdateCPCodeRequest{CPCodeID: cpCodeID, CPCode: cpCode}); err != nil {
+		return err
+	}
+	return nil
+}
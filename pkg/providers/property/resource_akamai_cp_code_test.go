@@ -229,17 +229,189 @@ func TestResCPCode(t *testing.T) {
 	t.Run("change name", func(t *testing.T) {
 		client := &mockpapi{}
 		defer client.AssertExpectations(t)
+		cprgClient := &mockcprg{}
+		defer cprgClient.AssertExpectations(t)
 
 		// Contains CP Codes known to mock PAPI
-		CPCodes := []papi.CPCode{}
+		CPCodes := []papi.CPCode{{ID: "cpc_0", Name: "other cpcode", ProductIDs: []string{"prd_1"}}}
+		detail := &CPCodeDetail{
+			CPCodeID:   1,
+			CPCodeName: "test cpcode",
+			Contracts:  []CPCodeContract{{ContractID: "1"}},
+			Products:   []CPCodeProduct{{ProductID: "1"}},
+		}
 
 		// Values are from fixture:
 		expectGetCPCode(client, "ctr_1", "grp_1", &CPCodes)
 		expectCreateCPCode(client, "test cpcode", "prd_1", "ctr_1", "grp_1", &CPCodes).Once()
-		expectCreateCPCode(client, "renamed cpcode", "prd_1", "ctr_1", "grp_1", &CPCodes).Once()
+		cprgClient.On("GetCPCode", AnyCTX, GetCPCodeRequest{CPCodeID: 1}).Return(detail, nil).Once()
+		cprgClient.On("UpdateCPCode", AnyCTX, UpdateCPCodeRequest{
+			CPCodeID: 1,
+			CPCode: CPCodeDetail{
+				CPCodeName: "renamed cpcode",
+				Contracts:  []CPCodeContract{{ContractID: "1"}},
+				Products:   []CPCodeProduct{{ProductID: "1"}},
+			},
+		}).Run(func(mock.Arguments) {
+			CPCodes[1].Name = "renamed cpcode"
+		}).Return(detail, nil).Once()
 
 		// No mock behavior for delete because there is no delete operation for CP Codes
 
+		useClient(client, func() {
+			useCPRGClient(cprgClient, func() {
+				resource.UnitTest(t, resource.TestCase{
+					Providers: testAccProviders,
+					Steps: []resource.TestStep{
+						{
+							Config: loadFixtureString("testdata/TestResCPCode/change_name_step0.tf"),
+							Check:  resource.TestCheckResourceAttr("akamai_cp_code.test", "id", "cpc_1"),
+						},
+						{
+							Config: loadFixtureString("testdata/TestResCPCode/change_name_step1.tf"),
+							Check: resource.ComposeAggregateTestCheckFunc(
+								resource.TestCheckResourceAttr("akamai_cp_code.test", "id", "cpc_1"),
+								resource.TestCheckResourceAttr("akamai_cp_code.test", "name", "renamed cpcode"),
+							),
+						},
+					},
+				})
+			})
+		})
+	})
+
+	t.Run("change time zone and purgeable", func(t *testing.T) {
+		client := &mockpapi{}
+		defer client.AssertExpectations(t)
+		cprgClient := &mockcprg{}
+		defer cprgClient.AssertExpectations(t)
+
+		// Contains CP Codes known to mock PAPI
+		CPCodes := []papi.CPCode{{ID: "cpc_0", Name: "other cpcode", ProductIDs: []string{"prd_1"}}}
+		// Contains the CP Code known to mock CPRG, updated by UpdateCPCode
+		detail := &CPCodeDetail{
+			CPCodeID:        1,
+			CPCodeName:      "test cpcode",
+			DefaultTimeZone: "0",
+			Contracts:       []CPCodeContract{{ContractID: "1"}},
+			Products:        []CPCodeProduct{{ProductID: "1"}},
+		}
+		expectUpdateCPCode := func(timeZone string, purgeable bool) {
+			cprgClient.On("UpdateCPCode", AnyCTX, UpdateCPCodeRequest{
+				CPCodeID: 1,
+				CPCode: CPCodeDetail{
+					CPCodeName:       "test cpcode",
+					Purgeable:        purgeable,
+					OverrideTimeZone: &CPCodeTimeZone{TimeZoneID: timeZone},
+					Contracts:        []CPCodeContract{{ContractID: "1"}},
+					Products:         []CPCodeProduct{{ProductID: "1"}},
+				},
+			}).Run(func(mock.Arguments) {
+				detail.OverrideTimeZone = &CPCodeTimeZone{TimeZoneID: timeZone}
+				detail.Purgeable = purgeable
+			}).Return(detail, nil).Once()
+		}
+
+		expectGetCPCode(client, "ctr_1", "grp_1", &CPCodes)
+		expectCreateCPCode(client, "test cpcode", "prd_1", "ctr_1", "grp_1", &CPCodes).Once()
+		cprgClient.On("GetCPCode", AnyCTX, GetCPCodeRequest{CPCodeID: 1}).Return(detail, nil)
+		expectUpdateCPCode("1", true)
+		expectUpdateCPCode("2", false)
+
+		useClient(client, func() {
+			useCPRGClient(cprgClient, func() {
+				resource.UnitTest(t, resource.TestCase{
+					Providers: testAccProviders,
+					Steps: []resource.TestStep{
+						{
+							Config: loadFixtureString("testdata/TestResCPCode/time_zone_purgeable_step0.tf"),
+							Check: resource.ComposeAggregateTestCheckFunc(
+								resource.TestCheckResourceAttr("akamai_cp_code.test", "id", "cpc_1"),
+								resource.TestCheckResourceAttr("akamai_cp_code.test", "time_zone", "1"),
+								resource.TestCheckResourceAttr("akamai_cp_code.test", "purgeable", "true"),
+							),
+						},
+						{
+							Config: loadFixtureString("testdata/TestResCPCode/time_zone_purgeable_step1.tf"),
+							Check: resource.ComposeAggregateTestCheckFunc(
+								resource.TestCheckResourceAttr("akamai_cp_code.test", "id", "cpc_1"),
+								resource.TestCheckResourceAttr("akamai_cp_code.test", "time_zone", "2"),
+								resource.TestCheckResourceAttr("akamai_cp_code.test", "purgeable", "false"),
+							),
+						},
+					},
+				})
+			})
+		})
+	})
+
+	t.Run("time zone only keeps purgeable", func(t *testing.T) {
+		client := &mockpapi{}
+		defer client.AssertExpectations(t)
+		cprgClient := &mockcprg{}
+		defer cprgClient.AssertExpectations(t)
+
+		// Contains CP Codes known to mock PAPI
+		CPCodes := []papi.CPCode{{ID: "cpc_0", Name: "other cpcode", ProductIDs: []string{"prd_1"}}}
+		// Contains the CP Code known to mock CPRG, updated by UpdateCPCode
+		detail := &CPCodeDetail{
+			CPCodeID:        1,
+			CPCodeName:      "test cpcode",
+			DefaultTimeZone: "0",
+			Purgeable:       true,
+			Contracts:       []CPCodeContract{{ContractID: "1"}},
+			Products:        []CPCodeProduct{{ProductID: "1"}},
+		}
+
+		expectGetCPCode(client, "ctr_1", "grp_1", &CPCodes)
+		expectCreateCPCode(client, "test cpcode", "prd_1", "ctr_1", "grp_1", &CPCodes).Once()
+		cprgClient.On("GetCPCode", AnyCTX, GetCPCodeRequest{CPCodeID: 1}).Return(detail, nil)
+		cprgClient.On("UpdateCPCode", AnyCTX, UpdateCPCodeRequest{
+			CPCodeID: 1,
+			CPCode: CPCodeDetail{
+				CPCodeName:       "test cpcode",
+				Purgeable:        true,
+				OverrideTimeZone: &CPCodeTimeZone{TimeZoneID: "1"},
+				Contracts:        []CPCodeContract{{ContractID: "1"}},
+				Products:         []CPCodeProduct{{ProductID: "1"}},
+			},
+		}).Run(func(mock.Arguments) {
+			detail.OverrideTimeZone = &CPCodeTimeZone{TimeZoneID: "1"}
+		}).Return(detail, nil).Once()
+
+		useClient(client, func() {
+			useCPRGClient(cprgClient, func() {
+				resource.UnitTest(t, resource.TestCase{
+					Providers: testAccProviders,
+					Steps: []resource.TestStep{
+						{
+							Config: loadFixtureString("testdata/TestResCPCode/time_zone_only.tf"),
+							Check: resource.ComposeAggregateTestCheckFunc(
+								resource.TestCheckResourceAttr("akamai_cp_code.test", "time_zone", "1"),
+								resource.TestCheckResourceAttr("akamai_cp_code.test", "purgeable", "true"),
+							),
+						},
+						{
+							// purgeable isn't configured, its live value doesn't show as a change
+							Config:   loadFixtureString("testdata/TestResCPCode/time_zone_only.tf"),
+							PlanOnly: true,
+						},
+					},
+				})
+			})
+		})
+	})
+
+	t.Run("detect rename outside of terraform", func(t *testing.T) {
+		client := &mockpapi{}
+		defer client.AssertExpectations(t)
+
+		// Contains CP Codes known to mock PAPI
+		CPCodes := []papi.CPCode{}
+
+		expectGetCPCode(client, "ctr_1", "grp_1", &CPCodes)
+		expectCreateCPCode(client, "test cpcode", "prd_1", "ctr_1", "grp_1", &CPCodes).Once()
+
 		useClient(client, func() {
 			resource.UnitTest(t, resource.TestCase{
 				Providers: testAccProviders,
@@ -249,8 +421,39 @@ func TestResCPCode(t *testing.T) {
 						Check:  resource.TestCheckResourceAttr("akamai_cp_code.test", "id", "cpc_0"),
 					},
 					{
-						Config: loadFixtureString("testdata/TestResCPCode/change_name_step1.tf"),
-						Check:  resource.TestCheckResourceAttr("akamai_cp_code.test", "id", "cpc_1"),
+						PreConfig: func() {
+							CPCodes[0].Name = "renamed outside"
+						},
+						Config:             loadFixtureString("testdata/TestResCPCode/change_name_step0.tf"),
+						PlanOnly:           true,
+						ExpectNonEmptyPlan: true,
+					},
+				},
+			})
+		})
+	})
+
+	t.Run("change product", func(t *testing.T) {
+		client := &mockpapi{}
+		defer client.AssertExpectations(t)
+
+		// Contains CP Codes known to mock PAPI
+		CPCodes := []papi.CPCode{}
+
+		expectGetCPCode(client, "ctr_ctr1", "grp_grp1", &CPCodes)
+		expectCreateCPCode(client, "test cpcode", "prd_prd1", "ctr_ctr1", "grp_grp1", &CPCodes).Once()
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestResCPCode/change_product_step0.tf"),
+						Check:  resource.TestCheckResourceAttr("akamai_cp_code.test", "product_id", "prd_prd1"),
+					},
+					{
+						Config:      loadFixtureString("testdata/TestResCPCode/change_product_step1.tf"),
+						ExpectError: regexp.MustCompile("contract, group and product of existing CP code cannot be changed"),
 					},
 				},
 			})
@@ -295,6 +498,40 @@ func TestResCPCode(t *testing.T) {
 		client.AssertExpectations(t)
 	})
 
+	t.Run("import existing cp code by name", func(t *testing.T) {
+		client := &mockpapi{}
+		id := "test, cpcode,ctr_1,grp_2"
+
+		cpCodes := []papi.CPCode{
+			{ID: "cpc_122", Name: "test", ProductIDs: []string{"prd_Web_Accel"}},
+			{ID: "cpc_123", Name: "test, cpcode", ProductIDs: []string{"prd_Web_Accel"}},
+		}
+		expectGetCPCode(client, "ctr_1", "grp_2", &cpCodes)
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config:        loadFixtureString("testdata/TestResCPCode/import_cp_code.tf"),
+						ImportState:   true,
+						ImportStateId: id,
+						ResourceName:  "akamai_cp_code.test",
+						ImportStateCheck: func(s []*terraform.InstanceState) error {
+							assert.Len(t, s, 1)
+							rs := s[0]
+							assert.Equal(t, "cpc_123", rs.Attributes["id"])
+							assert.Equal(t, "test, cpcode", rs.Attributes["name"])
+							assert.Equal(t, "ctr_1", rs.Attributes["contract_id"])
+							assert.Equal(t, "grp_2", rs.Attributes["group_id"])
+							return nil
+						},
+					},
+				},
+			})
+		})
+		client.AssertExpectations(t)
+	})
+
 	t.Run("invalid import ID passed", func(t *testing.T) {
 		client := &mockpapi{}
 		id := "123"
@@ -308,7 +545,7 @@ func TestResCPCode(t *testing.T) {
 						ImportState:   true,
 						ImportStateId: id,
 						ResourceName:  "akamai_cp_code.test",
-						ExpectError:   regexp.MustCompile("comma-separated list of CP code ID, contract ID and group ID has to be supplied in import"),
+						ExpectError:   regexp.MustCompile("comma-separated list of CP code ID or name, contract ID and group ID has to be supplied in import"),
 					},
 				},
 			})
@@ -5,13 +5,17 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-cty/cty"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/hapi"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
 	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
 	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
@@ -21,14 +25,30 @@ func resourceSecureEdgeHostName() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceSecureEdgeHostNameCreate,
 		ReadContext:   resourceSecureEdgeHostNameRead,
+		UpdateContext: resourceSecureEdgeHostNameUpdate,
 		DeleteContext: resourceSecureEdgeHostNameDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceSecureEdgeHostNameImport,
 		},
 		Schema: akamaiSecureEdgeHostNameSchema,
+		Timeouts: &schema.ResourceTimeout{
+			Default: &PropertyResourceTimeout,
+		},
 	}
 }
 
+var (
+	// EdgeHostnamePollInterval is the interval for polling the status of an edge hostname change request
+	EdgeHostnamePollInterval = 30 * time.Second
+
+	// ipBehaviorPAPIToHAPI maps the PAPI IP version behaviors to the values used by the Edge Hostnames API
+	ipBehaviorPAPIToHAPI = map[string]string{
+		papi.EHIPVersionV4:            "IPV4",
+		papi.EHIPVersionV6Compliance:  "IPV6_IPV4_DUALSTACK",
+		papi.EHIPVersionV6Performance: "IPV6_PERFORMANCE",
+	}
+)
+
 var akamaiSecureEdgeHostNameSchema = map[string]*schema.Schema{
 	"product": {
 		Type:       schema.TypeString,
@@ -83,7 +103,9 @@ var akamaiSecureEdgeHostNameSchema = map[string]*schema.Schema{
 	"ip_behavior": {
 		Type:     schema.TypeString,
 		Required: true,
-		ForceNew: true,
+		DiffSuppressFunc: func(_, old, new string, _ *schema.ResourceData) bool {
+			return strings.EqualFold(old, new)
+		},
 		ValidateDiagFunc: func(val interface{}, path cty.Path) diag.Diagnostics {
 			v := val.(string)
 			key := path[len(path)-1].(cty.GetAttrStep).Name
@@ -106,6 +128,24 @@ var akamaiSecureEdgeHostNameSchema = map[string]*schema.Schema{
 		DiffSuppressFunc: suppressEdgeHostnameUseCases,
 		Description:      "A JSON encoded list of use cases",
 	},
+	"ttl": {
+		Type:             schema.TypeInt,
+		Optional:         true,
+		ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(60, 86400)),
+		Description:      "The time to live of the edge hostname DNS record in seconds, the default TTL is used when not set",
+	},
+	"status_update_email": {
+		Type:        schema.TypeList,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "Email addresses notified when an update or deletion of the edge hostname completes",
+	},
+	"delete_on_destroy": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Whether the edge hostname is deleted when the resource is destroyed, it is only removed from the state by default",
+	},
 }
 
 func resourceSecureEdgeHostNameCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -240,14 +280,90 @@ func resourceSecureEdgeHostNameCreate(ctx context.Context, d *schema.ResourceDat
 		d.SetId(ehnID)
 	}
 	logger.Debugf("Resulting EHN Id: %s ", ehnID)
+
+	// PAPI creates edge hostnames with the default TTL, a custom one can only be set through the Edge Hostnames API
+	if ttl, ok := d.GetOk("ttl"); ok {
+		dnsZone, recordName := splitEdgeHostname(edgeHostname)
+		details, err := inst.HAPIClient(meta).GetEdgeHostname(ctx, GetEdgeHostnameRequest{
+			DNSZone:    dnsZone,
+			RecordName: recordName,
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if details.UseDefaultTTL || details.TTL != ttl.(int) {
+			if err := updateEdgeHostname(ctx, d, meta, []EdgeHostnamePatch{{Op: "replace", Path: "/ttl", Value: ttl.(int)}}); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
 	return resourceSecureEdgeHostNameRead(ctx, d, meta)
 }
 
-func resourceSecureEdgeHostNameDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func resourceSecureEdgeHostNameUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourceSecureEdgeHostNameUpdate")
+	logger.Debug("Updating edge hostname")
+
+	if d.HasChanges("contract_id", "contract", "group_id", "group", "product_id", "product") {
+		diags := tools.RestoreOldValues(d, []string{"contract_id", "contract", "group_id", "group", "product_id", "product"})
+		return append(diags, diag.FromErr(ErrEdgeHostnameUpdateNotAllowed)...)
+	}
+
+	var patches []EdgeHostnamePatch
+	if d.HasChange("ip_behavior") {
+		ipBehavior := strings.ToUpper(d.Get("ip_behavior").(string))
+		patches = append(patches, EdgeHostnamePatch{Op: "replace", Path: "/ipVersionBehavior", Value: ipBehaviorPAPIToHAPI[ipBehavior]})
+	}
+	if d.HasChange("ttl") {
+		if ttl, ok := d.GetOk("ttl"); ok {
+			patches = append(patches, EdgeHostnamePatch{Op: "replace", Path: "/ttl", Value: ttl.(int)})
+		} else {
+			patches = append(patches, EdgeHostnamePatch{Op: "replace", Path: "/useDefaultTtl", Value: true})
+		}
+	}
+	if len(patches) > 0 {
+		if err := updateEdgeHostname(ctx, d, meta, patches); err != nil {
+			diags := tools.RestoreOldValues(d, []string{"ip_behavior", "ttl"})
+			return append(diags, diag.FromErr(err)...)
+		}
+	}
+
+	return resourceSecureEdgeHostNameRead(ctx, d, meta)
+}
+
+func resourceSecureEdgeHostNameDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	meta := akamai.Meta(m)
 	logger := meta.Log("PAPI", "resourceSecureEdgeHostNameDelete")
 	logger.Debug("DELETING")
-	logger.Info("PAPI does not support edge hostname deletion - resource will only be removed from state")
+
+	if !d.Get("delete_on_destroy").(bool) {
+		logger.Infof("edge hostname %s is not deleted, removing it from state", d.Get("edge_hostname").(string))
+		d.SetId("")
+		return nil
+	}
+
+	client := inst.HAPIClient(meta)
+	dnsZone, recordName := splitEdgeHostname(d.Get("edge_hostname").(string))
+	change, err := client.DeleteEdgeHostname(ctx, hapi.DeleteEdgeHostnameRequest{
+		DNSZone:           dnsZone,
+		RecordName:        recordName,
+		StatusUpdateEmail: statusUpdateEmails(d),
+		Comments:          "deleted by terraform",
+	})
+	if err != nil {
+		var apiErr *hapi.Error
+		if errors.As(err, &apiErr) && apiErr.Status == http.StatusNotFound {
+			logger.Infof("edge hostname %s.%s does not exist, removing it from state", recordName, dnsZone)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	if err := waitForEdgeHostnameChange(ctx, client, change.ChangeID, change.Status); err != nil {
+		return diag.FromErr(err)
+	}
 	d.SetId("")
 	logger.Debugf("DONE")
 	return nil
@@ -304,6 +420,14 @@ func resourceSecureEdgeHostNameImport(ctx context.Context, d *schema.ResourceDat
 	if err := d.Set("edge_hostname", edgehostnameDetails.EdgeHostname.Domain); err != nil {
 		return nil, fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error())
 	}
+	if ipBehavior := edgehostnameDetails.EdgeHostname.IPVersionBehavior; ipBehavior != "" {
+		if err := d.Set("ip_behavior", ipBehavior); err != nil {
+			return nil, fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error())
+		}
+	}
+	if err := d.Set("delete_on_destroy", false); err != nil {
+		return nil, fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error())
+	}
 	d.SetId(edgehostID)
 
 	return []*schema.ResourceData{d}, nil
@@ -395,11 +519,97 @@ func resourceSecureEdgeHostNameRead(ctx context.Context, d *schema.ResourceData,
 	if err := d.Set("edge_hostname", foundEdgeHostname.Domain); err != nil {
 		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
 	}
+	if foundEdgeHostname.IPVersionBehavior != "" {
+		if err := d.Set("ip_behavior", foundEdgeHostname.IPVersionBehavior); err != nil {
+			return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+		}
+	}
 	d.SetId(foundEdgeHostname.ID)
 
+	// TTL is only exposed by the Edge Hostnames API, which is not called unless TTL is managed
+	if _, ok := d.GetOk("ttl"); ok {
+		dnsZone, recordName := splitEdgeHostname(foundEdgeHostname.Domain)
+		details, err := inst.HAPIClient(meta).GetEdgeHostname(ctx, GetEdgeHostnameRequest{
+			DNSZone:    dnsZone,
+			RecordName: recordName,
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("ttl", details.TTL); err != nil {
+			return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+		}
+	}
+
 	return nil
 }
 
+// updateEdgeHostname applies the given patch operations to an edge hostname and waits for the change to complete
+func updateEdgeHostname(ctx context.Context, d *schema.ResourceData, meta akamai.OperationMeta, patches []EdgeHostnamePatch) error {
+	logger := meta.Log("PAPI", "updateEdgeHostname")
+	client := inst.HAPIClient(meta)
+
+	dnsZone, recordName := splitEdgeHostname(d.Get("edge_hostname").(string))
+	logger.Debugf("Patching edge hostname %s.%s: %#v", recordName, dnsZone, patches)
+	change, err := client.UpdateEdgeHostname(ctx, UpdateEdgeHostnameRequest{
+		DNSZone:           dnsZone,
+		RecordName:        recordName,
+		StatusUpdateEmail: statusUpdateEmails(d),
+		Comments:          "updated by terraform",
+		Body:              patches,
+	})
+	if err != nil {
+		return err
+	}
+
+	return waitForEdgeHostnameChange(ctx, client, change.ChangeID, change.Status)
+}
+
+// waitForEdgeHostnameChange polls the given change request until it reaches a terminal status, and fails with the
+// status unless the change succeeded
+func waitForEdgeHostnameChange(ctx context.Context, client HAPI, changeID int, status string) error {
+	var message string
+	for {
+		switch status {
+		case EdgeHostnameChangeStatusSucceeded:
+			return nil
+		case EdgeHostnameChangeStatusFailed, EdgeHostnameChangeStatusRejected, EdgeHostnameChangeStatusCancelled:
+			return fmt.Errorf("%w: change request %d %s: %s", ErrEdgeHostnameChangeFailed, changeID, status, message)
+		}
+
+		select {
+		case <-time.After(EdgeHostnamePollInterval):
+			change, err := client.GetChangeRequest(ctx, GetChangeRequestRequest{ChangeID: changeID})
+			if err != nil {
+				return err
+			}
+			status, message = change.Status, change.StatusMessage
+		case <-ctx.Done():
+			return fmt.Errorf("timeout waiting for edge hostname change request %d: %w", changeID, ctx.Err())
+		}
+	}
+}
+
+// splitEdgeHostname returns the DNS zone (domain suffix) and the record name (domain prefix) of an edge hostname
+func splitEdgeHostname(domain string) (string, string) {
+	suffix := "edgesuite.net"
+	if strings.HasSuffix(domain, "edgekey.net") {
+		suffix = "edgekey.net"
+	}
+	if strings.HasSuffix(domain, "akamaized.net") {
+		suffix = "akamaized.net"
+	}
+	return suffix, strings.TrimSuffix(domain, "."+suffix)
+}
+
+func statusUpdateEmails(d *schema.ResourceData) []string {
+	var emails []string
+	for _, email := range d.Get("status_update_email").([]interface{}) {
+		emails = append(emails, email.(string))
+	}
+	return emails
+}
+
 func suppressEdgeHostnameDomain(_, old, new string, _ *schema.ResourceData) bool {
 	if old == new {
 		return true
@@ -455,15 +665,8 @@ func appendDefaultSuffixToEdgeHostname(i interface{}) string {
 }
 
 func findEdgeHostname(edgeHostnames papi.EdgeHostnameItems, domain string) (*papi.EdgeHostnameGetItem, error) {
-	suffix := "edgesuite.net"
 	if domain != "" {
-		if strings.HasSuffix(domain, "edgekey.net") {
-			suffix = "edgekey.net"
-		}
-		if strings.HasSuffix(domain, "akamaized.net") {
-			suffix = "akamaized.net"
-		}
-		prefix := strings.TrimSuffix(domain, "."+suffix)
+		suffix, prefix := splitEdgeHostname(domain)
 
 		for _, eHn := range edgeHostnames.Items {
 			if eHn.DomainPrefix == prefix && eHn.DomainSuffix == suffix {
@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -14,6 +16,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/hapi"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
 )
 
@@ -25,6 +28,8 @@ func TestResourceEdgeHostname(t *testing.T) {
 		expectedAttributes map[string]string
 		expectedOutputs    map[string]string
 		withError          *regexp.Regexp
+		// tainted is set when edge hostname is created despite the error, so it is deleted on destroy
+		tainted bool
 	}{
 		"edge hostname with .edgesuite.net, create edge hostname": {
 			givenTF: "new_edgesuite_net.tf",
@@ -507,12 +512,14 @@ func TestResourceEdgeHostname(t *testing.T) {
 				}, nil)
 			},
 			withError: regexp.MustCompile("unable to find edge hostname"),
+			tainted:   true,
 		},
 	}
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			client := &mockpapi{}
+			hapiClient := &mockhapi{}
 			test.init(client)
 			var checkFuncs []resource.TestCheckFunc
 			for k, v := range test.expectedAttributes {
@@ -522,20 +529,265 @@ func TestResourceEdgeHostname(t *testing.T) {
 				checkFuncs = append(checkFuncs, resource.TestCheckOutput(k, v))
 			}
 			useClient(client, func() {
+				useHAPIClient(hapiClient, func() {
+					resource.UnitTest(t, resource.TestCase{
+						Providers: testAccProviders,
+						Steps: []resource.TestStep{
+							{
+								Config:      loadFixtureString(fmt.Sprintf("%s/%s", testDir, test.givenTF)),
+								Check:       resource.ComposeAggregateTestCheckFunc(checkFuncs...),
+								ExpectError: test.withError,
+							},
+						},
+					})
+				})
+			})
+			client.AssertExpectations(t)
+			hapiClient.AssertExpectations(t)
+		})
+	}
+}
+
+// expectDeleteEdgeHostname mocks a deletion of edge hostname which completes on the first status check
+func expectDeleteEdgeHostname(m *mockhapi, request interface{}) {
+	m.On("DeleteEdgeHostname", mock.Anything, request).
+		Return(&hapi.DeleteEdgeHostnameResponse{Action: "DELETE", ChangeID: 66, Status: EdgeHostnameChangeStatusPending}, nil).Once()
+	m.On("GetChangeRequest", mock.Anything, GetChangeRequestRequest{ChangeID: 66}).
+		Return(&EdgeHostnameChange{Action: "DELETE", ChangeID: 66, Status: EdgeHostnameChangeStatusSucceeded}, nil).Once()
+}
+
+func TestResourceEdgeHostname_Lifecycle(t *testing.T) {
+	EdgeHostnamePollInterval = time.Millisecond
+	testDir := "testdata/TestResourceEdgeHostname/lifecycle"
+
+	// expectGetEdgeHostnames returns the given edge hostname from the PAPI list, on every call until the returned call is limited
+	expectGetEdgeHostnames := func(m *mockpapi, ipBehavior string) *mock.Call {
+		return m.On("GetEdgeHostnames", mock.Anything, papi.GetEdgeHostnamesRequest{
+			ContractID: "ctr_2",
+			GroupID:    "grp_2",
+		}).Return(&papi.GetEdgeHostnamesResponse{
+			ContractID: "ctr_2",
+			GroupID:    "grp_2",
+			EdgeHostnames: papi.EdgeHostnameItems{Items: []papi.EdgeHostnameGetItem{{
+				ID:                "eh_123",
+				Domain:            "test.edgesuite.net",
+				ProductID:         "prd_2",
+				DomainPrefix:      "test",
+				DomainSuffix:      "edgesuite.net",
+				IPVersionBehavior: ipBehavior,
+			}}},
+		}, nil)
+	}
+	deleteRequest := hapi.DeleteEdgeHostnameRequest{
+		DNSZone:           "edgesuite.net",
+		RecordName:        "test",
+		StatusUpdateEmail: []string{"admin@example.com"},
+		Comments:          "deleted by terraform",
+	}
+
+	t.Run("update IP behavior and TTL in place", func(t *testing.T) {
+		client := &mockpapi{}
+		hapiClient := &mockhapi{}
+		detail := &EdgeHostnameDetail{EdgeHostnameID: 123, RecordName: "test", DNSZone: "edgesuite.net", TTL: 300}
+		expectGetEdgeHostnames(client, "IPV4").Times(4)
+		expectGetEdgeHostnames(client, "IPV6_COMPLIANCE")
+		hapiClient.On("UpdateEdgeHostname", mock.Anything, UpdateEdgeHostnameRequest{
+			DNSZone:           "edgesuite.net",
+			RecordName:        "test",
+			StatusUpdateEmail: []string{"admin@example.com"},
+			Comments:          "updated by terraform",
+			Body: []EdgeHostnamePatch{
+				{Op: "replace", Path: "/ipVersionBehavior", Value: "IPV6_IPV4_DUALSTACK"},
+				{Op: "replace", Path: "/ttl", Value: 600},
+			},
+		}).Return(&EdgeHostnameChange{Action: "EDIT", ChangeID: 65, Status: EdgeHostnameChangeStatusPending}, nil).Once().
+			Run(func(mock.Arguments) { detail.TTL = 600 })
+		hapiClient.On("GetChangeRequest", mock.Anything, GetChangeRequestRequest{ChangeID: 65}).
+			Return(&EdgeHostnameChange{Action: "EDIT", ChangeID: 65, Status: EdgeHostnameChangeStatusPending}, nil).Once()
+		hapiClient.On("GetChangeRequest", mock.Anything, GetChangeRequestRequest{ChangeID: 65}).
+			Return(&EdgeHostnameChange{Action: "EDIT", ChangeID: 65, Status: EdgeHostnameChangeStatusSucceeded}, nil).Once()
+		hapiClient.On("GetEdgeHostname", mock.Anything, GetEdgeHostnameRequest{DNSZone: "edgesuite.net", RecordName: "test"}).
+			Return(detail, nil)
+		expectDeleteEdgeHostname(hapiClient, deleteRequest)
+
+		useClient(client, func() {
+			useHAPIClient(hapiClient, func() {
 				resource.UnitTest(t, resource.TestCase{
 					Providers: testAccProviders,
 					Steps: []resource.TestStep{
 						{
-							Config:      loadFixtureString(fmt.Sprintf("%s/%s", testDir, test.givenTF)),
-							Check:       resource.ComposeAggregateTestCheckFunc(checkFuncs...),
-							ExpectError: test.withError,
+							Config: loadFixtureString(fmt.Sprintf("%s/step0.tf", testDir)),
+							Check: resource.ComposeAggregateTestCheckFunc(
+								resource.TestCheckResourceAttr("akamai_edge_hostname.edgehostname", "id", "eh_123"),
+								resource.TestCheckResourceAttr("akamai_edge_hostname.edgehostname", "ip_behavior", "IPV4"),
+								resource.TestCheckResourceAttr("akamai_edge_hostname.edgehostname", "ttl", "300"),
+							),
+						},
+						{
+							Config: loadFixtureString(fmt.Sprintf("%s/step1.tf", testDir)),
+							Check: resource.ComposeAggregateTestCheckFunc(
+								resource.TestCheckResourceAttr("akamai_edge_hostname.edgehostname", "id", "eh_123"),
+								resource.TestCheckResourceAttr("akamai_edge_hostname.edgehostname", "ip_behavior", "IPV6_COMPLIANCE"),
+								resource.TestCheckResourceAttr("akamai_edge_hostname.edgehostname", "ttl", "600"),
+							),
 						},
 					},
 				})
 			})
-			client.AssertExpectations(t)
 		})
-	}
+		client.AssertExpectations(t)
+		hapiClient.AssertExpectations(t)
+	})
+
+	t.Run("failed update is reported", func(t *testing.T) {
+		client := &mockpapi{}
+		hapiClient := &mockhapi{}
+		expectGetEdgeHostnames(client, "IPV4")
+		hapiClient.On("GetEdgeHostname", mock.Anything, GetEdgeHostnameRequest{DNSZone: "edgesuite.net", RecordName: "test"}).
+			Return(&EdgeHostnameDetail{EdgeHostnameID: 123, RecordName: "test", DNSZone: "edgesuite.net", TTL: 300}, nil)
+		hapiClient.On("UpdateEdgeHostname", mock.Anything, mock.Anything).
+			Return(&EdgeHostnameChange{Action: "EDIT", ChangeID: 65, Status: EdgeHostnameChangeStatusPending}, nil).Once()
+		hapiClient.On("GetChangeRequest", mock.Anything, GetChangeRequestRequest{ChangeID: 65}).
+			Return(&EdgeHostnameChange{Action: "EDIT", ChangeID: 65, Status: EdgeHostnameChangeStatusFailed, StatusMessage: "invalid TTL"}, nil).Once()
+		expectDeleteEdgeHostname(hapiClient, deleteRequest)
+
+		useClient(client, func() {
+			useHAPIClient(hapiClient, func() {
+				resource.UnitTest(t, resource.TestCase{
+					Providers: testAccProviders,
+					Steps: []resource.TestStep{
+						{
+							Config: loadFixtureString(fmt.Sprintf("%s/step0.tf", testDir)),
+						},
+						{
+							Config:      loadFixtureString(fmt.Sprintf("%s/step1.tf", testDir)),
+							ExpectError: regexp.MustCompile("edge hostname change failed: change request 65 FAILED: invalid TTL"),
+						},
+					},
+				})
+			})
+		})
+		client.AssertExpectations(t)
+		hapiClient.AssertExpectations(t)
+	})
+
+	t.Run("rejected update is reported", func(t *testing.T) {
+		client := &mockpapi{}
+		hapiClient := &mockhapi{}
+		expectGetEdgeHostnames(client, "IPV4")
+		hapiClient.On("GetEdgeHostname", mock.Anything, GetEdgeHostnameRequest{DNSZone: "edgesuite.net", RecordName: "test"}).
+			Return(&EdgeHostnameDetail{EdgeHostnameID: 123, RecordName: "test", DNSZone: "edgesuite.net", TTL: 300}, nil)
+		hapiClient.On("UpdateEdgeHostname", mock.Anything, mock.Anything).
+			Return(&EdgeHostnameChange{Action: "EDIT", ChangeID: 65, Status: EdgeHostnameChangeStatusPending}, nil).Once()
+		hapiClient.On("GetChangeRequest", mock.Anything, GetChangeRequestRequest{ChangeID: 65}).
+			Return(&EdgeHostnameChange{Action: "EDIT", ChangeID: 65, Status: EdgeHostnameChangeStatusRejected, StatusMessage: "not authorized"}, nil).Once()
+		expectDeleteEdgeHostname(hapiClient, deleteRequest)
+
+		useClient(client, func() {
+			useHAPIClient(hapiClient, func() {
+				resource.UnitTest(t, resource.TestCase{
+					Providers: testAccProviders,
+					Steps: []resource.TestStep{
+						{
+							Config: loadFixtureString(fmt.Sprintf("%s/step0.tf", testDir)),
+						},
+						{
+							Config:      loadFixtureString(fmt.Sprintf("%s/step1.tf", testDir)),
+							ExpectError: regexp.MustCompile("edge hostname change failed: change request 65 REJECTED: not authorized"),
+						},
+					},
+				})
+			})
+		})
+		client.AssertExpectations(t)
+		hapiClient.AssertExpectations(t)
+	})
+
+	t.Run("change of product is rejected", func(t *testing.T) {
+		client := &mockpapi{}
+		hapiClient := &mockhapi{}
+		expectGetEdgeHostnames(client, "IPV4")
+		hapiClient.On("GetEdgeHostname", mock.Anything, GetEdgeHostnameRequest{DNSZone: "edgesuite.net", RecordName: "test"}).
+			Return(&EdgeHostnameDetail{EdgeHostnameID: 123, RecordName: "test", DNSZone: "edgesuite.net", TTL: 300}, nil)
+		expectDeleteEdgeHostname(hapiClient, deleteRequest)
+
+		useClient(client, func() {
+			useHAPIClient(hapiClient, func() {
+				resource.UnitTest(t, resource.TestCase{
+					Providers: testAccProviders,
+					Steps: []resource.TestStep{
+						{
+							Config: loadFixtureString(fmt.Sprintf("%s/step0.tf", testDir)),
+						},
+						{
+							Config:      loadFixtureString(fmt.Sprintf("%s/change_product.tf", testDir)),
+							ExpectError: regexp.MustCompile("contract, group and product of existing edge hostname cannot be changed"),
+						},
+					},
+				})
+			})
+		})
+		client.AssertExpectations(t)
+		hapiClient.AssertExpectations(t)
+	})
+
+	t.Run("edge hostname already deleted", func(t *testing.T) {
+		client := &mockpapi{}
+		hapiClient := &mockhapi{}
+		expectGetEdgeHostnames(client, "IPV4")
+		hapiClient.On("GetEdgeHostname", mock.Anything, GetEdgeHostnameRequest{DNSZone: "edgesuite.net", RecordName: "test"}).
+			Return(&EdgeHostnameDetail{EdgeHostnameID: 123, RecordName: "test", DNSZone: "edgesuite.net", TTL: 300}, nil)
+		hapiClient.On("DeleteEdgeHostname", mock.Anything, deleteRequest).
+			Return(nil, fmt.Errorf("%s: %w", hapi.ErrDeleteEdgeHostname, &hapi.Error{Status: http.StatusNotFound})).Once()
+
+		useClient(client, func() {
+			useHAPIClient(hapiClient, func() {
+				resource.UnitTest(t, resource.TestCase{
+					Providers: testAccProviders,
+					Steps: []resource.TestStep{
+						{
+							Config: loadFixtureString(fmt.Sprintf("%s/step0.tf", testDir)),
+						},
+					},
+				})
+			})
+		})
+		client.AssertExpectations(t)
+		hapiClient.AssertExpectations(t)
+	})
+
+	t.Run("deletion fails", func(t *testing.T) {
+		client := &mockpapi{}
+		hapiClient := &mockhapi{}
+		expectGetEdgeHostnames(client, "IPV4")
+		hapiClient.On("GetEdgeHostname", mock.Anything, GetEdgeHostnameRequest{DNSZone: "edgesuite.net", RecordName: "test"}).
+			Return(&EdgeHostnameDetail{EdgeHostnameID: 123, RecordName: "test", DNSZone: "edgesuite.net", TTL: 300}, nil)
+		hapiClient.On("DeleteEdgeHostname", mock.Anything, deleteRequest).
+			Return(&hapi.DeleteEdgeHostnameResponse{Action: "DELETE", ChangeID: 66, Status: EdgeHostnameChangeStatusPending}, nil).Once()
+		hapiClient.On("GetChangeRequest", mock.Anything, GetChangeRequestRequest{ChangeID: 66}).
+			Return(&EdgeHostnameChange{Action: "DELETE", ChangeID: 66, Status: EdgeHostnameChangeStatusFailed, StatusMessage: "hostname in use"}, nil).Once()
+		expectDeleteEdgeHostname(hapiClient, deleteRequest)
+
+		useClient(client, func() {
+			useHAPIClient(hapiClient, func() {
+				resource.UnitTest(t, resource.TestCase{
+					Providers: testAccProviders,
+					Steps: []resource.TestStep{
+						{
+							Config: loadFixtureString(fmt.Sprintf("%s/step0.tf", testDir)),
+						},
+						{
+							Config:      loadFixtureString(fmt.Sprintf("%s/step0.tf", testDir)),
+							Destroy:     true,
+							ExpectError: regexp.MustCompile("change request 66 FAILED: hostname in use"),
+						},
+					},
+				})
+			})
+		})
+		client.AssertExpectations(t)
+		hapiClient.AssertExpectations(t)
+	})
 }
 
 func TestResourceEdgeHostnames_WithImport(t *testing.T) {
@@ -610,33 +862,36 @@ func TestResourceEdgeHostnames_WithImport(t *testing.T) {
 
 		expectGetEdgeHostname(client, "eh_1", "ctr_1", "grp_2")
 		expectGetEdgeHostnames(client, "ctr_1", "grp_2")
+		hapiClient := &mockhapi{}
 		useClient(client, func() {
-			resource.UnitTest(t, resource.TestCase{
-				Providers: testAccProviders,
-				Steps: []resource.TestStep{
-					{
-						Config: loadFixtureString("testdata/TestResourceEdgeHostname/import_edgehostname.tf"),
-					},
-					{
-						Config:      loadFixtureString("testdata/TestResourceEdgeHostname/import_edgehostname.tf"),
-						ImportState: true,
-						ImportStateCheck: func(s []*terraform.InstanceState) error {
-							assert.Len(t, s, 1)
-							rs := s[0]
-							assert.Equal(t, "grp_2", rs.Attributes["group_id"])
-							assert.Equal(t, "ctr_1", rs.Attributes["contract_id"])
-							assert.Equal(t, "eh_1", rs.Attributes["id"])
-							return nil
-						},
-						ImportStateId:           id,
-						ResourceName:            "akamai_edge_hostname.importedgehostname",
-						ImportStateVerify:       true,
-						ImportStateVerifyIgnore: []string{"ip_behavior"},
+			useHAPIClient(hapiClient, func() {
+				resource.UnitTest(t, resource.TestCase{
+					Providers: testAccProviders,
+					Steps: []resource.TestStep{
+						{
+							Config: loadFixtureString("testdata/TestResourceEdgeHostname/import_edgehostname.tf"),
+						},
+						{
+							Config:      loadFixtureString("testdata/TestResourceEdgeHostname/import_edgehostname.tf"),
+							ImportState: true,
+							ImportStateCheck: func(s []*terraform.InstanceState) error {
+								assert.Len(t, s, 1)
+								rs := s[0]
+								assert.Equal(t, "grp_2", rs.Attributes["group_id"])
+								assert.Equal(t, "ctr_1", rs.Attributes["contract_id"])
+								assert.Equal(t, "eh_1", rs.Attributes["id"])
+								return nil
+							},
+							ImportStateId:     id,
+							ResourceName:      "akamai_edge_hostname.importedgehostname",
+							ImportStateVerify: true,
+						},
 					},
-				},
+				})
 			})
 		})
 		client.AssertExpectations(t)
+		hapiClient.AssertExpectations(t)
 	})
 }
 
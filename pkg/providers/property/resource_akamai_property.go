@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
@@ -167,23 +169,11 @@ func resourceProperty() *schema.Resource {
 
 			// Optional
 			"rule_format": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Computed:    true,
-				Description: "Specify the rule format version (defaults to latest version available when created)",
-				ValidateDiagFunc: func(v interface{}, _ cty.Path) diag.Diagnostics {
-					format := v.(string)
-					if format == "" || format == "latest" {
-						return nil
-					}
-
-					if !regexp.MustCompile(`^v[0-9]{4}-[0-9]{2}-[0-9]{2}$`).MatchString(format) {
-						url := "https://developer.akamai.com/api/core_features/property_manager/vlatest.html#behaviors"
-						return diag.Errorf(`"rule_format" must be of the form vYYYY-MM-DD (with a leading "v") see %s`, url)
-					}
-
-					return nil
-				},
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "Specify the rule format version (defaults to latest version available when created)",
+				ValidateDiagFunc: validateRuleFormat,
 			},
 			"rules": {
 				Type:             schema.TypeString,
@@ -200,6 +190,46 @@ func resourceProperty() *schema.Resource {
 					return v.(string)
 				},
 			},
+			"version_notes": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Notes written to each property version created or updated, instead of the comments in the rules",
+			},
+			"auto_version_notes": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Append a summary of the rule and hostname changes to the notes of each updated property version",
+			},
+			"clone_from": {
+				Type:             schema.TypeList,
+				Optional:         true,
+				MaxItems:         1,
+				Description:      "Property version to copy when the property is created, ignored afterwards",
+				DiffSuppressFunc: suppressCloneFromAfterCreate,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"property_id": {
+							Type:             schema.TypeString,
+							Required:         true,
+							Description:      "Property to clone",
+							DiffSuppressFunc: suppressCloneFromAfterCreate,
+						},
+						"version": {
+							Type:             schema.TypeInt,
+							Optional:         true,
+							Description:      "Version of the property to clone, defaults to its latest version",
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+							DiffSuppressFunc: suppressCloneFromAfterCreate,
+						},
+						"copy_hostnames": {
+							Type:             schema.TypeBool,
+							Optional:         true,
+							Description:      "Copy the hostnames of the cloned version, hostnames declared in the configuration replace them",
+							DiffSuppressFunc: suppressCloneFromAfterCreate,
+						},
+					},
+				},
+			},
 			"hostnames": {
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -359,7 +389,7 @@ func rulesCustomDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}
 	var oldRulesUpdate, newRulesUpdate papi.RulesUpdate
 
 	if diff.Id() == "" && newValue != "" {
-		rules, err := unifyRulesDiff(newValue)
+		rules, err := unifyRulesDiff(newValue, versionNotesManaged(diff.Get))
 		if err != nil {
 			return err
 		}
@@ -387,6 +417,9 @@ func rulesCustomDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}
 	if err != nil {
 		return fmt.Errorf("cannot encode rules JSON %s", err)
 	}
+	if versionNotesManaged(diff.Get) {
+		newRulesUpdate.Comments = ""
+	}
 	rulesBytes, err := json.Marshal(newRulesUpdate)
 	if err != nil {
 		return err
@@ -402,12 +435,16 @@ func rulesCustomDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}
 // unifyRulesDiff is invoked on first planning for property creation
 // Its main purpose is to unify the rules JSON with what we expect will be created by PAPI
 // It is used in order to prevent diffs on output on subsequent terraform applies
-func unifyRulesDiff(newValue string) (string, error) {
+// Comments are dropped when the version notes are managed by version_notes or auto_version_notes
+func unifyRulesDiff(newValue string, dropComments bool) (string, error) {
 	var newRulesUpdate papi.RulesUpdate
 	err := json.Unmarshal([]byte(newValue), &newRulesUpdate)
 	if err != nil {
 		return "", fmt.Errorf("cannot parse rules JSON from config: %s", err)
 	}
+	if dropComments {
+		newRulesUpdate.Comments = ""
+	}
 	rulesBytes, err := json.Marshal(newRulesUpdate)
 	if err != nil {
 		return "", err
@@ -512,7 +549,12 @@ func resourcePropertyCreate(ctx context.Context, d *schema.ResourceData, m inter
 
 	RulesJSON := []byte(d.Get("rules").(string))
 
-	PropertyID, err := createProperty(ctx, client, PropertyName, GroupID, ContractID, ProductID, RuleFormat)
+	CloneFrom, err := cloneFrom(ctx, client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	PropertyID, err := createProperty(ctx, client, PropertyName, GroupID, ContractID, ProductID, RuleFormat, CloneFrom)
 	if err != nil {
 		if strings.Contains(err.Error(), "\"statusCode\": 404") {
 			// find out what is missing from the request
@@ -577,6 +619,9 @@ func resourcePropertyCreate(ctx context.Context, d *schema.ResourceData, m inter
 			logger.WithError(err).Error("failed to unmarshal property rules")
 			return diag.Errorf("rules are not valid JSON: %s", err)
 		}
+		if versionNotesManaged(d.Get) {
+			Rules.Comments = d.Get("version_notes").(string)
+		}
 
 		ctx := ctx
 		if RuleFormat != "" {
@@ -662,6 +707,10 @@ func resourcePropertyRead(ctx context.Context, d *schema.ResourceData, m interfa
 		logger.Warnf("Property has rule warnings %s", msg)
 	}
 
+	if versionNotesManaged(d.Get) {
+		// the comments hold the version notes, which are not part of the configured rules
+		Rules.Comments = ""
+	}
 	RulesJSON, err := json.Marshal(Rules)
 	if err != nil {
 		logger.WithError(err).Error("could not render rules as JSON")
@@ -692,6 +741,10 @@ func resourcePropertyRead(ctx context.Context, d *schema.ResourceData, m interfa
 		attrs["product_id"] = Property.ProductID
 		attrs["product"] = Property.ProductID
 	}
+	if copiedHostnamesUnmanaged(d) {
+		// hostnames copied from the cloned version are left alone until hostnames are declared
+		delete(attrs, "hostnames")
+	}
 	if err := rdSetAttrs(ctx, d, attrs); err != nil {
 		return diag.FromErr(err)
 	}
@@ -815,13 +868,18 @@ func resourcePropertyUpdate(ctx context.Context, d *schema.ResourceData, m inter
 	RulesJSON := []byte(d.Get("rules").(string))
 	RulesNeedUpdate := len(RulesJSON) > 0 && d.HasChange("rules")
 	FormatNeedsUpdate := len(RuleFormat) > 0 && d.HasChange("rule_format")
+	// version notes are saved with the rules, so they are also written when only hostnames change
+	NotesNeedUpdate := len(RulesJSON) > 0 && versionNotesManaged(d.Get)
 
-	if FormatNeedsUpdate || RulesNeedUpdate {
+	if FormatNeedsUpdate || RulesNeedUpdate || NotesNeedUpdate {
 		var Rules papi.RulesUpdate
 		if err := json.Unmarshal(RulesJSON, &Rules); err != nil {
 			d.Partial(true)
 			return diag.Errorf("rules are not valid JSON: %s", err)
 		}
+		if NotesNeedUpdate {
+			Rules.Comments = versionNotes(d)
+		}
 
 		MIME := fmt.Sprintf("application/vnd.akamai.papirules.%s+json", RuleFormat)
 		h := http.Header{"Content-Type": []string{MIME}}
@@ -962,11 +1020,12 @@ func resPropForbiddenAttrs() []string {
 	}
 }
 
-func createProperty(ctx context.Context, client papi.PAPI, PropertyName, GroupID, ContractID, ProductID, RuleFormat string) (PropertyID string, err error) {
+func createProperty(ctx context.Context, client papi.PAPI, PropertyName, GroupID, ContractID, ProductID, RuleFormat string, CloneFrom *papi.PropertyCloneFrom) (PropertyID string, err error) {
 	req := papi.CreatePropertyRequest{
 		ContractID: ContractID,
 		GroupID:    GroupID,
 		Property: papi.PropertyCreate{
+			CloneFrom:    CloneFrom,
 			ProductID:    ProductID,
 			PropertyName: PropertyName,
 			RuleFormat:   RuleFormat,
@@ -1355,3 +1414,136 @@ func rdSetAttrs(ctx context.Context, d *schema.ResourceData, AttributeValues map
 
 	return nil
 }
+
+// validateRuleFormat checks that a rule format is either "latest" or a frozen vYYYY-MM-DD version
+func validateRuleFormat(v interface{}, path cty.Path) diag.Diagnostics {
+	format := v.(string)
+	if format == "" || format == "latest" {
+		return nil
+	}
+
+	if !regexp.MustCompile(`^v[0-9]{4}-[0-9]{2}-[0-9]{2}$`).MatchString(format) {
+		key := path[len(path)-1].(cty.GetAttrStep).Name
+		url := "https://developer.akamai.com/api/core_features/property_manager/vlatest.html#behaviors"
+		return diag.Errorf(`"%s" must be of the form vYYYY-MM-DD (with a leading "v") see %s`, key, url)
+	}
+
+	return nil
+}
+
+// versionNotesManaged tells whether the notes of property versions are set by version_notes or auto_version_notes
+// instead of the comments in the rules
+func versionNotesManaged(get func(string) interface{}) bool {
+	return get("version_notes").(string) != "" || get("auto_version_notes").(bool)
+}
+
+// versionNotes returns the notes of an updated property version, followed by a summary of the changes
+// when auto_version_notes is enabled
+func versionNotes(d *schema.ResourceData) string {
+	notes := d.Get("version_notes").(string)
+	if !d.Get("auto_version_notes").(bool) {
+		return notes
+	}
+
+	oldRules, newRules := d.GetChange("rules")
+	oldFormat, newFormat := d.GetChange("rule_format")
+	oldHostnames, newHostnames := d.GetChange("hostnames")
+	summary := summarizePropertyChanges(
+		oldRules.(string), newRules.(string),
+		oldFormat.(string), newFormat.(string),
+		oldHostnames.(*schema.Set), newHostnames.(*schema.Set),
+	)
+	if notes == "" {
+		return summary
+	}
+	return notes + "\n" + summary
+}
+
+// maxVersionNotesChanges limits the number of changes listed in generated version notes
+const maxVersionNotesChanges = 10
+
+// summarizePropertyChanges describes the behavior, criteria, rule, rule format and hostname changes between two versions
+func summarizePropertyChanges(oldRules, newRules, oldFormat, newFormat string, oldHostnames, newHostnames *schema.Set) string {
+	var changes []string
+	if oldFormat != "" && newFormat != "" && oldFormat != newFormat {
+		changes = append(changes, fmt.Sprintf("rule format changed from %s to %s", oldFormat, newFormat))
+	}
+
+	var oldTree, newTree papi.RulesUpdate
+	if json.Unmarshal([]byte(oldRules), &oldTree) == nil && json.Unmarshal([]byte(newRules), &newTree) == nil {
+		for _, c := range diffRuleTrees(newTree.Rules.Name, &oldTree.Rules, &newTree.Rules) {
+			change := fmt.Sprintf("%s %s %s in %s", c.kind, c.name, c.change, c.rulePath)
+			if len(c.options) > 0 {
+				change = fmt.Sprintf("%s %s %s (%s) in %s", c.kind, c.name, c.change, strings.Join(c.options, ", "), c.rulePath)
+			}
+			changes = append(changes, change)
+		}
+	}
+
+	added, removed := diffHostnames(oldHostnames, newHostnames)
+	if len(added) > 0 {
+		changes = append(changes, fmt.Sprintf("hostnames added: %s", strings.Join(added, ", ")))
+	}
+	if len(removed) > 0 {
+		changes = append(changes, fmt.Sprintf("hostnames removed: %s", strings.Join(removed, ", ")))
+	}
+
+	switch {
+	case len(changes) == 0:
+		return "Updated by Terraform: no rule or hostname changes"
+	case len(changes) > maxVersionNotesChanges:
+		more := len(changes) - maxVersionNotesChanges
+		changes = append(changes[:maxVersionNotesChanges], fmt.Sprintf("%d more changes", more))
+	}
+	return "Updated by Terraform: " + strings.Join(changes, "; ")
+}
+
+// diffHostnames returns the sorted cname_from values whose hostname was added to or removed from the set,
+// a hostname whose edge hostname or certificate provisioning type changed is listed as both removed and added
+func diffHostnames(old, new *schema.Set) (added, removed []string) {
+	for _, h := range new.Difference(old).List() {
+		added = append(added, h.(map[string]interface{})["cname_from"].(string))
+	}
+	for _, h := range old.Difference(new).List() {
+		removed = append(removed, h.(map[string]interface{})["cname_from"].(string))
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+// suppressCloneFromAfterCreate ignores changes of clone_from once the property exists, it is only used on creation
+func suppressCloneFromAfterCreate(_, _, _ string, d *schema.ResourceData) bool {
+	return d.Id() != ""
+}
+
+// cloneFrom returns the property version to clone from the clone_from block, or nil when the block is not set
+func cloneFrom(ctx context.Context, client papi.PAPI, d *schema.ResourceData) (*papi.PropertyCloneFrom, error) {
+	if _, ok := d.GetOk("clone_from"); !ok {
+		return nil, nil
+	}
+	CloneFrom := &papi.PropertyCloneFrom{
+		PropertyID:    tools.AddPrefix(d.Get("clone_from.0.property_id").(string), "prp_"),
+		Version:       d.Get("clone_from.0.version").(int),
+		CopyHostnames: d.Get("clone_from.0.copy_hostnames").(bool),
+	}
+	if CloneFrom.Version != 0 {
+		return CloneFrom, nil
+	}
+
+	logger := log.FromContext(ctx)
+	logger.Debugf("fetching latest version of property %s to clone", CloneFrom.PropertyID)
+	res, err := client.GetLatestVersion(ctx, papi.GetLatestVersionRequest{PropertyID: CloneFrom.PropertyID})
+	if err != nil {
+		logger.WithError(err).Error("could not fetch latest version of property to clone")
+		return nil, fmt.Errorf("cannot find property %s to clone: %w", CloneFrom.PropertyID, err)
+	}
+	CloneFrom.Version = res.Version.PropertyVersion
+	return CloneFrom, nil
+}
+
+// copiedHostnamesUnmanaged tells whether the property keeps the hostnames copied from the cloned version
+// because no hostnames are declared
+func copiedHostnamesUnmanaged(d *schema.ResourceData) bool {
+	return d.Get("clone_from.0.copy_hostnames").(bool) && d.Get("hostnames").(*schema.Set).Len() == 0
+}
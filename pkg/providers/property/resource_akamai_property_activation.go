@@ -25,6 +25,7 @@ func resourcePropertyActivation() *schema.Resource {
 		UpdateContext: resourcePropertyActivationUpdate,
 		DeleteContext: resourcePropertyActivationDelete,
 		Schema:        akamaiPropertyActivationSchema,
+		CustomizeDiff: akamai.EnforceChangeFreeze("network"),
 		Timeouts: &schema.ResourceTimeout{
 			Default: &PropertyResourceTimeout,
 		},
@@ -115,6 +116,25 @@ var akamaiPropertyActivationSchema = map[string]*schema.Schema{
 		Optional:    true,
 		Description: "assigns a log message to the activation request",
 	},
+	"include_certificate_challenges": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Description: "Set the Default DV certificate validation challenges of the property hostnames in certificate_challenges",
+	},
+	"certificate_challenges": {
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "CNAME records validating the Default DV certificates of the property hostnames, set when include_certificate_challenges is enabled",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"cname_from": {Type: schema.TypeString, Computed: true},
+				"hostname":   {Type: schema.TypeString, Computed: true},
+				"target":     {Type: schema.TypeString, Computed: true},
+				"status":     {Type: schema.TypeString, Computed: true},
+			},
+		},
+	},
+	akamai.IgnoreChangeFreezeKey: akamai.IgnoreChangeFreezeSchema(),
 }
 
 func papiError() *schema.Resource {
@@ -297,6 +317,10 @@ func resourcePropertyActivationCreate(ctx context.Context, d *schema.ResourceDat
 		}
 	}
 
+	if err := setCertificateChallenges(ctx, d, client, propertyID, version, network); err != nil {
+		return diag.FromErr(err)
+	}
+
 	if err := d.Set("version", activation.PropertyVersion); err != nil {
 		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
 	}
@@ -315,6 +339,9 @@ func resourcePropertyActivationCreate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourcePropertyActivationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := akamai.EnforceChangeFreezeOnDelete(d, m, "network"); err != nil {
+		return diag.FromErr(err)
+	}
 	meta := akamai.Meta(m)
 	logger := meta.Log("PAPI", "resourcePropertyActivationDelete")
 	client := inst.Client(meta)
@@ -529,6 +556,10 @@ func resourcePropertyActivationRead(ctx context.Context, d *schema.ResourceData,
 		}
 	}
 
+	if err := setCertificateChallenges(ctx, d, client, propertyID, version, network); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return nil
 }
 
@@ -722,6 +753,10 @@ func resourcePropertyActivationUpdate(ctx context.Context, d *schema.ResourceDat
 		}
 	}
 
+	if err := setCertificateChallenges(ctx, d, client, propertyID, version, network); err != nil {
+		return diag.FromErr(err)
+	}
+
 	if err := d.Set("version", propertyActivation.PropertyVersion); err != nil {
 		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
 	}
@@ -830,3 +865,58 @@ func networkAlias(d *schema.ResourceData) (papi.ActivationNetwork, error) {
 
 	return papi.ActivationNetwork(alias), nil
 }
+
+// setCertificateChallenges saves the validation challenges of the Default DV certificates of the activated hostnames
+// in certificate_challenges, when include_certificate_challenges is enabled
+func setCertificateChallenges(ctx context.Context, d *schema.ResourceData, client papi.PAPI, propertyID string, version int, network papi.ActivationNetwork) error {
+	challenges := []map[string]interface{}{}
+	if d.Get("include_certificate_challenges").(bool) {
+		hostnames, err := getActivationHostnames(ctx, client, propertyID, version)
+		if err != nil {
+			return err
+		}
+		challenges = flattenCertificateChallenges(hostnames, network)
+	}
+	if err := d.Set("certificate_challenges", challenges); err != nil {
+		return fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error())
+	}
+	return nil
+}
+
+func getActivationHostnames(ctx context.Context, client papi.PAPI, propertyID string, version int) ([]papi.Hostname, error) {
+	res, err := client.GetPropertyVersionHostnames(ctx, papi.GetPropertyVersionHostnamesRequest{
+		PropertyID:        propertyID,
+		PropertyVersion:   version,
+		IncludeCertStatus: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hostnames for property: %w", err)
+	}
+	return res.Hostnames.Items, nil
+}
+
+// flattenCertificateChallenges returns the validation CNAME and certificate status on the network of hostnames
+// with Default DV certificates
+func flattenCertificateChallenges(hostnames []papi.Hostname, network papi.ActivationNetwork) []map[string]interface{} {
+	challenges := make([]map[string]interface{}, 0, len(hostnames))
+	for _, h := range hostnames {
+		if h.CertProvisioningType != "DEFAULT" {
+			continue
+		}
+		statuses := h.CertStatus.Staging
+		if network == papi.ActivationNetworkProduction {
+			statuses = h.CertStatus.Production
+		}
+		var status string
+		if len(statuses) > 0 {
+			status = statuses[0].Status
+		}
+		challenges = append(challenges, map[string]interface{}{
+			"cname_from": h.CnameFrom,
+			"hostname":   h.CertStatus.ValidationCname.Hostname,
+			"target":     h.CertStatus.ValidationCname.Target,
+			"status":     status,
+		})
+	}
+	return challenges
+}
@@ -0,0 +1,563 @@
+package property
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/spf13/cast"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+)
+
+var (
+	// ErrActivationBatchFailed is returned when an activation of the batch does not complete
+	ErrActivationBatchFailed = errors.New("activation batch failed")
+)
+
+func resourcePropertyActivationBatch() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePropertyActivationBatchCreate,
+		ReadContext:   resourcePropertyActivationBatchRead,
+		UpdateContext: resourcePropertyActivationBatchUpdate,
+		DeleteContext: resourcePropertyActivationBatchDelete,
+		CustomizeDiff: customdiff.All(
+			akamai.EnforceChangeFreeze("network"),
+			activationBatchCustomizeDiff,
+		),
+		Timeouts: &schema.ResourceTimeout{
+			Default: &PropertyResourceTimeout,
+		},
+		Schema: map[string]*schema.Schema{
+			"network": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  papi.ActivationNetworkStaging,
+			},
+			"contact": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"note": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "assigns a log message to every activation request of the batch",
+			},
+			"auto_acknowledge_rule_warnings": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "automatically acknowledge all rule warnings for activations to continue. default is true",
+			},
+			"rollback_on_failure": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Reactivate the previously active versions of the properties already activated when an activation fails",
+			},
+			"group": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "Groups of properties activated in order, the properties of a group are activated in parallel",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"property": {
+							Type:     schema.TypeList,
+							Required: true,
+							MinItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"property_id": {
+										Type:             schema.TypeString,
+										Required:         true,
+										ValidateDiagFunc: tools.IsNotBlank,
+									},
+									"version": {
+										Type:             schema.TypeInt,
+										Required:         true,
+										ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"activations": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Outcome of the activation of each property, in activation order",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"group":            {Type: schema.TypeInt, Computed: true},
+						"property_id":      {Type: schema.TypeString, Computed: true},
+						"version":          {Type: schema.TypeInt, Computed: true},
+						"previous_version": {Type: schema.TypeInt, Computed: true},
+						"activation_id":    {Type: schema.TypeString, Computed: true},
+						"status":           {Type: schema.TypeString, Computed: true},
+					},
+				},
+			},
+			akamai.IgnoreChangeFreezeKey: akamai.IgnoreChangeFreezeSchema(),
+		},
+	}
+}
+
+// batchActivation tracks the activation, or its rollback, of a single property of the batch
+type batchActivation struct {
+	Group           int
+	PropertyID      string
+	Version         int
+	PreviousVersion int
+	ActivationType  papi.ActivationType
+	ActivationID    string
+	Status          papi.ActivationStatus
+}
+
+// batchActivationRequest holds the settings shared by every activation of the batch
+type batchActivationRequest struct {
+	network                 papi.ActivationNetwork
+	notify                  []string
+	note                    string
+	acknowledgeRuleWarnings bool
+}
+
+func resourcePropertyActivationBatchCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourcePropertyActivationBatchCreate")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	if diags := applyActivationBatch(ctx, d, m); diags.HasError() {
+		return diags
+	}
+	return resourcePropertyActivationBatchRead(ctx, d, m)
+}
+
+func resourcePropertyActivationBatchUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourcePropertyActivationBatchUpdate")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	if !d.HasChanges("group", "activations") {
+		logger.Debug("No properties to activate")
+		return resourcePropertyActivationBatchRead(ctx, d, m)
+	}
+	if diags := applyActivationBatch(ctx, d, m); diags.HasError() {
+		return diags
+	}
+	return resourcePropertyActivationBatchRead(ctx, d, m)
+}
+
+func resourcePropertyActivationBatchRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourcePropertyActivationBatchRead")
+	client := inst.Client(meta)
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	network, err := NetworkAlias(d.Get("network").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	activations := expandBatchActivations(d.Get("activations").([]interface{}))
+	for _, a := range activations {
+		res, err := client.GetActivations(ctx, papi.GetActivationsRequest{PropertyID: a.PropertyID})
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to get activations for property %s: %w", a.PropertyID, err))
+		}
+		active, err := activeVersion(res.Activations.Items, papi.ActivationNetwork(network))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		a.Status = papi.ActivationStatusActive
+		if active != a.Version {
+			logger.Debugf("Property %s version %d is no longer active on %s", a.PropertyID, a.Version, network)
+			a.Status = papi.ActivationStatusInactive
+		}
+	}
+
+	if err := d.Set("activations", flattenBatchActivations(activations)); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+	return nil
+}
+
+func resourcePropertyActivationBatchDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := akamai.EnforceChangeFreezeOnDelete(d, m, "network"); err != nil {
+		return diag.FromErr(err)
+	}
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourcePropertyActivationBatchDelete")
+	client := inst.Client(meta)
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	request, err := getBatchActivationRequest(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// deactivate the properties still running the batch versions in the reverse activation order
+	var groups [][]*batchActivation
+	for _, group := range groupBatchActivations(expandBatchActivations(d.Get("activations").([]interface{}))) {
+		var deactivations []*batchActivation
+		for _, a := range group {
+			if a.Status != papi.ActivationStatusActive {
+				continue
+			}
+			deactivations = append(deactivations, &batchActivation{
+				Group:          a.Group,
+				PropertyID:     a.PropertyID,
+				Version:        a.Version,
+				ActivationType: papi.ActivationTypeDeactivate,
+			})
+		}
+		if len(deactivations) > 0 {
+			groups = append([][]*batchActivation{deactivations}, groups...)
+		}
+	}
+	if _, err := runActivationGroups(ctx, client, request, groups); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// activationBatchCustomizeDiff plans a new activation run when a property of the batch is no longer active
+func activationBatchCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	if d.HasChange("group") {
+		return d.SetNewComputed("activations")
+	}
+	for _, a := range expandBatchActivations(d.Get("activations").([]interface{})) {
+		if a.Status != papi.ActivationStatusActive {
+			return d.SetNewComputed("activations")
+		}
+	}
+	return nil
+}
+
+// applyActivationBatch activates the configured groups in order, and optionally rolls back the activated
+// properties when an activation fails
+func applyActivationBatch(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "applyActivationBatch")
+	client := inst.Client(meta)
+
+	request, err := getBatchActivationRequest(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	groups, err := getBatchActivationGroups(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// previous versions are looked up before anything is activated, so that a rollback restores them
+	var activations []*batchActivation
+	for _, group := range groups {
+		for _, a := range group {
+			res, err := client.GetActivations(ctx, papi.GetActivationsRequest{PropertyID: a.PropertyID})
+			if err != nil {
+				return diag.FromErr(fmt.Errorf("failed to get activations for property %s: %w", a.PropertyID, err))
+			}
+			if a.PreviousVersion, err = activeVersion(res.Activations.Items, request.network); err != nil {
+				return diag.FromErr(err)
+			}
+			activations = append(activations, a)
+		}
+	}
+
+	completed, err := runActivationGroups(ctx, client, request, groups)
+	if err == nil {
+		d.SetId(batchActivationID(activations, request.network))
+		if err := d.Set("activations", flattenBatchActivations(activations)); err != nil {
+			return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+		}
+		return nil
+	}
+
+	// the prior state is kept, so the failed release is planned again
+	d.Partial(true)
+	diags := diag.FromErr(err)
+	if !d.Get("rollback_on_failure").(bool) {
+		return diags
+	}
+
+	logger.Warnf("Rolling back %d activated properties", len(completed))
+	if _, err := runActivationGroups(ctx, client, request, rollbackActivationGroups(completed)); err != nil {
+		return append(diags, diag.Errorf("rollback failed: %s", err)...)
+	}
+	return append(diags, diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("%d activated properties were rolled back", len(completed)),
+	})
+}
+
+// runActivationGroups submits the activations of each group and waits for them to complete before moving to the
+// next group. It stops after the first group with a failed activation and returns the completed activations.
+func runActivationGroups(ctx context.Context, client papi.PAPI, request batchActivationRequest, groups [][]*batchActivation) ([]*batchActivation, error) {
+	var completed []*batchActivation
+	for _, group := range groups {
+		var pending []*batchActivation
+		for _, a := range group {
+			if a.ActivationType == papi.ActivationTypeActivate && a.PreviousVersion == a.Version {
+				a.Status = papi.ActivationStatusActive
+				completed = append(completed, a)
+				continue
+			}
+			if err := submitBatchActivation(ctx, client, request, a); err != nil {
+				return completed, err
+			}
+			pending = append(pending, a)
+		}
+
+		var failures []string
+		for len(pending) > 0 {
+			var running []*batchActivation
+			for _, a := range pending {
+				act, err := client.GetActivation(ctx, papi.GetActivationRequest{
+					ActivationID: a.ActivationID,
+					PropertyID:   a.PropertyID,
+				})
+				if err != nil {
+					return completed, err
+				}
+				a.Status = act.Activation.Status
+				switch a.Status {
+				case papi.ActivationStatusActive:
+					completed = append(completed, a)
+				case papi.ActivationStatusAborted, papi.ActivationStatusFailed:
+					failures = append(failures, fmt.Sprintf("%s version %d: %s %s", a.PropertyID, a.Version, a.ActivationType, a.Status))
+				default:
+					running = append(running, a)
+				}
+			}
+			if pending = running; len(pending) == 0 {
+				break
+			}
+			select {
+			case <-time.After(tools.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
+			case <-ctx.Done():
+				return completed, fmt.Errorf("activation context terminated: %w", ctx.Err())
+			}
+		}
+		if len(failures) > 0 {
+			return completed, fmt.Errorf("%w: %s", ErrActivationBatchFailed, strings.Join(failures, "; "))
+		}
+	}
+	return completed, nil
+}
+
+// submitBatchActivation creates the activation request, an activation of the same version still in progress is
+// reused instead
+func submitBatchActivation(ctx context.Context, client papi.PAPI, request batchActivationRequest, a *batchActivation) error {
+	res, err := client.GetActivations(ctx, papi.GetActivationsRequest{PropertyID: a.PropertyID})
+	if err != nil {
+		return fmt.Errorf("failed to get activations for property %s: %w", a.PropertyID, err)
+	}
+	for _, act := range res.Activations.Items {
+		if act.Network == request.network && act.PropertyVersion == a.Version && act.ActivationType == a.ActivationType &&
+			isActivationInProgress(act.Status) {
+			a.ActivationID = act.ActivationID
+			return nil
+		}
+	}
+
+	create, err := client.CreateActivation(ctx, papi.CreateActivationRequest{
+		PropertyID: a.PropertyID,
+		Activation: papi.Activation{
+			ActivationType:         a.ActivationType,
+			Network:                request.network,
+			PropertyVersion:        a.Version,
+			NotifyEmails:           request.notify,
+			AcknowledgeAllWarnings: request.acknowledgeRuleWarnings,
+			Note:                   request.note,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("create %s of %s failed: %w", strings.ToLower(string(a.ActivationType)), a.PropertyID, err)
+	}
+	a.ActivationID = create.ActivationID
+	return nil
+}
+
+func isActivationInProgress(status papi.ActivationStatus) bool {
+	switch status {
+	case papi.ActivationStatusNew, papi.ActivationStatusPending, papi.ActivationStatusDeactivating,
+		papi.ActivationStatusZone1, papi.ActivationStatusZone2, papi.ActivationStatusZone3:
+		return true
+	}
+	return false
+}
+
+// rollbackActivationGroups returns the groups restoring the previous versions of the completed activations, in the
+// reverse activation order. Properties without a previous version are deactivated.
+func rollbackActivationGroups(completed []*batchActivation) [][]*batchActivation {
+	var groups [][]*batchActivation
+	for _, group := range groupBatchActivations(completed) {
+		var rollbacks []*batchActivation
+		for _, a := range group {
+			if a.PreviousVersion == a.Version {
+				continue
+			}
+			rollback := &batchActivation{
+				Group:          a.Group,
+				PropertyID:     a.PropertyID,
+				Version:        a.PreviousVersion,
+				ActivationType: papi.ActivationTypeActivate,
+			}
+			if a.PreviousVersion == 0 {
+				rollback.Version = a.Version
+				rollback.ActivationType = papi.ActivationTypeDeactivate
+			}
+			rollbacks = append(rollbacks, rollback)
+		}
+		if len(rollbacks) > 0 {
+			groups = append([][]*batchActivation{rollbacks}, groups...)
+		}
+	}
+	return groups
+}
+
+// groupBatchActivations splits the activations, listed in activation order, by group
+func groupBatchActivations(activations []*batchActivation) [][]*batchActivation {
+	var groups [][]*batchActivation
+	for i, a := range activations {
+		if i == 0 || a.Group != activations[i-1].Group {
+			groups = append(groups, nil)
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], a)
+	}
+	return groups
+}
+
+// activeVersion returns the version currently active on the network, or 0 if the property is not active
+func activeVersion(activations []*papi.Activation, network papi.ActivationNetwork) (int, error) {
+	var latest *papi.Activation
+	var latestSubmitDate time.Time
+	for _, a := range activations {
+		if a.Network != network || a.Status != papi.ActivationStatusActive {
+			continue
+		}
+		submitDate, err := tools.ParseDate(tools.DateTimeFormat, a.SubmitDate)
+		if err != nil {
+			return 0, err
+		}
+		if latest == nil || latestSubmitDate.Before(submitDate) {
+			latest = a
+			latestSubmitDate = submitDate
+		}
+	}
+	if latest == nil || latest.ActivationType == papi.ActivationTypeDeactivate {
+		return 0, nil
+	}
+	return latest.PropertyVersion, nil
+}
+
+func getBatchActivationRequest(d *schema.ResourceData) (batchActivationRequest, error) {
+	network, err := NetworkAlias(d.Get("network").(string))
+	if err != nil {
+		return batchActivationRequest{}, err
+	}
+	request := batchActivationRequest{
+		network:                 papi.ActivationNetwork(network),
+		note:                    d.Get("note").(string),
+		acknowledgeRuleWarnings: d.Get("auto_acknowledge_rule_warnings").(bool),
+	}
+	for _, contact := range d.Get("contact").(*schema.Set).List() {
+		request.notify = append(request.notify, cast.ToString(contact))
+	}
+	return request, nil
+}
+
+func getBatchActivationGroups(d *schema.ResourceData) ([][]*batchActivation, error) {
+	seen := make(map[string]struct{})
+	var groups [][]*batchActivation
+	for i, g := range d.Get("group").([]interface{}) {
+		var group []*batchActivation
+		for _, p := range g.(map[string]interface{})["property"].([]interface{}) {
+			property := p.(map[string]interface{})
+			propertyID := tools.AddPrefix(property["property_id"].(string), "prp_")
+			if _, ok := seen[propertyID]; ok {
+				return nil, fmt.Errorf("%w: property %s is listed more than once", ErrActivationBatchFailed, propertyID)
+			}
+			seen[propertyID] = struct{}{}
+			group = append(group, &batchActivation{
+				Group:          i,
+				PropertyID:     propertyID,
+				Version:        property["version"].(int),
+				ActivationType: papi.ActivationTypeActivate,
+			})
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+func batchActivationID(activations []*batchActivation, network papi.ActivationNetwork) string {
+	propertyIDs := make([]string, 0, len(activations))
+	for _, a := range activations {
+		propertyIDs = append(propertyIDs, a.PropertyID)
+	}
+	return fmt.Sprintf("%s:%s", strings.Join(propertyIDs, ","), network)
+}
+
+func expandBatchActivations(list []interface{}) []*batchActivation {
+	activations := make([]*batchActivation, 0, len(list))
+	for _, item := range list {
+		a := item.(map[string]interface{})
+		activations = append(activations, &batchActivation{
+			Group:           a["group"].(int),
+			PropertyID:      a["property_id"].(string),
+			Version:         a["version"].(int),
+			PreviousVersion: a["previous_version"].(int),
+			ActivationID:    a["activation_id"].(string),
+			Status:          papi.ActivationStatus(a["status"].(string)),
+		})
+	}
+	return activations
+}
+
+func flattenBatchActivations(activations []*batchActivation) []interface{} {
+	flattened := make([]interface{}, 0, len(activations))
+	for _, a := range activations {
+		flattened = append(flattened, map[string]interface{}{
+			"group":            a.Group,
+			"property_id":      a.PropertyID,
+			"version":          a.Version,
+			"previous_version": a.PreviousVersion,
+			"activation_id":    a.ActivationID,
+			"status":           string(a.Status),
+		})
+	}
+	return flattened
+}
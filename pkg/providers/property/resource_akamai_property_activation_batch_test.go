@@ -0,0 +1,270 @@
+package property
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+)
+
+// fakeActivations keeps the activations of the mocked properties, activations complete as soon as they are submitted
+type fakeActivations struct {
+	responses map[string]*papi.GetActivationsResponse
+	failing   map[string]struct{}
+	submitted []string
+	created   papi.CreateActivationResponse
+	fetched   papi.GetActivationResponse
+	submitAt  time.Time
+}
+
+func newFakeActivations(propertyIDs ...string) *fakeActivations {
+	f := &fakeActivations{
+		responses: make(map[string]*papi.GetActivationsResponse),
+		failing:   make(map[string]struct{}),
+		submitAt:  time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for _, propertyID := range propertyIDs {
+		f.responses[propertyID] = &papi.GetActivationsResponse{}
+	}
+	return f
+}
+
+// activate records a completed activation, superseding the previous one on the network
+func (f *fakeActivations) activate(propertyID string, version int, activationType papi.ActivationType) *papi.Activation {
+	items := f.responses[propertyID].Activations.Items
+	key := fmt.Sprintf("%s %d %s", propertyID, version, activationType)
+	status := papi.ActivationStatusActive
+	if _, ok := f.failing[key]; ok {
+		status = papi.ActivationStatusFailed
+	} else {
+		for _, a := range items {
+			if a.Status == papi.ActivationStatusActive {
+				a.Status = papi.ActivationStatusInactive
+			}
+		}
+	}
+	f.submitAt = f.submitAt.Add(time.Minute)
+	activation := &papi.Activation{
+		ActivationID:    fmt.Sprintf("atv_%d", len(f.submitted)+len(items)),
+		ActivationType:  activationType,
+		Network:         papi.ActivationNetworkProduction,
+		PropertyID:      propertyID,
+		PropertyVersion: version,
+		Status:          status,
+		SubmitDate:      f.submitAt.Format(tools.DateTimeFormat),
+	}
+	f.responses[propertyID].Activations.Items = append(items, activation)
+	return activation
+}
+
+func (f *fakeActivations) init(m *mockpapi) {
+	for propertyID, res := range f.responses {
+		m.On("GetActivations", mock.Anything, papi.GetActivationsRequest{PropertyID: propertyID}).Return(res, nil)
+	}
+	m.On("CreateActivation", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		req := args.Get(1).(papi.CreateActivationRequest)
+		activation := f.activate(req.PropertyID, req.Activation.PropertyVersion, req.Activation.ActivationType)
+		f.submitted = append(f.submitted, fmt.Sprintf("%s %d %s", req.PropertyID, req.Activation.PropertyVersion, req.Activation.ActivationType))
+		f.created.ActivationID = activation.ActivationID
+	}).Return(&f.created, nil)
+	m.On("GetActivation", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		req := args.Get(1).(papi.GetActivationRequest)
+		for _, a := range f.responses[req.PropertyID].Activations.Items {
+			if a.ActivationID == req.ActivationID {
+				f.fetched.Activation = a
+			}
+		}
+	}).Return(&f.fetched, nil)
+}
+
+func TestResPropertyActivationBatch(t *testing.T) {
+	tests := map[string]struct {
+		init      func(*fakeActivations)
+		steps     func(*fakeActivations) []resource.TestStep
+		submitted []string
+	}{
+		"groups activated in order and deactivated in reverse order": {
+			init: func(f *fakeActivations) {
+				f.activate("prp_1", 1, papi.ActivationTypeActivate)
+				f.activate("prp_3", 1, papi.ActivationTypeActivate)
+			},
+			steps: func(f *fakeActivations) []resource.TestStep {
+				return []resource.TestStep{{
+					Config: loadFixtureString("testdata/TestResPropertyActivationBatch/batch.tf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("akamai_property_activation_batch.test", "id", "prp_1,prp_2,prp_3:PRODUCTION"),
+						resource.TestCheckResourceAttr("akamai_property_activation_batch.test", "activations.#", "3"),
+						resource.TestCheckResourceAttr("akamai_property_activation_batch.test", "activations.0.previous_version", "1"),
+						resource.TestCheckResourceAttr("akamai_property_activation_batch.test", "activations.0.status", "ACTIVE"),
+						resource.TestCheckResourceAttr("akamai_property_activation_batch.test", "activations.1.group", "1"),
+						resource.TestCheckResourceAttr("akamai_property_activation_batch.test", "activations.1.previous_version", "0"),
+						resource.TestCheckResourceAttr("akamai_property_activation_batch.test", "activations.1.status", "ACTIVE"),
+						resource.TestCheckResourceAttr("akamai_property_activation_batch.test", "activations.2.property_id", "prp_3"),
+						resource.TestCheckResourceAttr("akamai_property_activation_batch.test", "activations.2.activation_id", ""),
+					),
+				}}
+			},
+			submitted: []string{
+				"prp_1 2 ACTIVATE",
+				"prp_2 3 ACTIVATE",
+				"prp_2 3 DEACTIVATE",
+				"prp_3 1 DEACTIVATE",
+				"prp_1 2 DEACTIVATE",
+			},
+		},
+		"version activated outside of the batch is reactivated": {
+			steps: func(f *fakeActivations) []resource.TestStep {
+				return []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestResPropertyActivationBatch/batch.tf"),
+					},
+					{
+						PreConfig: func() {
+							f.activate("prp_1", 5, papi.ActivationTypeActivate)
+						},
+						Config: loadFixtureString("testdata/TestResPropertyActivationBatch/batch.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_property_activation_batch.test", "activations.0.previous_version", "5"),
+							resource.TestCheckResourceAttr("akamai_property_activation_batch.test", "activations.0.status", "ACTIVE"),
+						),
+					},
+				}
+			},
+			submitted: []string{
+				"prp_1 2 ACTIVATE",
+				"prp_2 3 ACTIVATE",
+				"prp_3 1 ACTIVATE",
+				"prp_1 2 ACTIVATE",
+				"prp_2 3 DEACTIVATE",
+				"prp_3 1 DEACTIVATE",
+				"prp_1 2 DEACTIVATE",
+			},
+		},
+		"batch stops on first failure": {
+			init: func(f *fakeActivations) {
+				f.activate("prp_1", 1, papi.ActivationTypeActivate)
+				f.failing["prp_2 3 ACTIVATE"] = struct{}{}
+			},
+			steps: func(f *fakeActivations) []resource.TestStep {
+				return []resource.TestStep{{
+					Config:      loadFixtureString("testdata/TestResPropertyActivationBatch/failure.tf"),
+					ExpectError: regexp.MustCompile("activation batch failed: prp_2 version 3: ACTIVATE FAILED"),
+				}}
+			},
+			submitted: []string{
+				"prp_1 2 ACTIVATE",
+				"prp_4 2 ACTIVATE",
+				"prp_2 3 ACTIVATE",
+			},
+		},
+		"failed release planned again": {
+			init: func(f *fakeActivations) {
+				f.failing["prp_3 2 ACTIVATE"] = struct{}{}
+			},
+			steps: func(f *fakeActivations) []resource.TestStep {
+				return []resource.TestStep{
+					{
+						Config: loadFixtureString("testdata/TestResPropertyActivationBatch/batch.tf"),
+					},
+					{
+						Config:      loadFixtureString("testdata/TestResPropertyActivationBatch/failure.tf"),
+						ExpectError: regexp.MustCompile("activation batch failed: prp_3 version 2: ACTIVATE FAILED"),
+					},
+					{
+						PreConfig: func() {
+							delete(f.failing, "prp_3 2 ACTIVATE")
+						},
+						Config: loadFixtureString("testdata/TestResPropertyActivationBatch/failure.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_property_activation_batch.test", "activations.3.property_id", "prp_3"),
+							resource.TestCheckResourceAttr("akamai_property_activation_batch.test", "activations.3.status", "ACTIVE"),
+						),
+					},
+				}
+			},
+			submitted: []string{
+				"prp_1 2 ACTIVATE",
+				"prp_2 3 ACTIVATE",
+				"prp_3 1 ACTIVATE",
+				"prp_4 2 ACTIVATE",
+				"prp_3 2 ACTIVATE",
+				"prp_3 2 ACTIVATE",
+				"prp_3 2 DEACTIVATE",
+				"prp_2 3 DEACTIVATE",
+				"prp_1 2 DEACTIVATE",
+				"prp_4 2 DEACTIVATE",
+			},
+		},
+		"activated properties rolled back on failure": {
+			init: func(f *fakeActivations) {
+				f.activate("prp_1", 1, papi.ActivationTypeActivate)
+				f.failing["prp_2 3 ACTIVATE"] = struct{}{}
+			},
+			steps: func(f *fakeActivations) []resource.TestStep {
+				return []resource.TestStep{{
+					Config:      loadFixtureString("testdata/TestResPropertyActivationBatch/rollback.tf"),
+					ExpectError: regexp.MustCompile("activation batch failed: prp_2 version 3: ACTIVATE FAILED"),
+				}}
+			},
+			submitted: []string{
+				"prp_1 2 ACTIVATE",
+				"prp_4 2 ACTIVATE",
+				"prp_2 3 ACTIVATE",
+				"prp_1 1 ACTIVATE",
+				"prp_4 2 DEACTIVATE",
+			},
+		},
+		"property listed twice": {
+			steps: func(f *fakeActivations) []resource.TestStep {
+				return []resource.TestStep{{
+					Config:      loadFixtureString("testdata/TestResPropertyActivationBatch/duplicate.tf"),
+					ExpectError: regexp.MustCompile("property prp_1 is listed more than once"),
+				}}
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &mockpapi{}
+			fake := newFakeActivations("prp_1", "prp_2", "prp_3", "prp_4")
+			if test.init != nil {
+				test.init(fake)
+			}
+			fake.init(client)
+			useClient(client, func() {
+				resource.UnitTest(t, resource.TestCase{
+					Providers: testAccProviders,
+					Steps:     test.steps(fake),
+				})
+			})
+			assert.Equal(t, test.submitted, fake.submitted)
+		})
+	}
+}
+
+func TestRollbackActivationGroups(t *testing.T) {
+	groups := rollbackActivationGroups([]*batchActivation{
+		{Group: 0, PropertyID: "prp_1", Version: 2, PreviousVersion: 1},
+		{Group: 0, PropertyID: "prp_2", Version: 1},
+		{Group: 1, PropertyID: "prp_3", Version: 4, PreviousVersion: 4},
+		{Group: 2, PropertyID: "prp_4", Version: 3, PreviousVersion: 2},
+	})
+
+	assert.Equal(t, [][]*batchActivation{
+		{
+			{Group: 2, PropertyID: "prp_4", Version: 2, ActivationType: papi.ActivationTypeActivate},
+		},
+		{
+			{Group: 0, PropertyID: "prp_1", Version: 1, ActivationType: papi.ActivationTypeActivate},
+			{Group: 0, PropertyID: "prp_2", Version: 1, ActivationType: papi.ActivationTypeDeactivate},
+		},
+	}, groups)
+}
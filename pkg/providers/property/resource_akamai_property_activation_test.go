@@ -157,6 +157,67 @@ func TestResourcePAPIPropertyActivation(t *testing.T) {
 				},
 			},
 		},
+		"production activation during change freeze": {
+			steps: []resource.TestStep{
+				{
+					Config:      loadFixtureString("./testdata/TestPropertyActivation/change_freeze/resource_property_activation.tf"),
+					ExpectError: regexp.MustCompile(`change freeze is active: production changes are not allowed during change freeze window(.|\n)*test freeze`),
+				},
+			},
+		},
+		"staging activation during change freeze - OK": {
+			init: func(m *mockpapi) {
+				expectGetRuleTree(m, "prp_test", 1, ruleTreeResponseValid, nil).Once()
+				expectGetActivations(m, "prp_test", activationsResponseActivated, nil).Twice()
+				expectGetActivations(m, "prp_test", activationsResponseDeactivated, nil).Once()
+			},
+			steps: []resource.TestStep{
+				{
+					Config: loadFixtureString("./testdata/TestPropertyActivation/change_freeze/resource_property_activation_staging.tf"),
+					Check:  resource.TestCheckResourceAttr("akamai_property_activation.test", "network", "STAGING"),
+				},
+			},
+		},
+		"production activation during change freeze with override - OK": {
+			init: func(m *mockpapi) {
+				expectGetRuleTree(m, "prp_test", 1, ruleTreeResponseValid, nil).Once()
+				expectGetActivations(m, "prp_test", activationsResponseProductionActivated, nil).Twice()
+				expectGetActivations(m, "prp_test", activationsResponseProductionDeactivated, nil).Once()
+			},
+			steps: []resource.TestStep{
+				{
+					Config: loadFixtureString("./testdata/TestPropertyActivation/change_freeze/resource_property_activation_override.tf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("akamai_property_activation.test", "network", "PRODUCTION"),
+						resource.TestCheckResourceAttr("akamai_property_activation.test", "ignore_change_freeze", "true"),
+					),
+				},
+			},
+		},
+		"production activation with certificate challenges - OK": {
+			init: func(m *mockpapi) {
+				// create
+				expectGetRuleTree(m, "prp_test", 1, ruleTreeResponseValid, nil).Once()
+				expectGetActivations(m, "prp_test", activationsResponseProductionActivated, nil).Twice()
+				expectGetPropertyVersionHostnames(m, "prp_test", 1, "PENDING")
+				// delete
+				expectGetActivations(m, "prp_test", activationsResponseProductionDeactivated, nil).Once()
+			},
+			steps: []resource.TestStep{
+				{
+					Config: loadFixtureString("./testdata/TestPropertyActivation/certificate_challenges/resource_property_activation.tf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("akamai_property_activation.test", "network", "PRODUCTION"),
+						resource.TestCheckResourceAttr("akamai_property_activation.test", "status", "ACTIVE"),
+						resource.TestCheckResourceAttr("akamai_property_activation.test", "certificate_challenges.#", "1"),
+						resource.TestCheckResourceAttr("akamai_property_activation.test", "certificate_challenges.0.cname_from", "www.example.com"),
+						resource.TestCheckResourceAttr("akamai_property_activation.test", "certificate_challenges.0.hostname", "_acme-challenge.www.example.com"),
+						resource.TestCheckResourceAttr("akamai_property_activation.test", "certificate_challenges.0.target", "ac.1234.www.example.com.validate-akdv.net"),
+						resource.TestCheckResourceAttr("akamai_property_activation.test", "certificate_challenges.0.status", "PENDING"),
+					),
+				},
+			},
+		},
 		"Note field cannot be added after activation is completed": {
 			init: func(m *mockpapi) {
 				// create
@@ -268,6 +329,34 @@ var (
 			SubmitDate:      "2020-10-28T15:04:05Z",
 		}}},
 	}
+	activationsResponseProductionActivated = papi.GetActivationsResponse{
+		Activations: papi.ActivationsItems{Items: []*papi.Activation{{
+			AccountID:       "act_1-6JHGX",
+			ActivationID:    "atv_activation1",
+			ActivationType:  "ACTIVATE",
+			GroupID:         "grp_91533",
+			PropertyName:    "test",
+			PropertyID:      "prp_test",
+			PropertyVersion: 1,
+			Network:         "PRODUCTION",
+			Status:          "ACTIVE",
+			SubmitDate:      "2020-10-28T15:04:05Z",
+		}}},
+	}
+	activationsResponseProductionDeactivated = papi.GetActivationsResponse{
+		Activations: papi.ActivationsItems{Items: []*papi.Activation{{
+			AccountID:       "act_1-6JHGX",
+			ActivationID:    "atv_activation1",
+			ActivationType:  "DEACTIVATE",
+			GroupID:         "grp_91533",
+			PropertyName:    "test",
+			PropertyID:      "prp_test",
+			PropertyVersion: 1,
+			Network:         "PRODUCTION",
+			Status:          "ACTIVE",
+			SubmitDate:      "2020-10-28T15:04:05Z",
+		}}},
+	}
 	activationsResponseDeactivated = papi.GetActivationsResponse{
 		Activations: papi.ActivationsItems{Items: []*papi.Activation{{
 			AccountID:       "act_1-6JHGX",
@@ -393,4 +482,31 @@ var (
 			},
 		}, nil)
 	}
+
+	expectGetPropertyVersionHostnames = func(m *mockpapi, propertyID string, version int, productionStatus string) *mock.Call {
+		return m.On(
+			"GetPropertyVersionHostnames",
+			mock.Anything,
+			papi.GetPropertyVersionHostnamesRequest{PropertyID: propertyID, PropertyVersion: version, IncludeCertStatus: true},
+		).Return(&papi.GetPropertyVersionHostnamesResponse{Hostnames: papi.HostnameResponseItems{Items: []papi.Hostname{
+			{
+				CnameFrom:            "www.example.com",
+				CnameTo:              "www.example.com.edgekey.net",
+				CertProvisioningType: "DEFAULT",
+				CertStatus: papi.CertStatusItem{
+					ValidationCname: papi.ValidationCname{
+						Hostname: "_acme-challenge.www.example.com",
+						Target:   "ac.1234.www.example.com.validate-akdv.net",
+					},
+					Staging:    []papi.StatusItem{{Status: CertStatusDeployed}},
+					Production: []papi.StatusItem{{Status: productionStatus}},
+				},
+			},
+			{
+				CnameFrom:            "api.example.com",
+				CnameTo:              "api.example.com.edgekey.net",
+				CertProvisioningType: "CPS_MANAGED",
+			},
+		}}}, nil)
+	}
 )
@@ -0,0 +1,422 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+)
+
+var (
+	// BulkPatchPollInterval is the interval for polling the bulk version creation and bulk patch status
+	BulkPatchPollInterval = 5 * time.Second
+
+	// ErrBulkPatchFailed is returned when PAPI could not complete the bulk version creation or bulk patch
+	ErrBulkPatchFailed = errors.New("bulk patch failed")
+)
+
+func resourcePropertyBulkPatch() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePropertyBulkPatchCreate,
+		ReadContext:   resourcePropertyBulkPatchRead,
+		DeleteContext: resourcePropertyBulkPatchDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Default: &PropertyResourceTimeout,
+		},
+		Schema: map[string]*schema.Schema{
+			"bulk_search_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"bulk_search_id", "property"},
+				Description:  "ID of a completed bulk search, new versions are created from the highest matching version of each property found",
+			},
+			"property": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Properties to patch, as an alternative to a bulk search",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"property_id": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"version": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "Version to create the new version from, defaults to the latest version",
+						},
+					},
+				},
+			},
+			"patch": {
+				Type:        schema.TypeList,
+				Required:    true,
+				ForceNew:    true,
+				Description: "JSON patch operations applied to the rule tree of every new version",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"op": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+							ValidateDiagFunc: tools.ValidateStringInSlice([]string{
+								"add", "remove", "replace", "move", "copy", "test",
+							}),
+						},
+						"path": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ForceNew:         true,
+							ValidateDiagFunc: tools.IsNotBlank,
+						},
+						"from": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"value": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ForceNew:         true,
+							ValidateDiagFunc: validateJSONValue,
+							Description:      "JSON encoded value of the operation",
+						},
+					},
+				},
+			},
+			"bulk_create_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"bulk_patch_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"results": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Outcome of the version creation and patch of each property",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"property_id":         {Type: schema.TypeString, Computed: true},
+						"property_name":       {Type: schema.TypeString, Computed: true},
+						"create_from_version": {Type: schema.TypeInt, Computed: true},
+						"property_version":    {Type: schema.TypeInt, Computed: true},
+						"status":              {Type: schema.TypeString, Computed: true},
+						"error":               {Type: schema.TypeString, Computed: true},
+					},
+				},
+			},
+			"versions": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "New version of each successfully patched property, keyed by property ID",
+			},
+		},
+	}
+}
+
+// bulkPatchResult tracks a single property through version creation and patch
+type bulkPatchResult struct {
+	PropertyID        string
+	PropertyName      string
+	CreateFromVersion int
+	PropertyVersion   int
+	Status            string
+	Error             string
+}
+
+func resourcePropertyBulkPatchCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourcePropertyBulkPatchCreate")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+	client := inst.BulkClient(meta)
+
+	patches, err := getBulkPatchOperations(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	creations, err := getBulkVersionCreations(ctx, d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(creations) == 0 {
+		return diag.Errorf("%s: no properties to patch", ErrBulkPatchFailed)
+	}
+
+	logger.Debugf("Creating new versions of %d properties", len(creations))
+	created, err := client.CreateBulkVersions(ctx, CreateBulkVersionsRequest{CreatePropertyVersions: creations})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	versions, err := waitForBulkVersions(ctx, client, created.BulkCreateID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	results := make([]*bulkPatchResult, 0, len(versions.CreatePropertyVersions))
+	byProperty := make(map[string]*bulkPatchResult)
+	request := CreateBulkPatchRequest{}
+	for _, v := range versions.CreatePropertyVersions {
+		result := &bulkPatchResult{
+			PropertyID:        v.PropertyID,
+			PropertyName:      v.PropertyName,
+			CreateFromVersion: v.CreateFromVersion,
+			PropertyVersion:   v.PropertyVersion,
+			Status:            v.Status,
+			Error:             v.FailureCause,
+		}
+		results = append(results, result)
+		if v.Status != BulkItemStatusComplete {
+			continue
+		}
+		byProperty[v.PropertyID] = result
+		request.PatchPropertyVersions = append(request.PatchPropertyVersions, BulkPatch{
+			PropertyID:      v.PropertyID,
+			PropertyVersion: v.PropertyVersion,
+			Patches:         patches,
+		})
+	}
+
+	var bulkPatchID int
+	if len(request.PatchPropertyVersions) > 0 {
+		logger.Debugf("Patching %d new property versions", len(request.PatchPropertyVersions))
+		submitted, err := client.CreateBulkPatch(ctx, request)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		bulkPatchID = submitted.BulkPatchID
+		patched, err := waitForBulkPatch(ctx, client, bulkPatchID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		for _, p := range patched.PatchPropertyVersions {
+			result, ok := byProperty[p.PropertyID]
+			if !ok {
+				continue
+			}
+			result.Status = p.Status
+			result.Error = p.FailureCause
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%d:%d", created.BulkCreateID, bulkPatchID))
+	attrs := map[string]interface{}{
+		"bulk_create_id": created.BulkCreateID,
+		"bulk_patch_id":  bulkPatchID,
+		"results":        flattenBulkPatchResults(results),
+		"versions":       bulkPatchVersions(results),
+	}
+	if err := tools.SetAttrs(d, attrs); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+
+	var diags diag.Diagnostics
+	for _, r := range results {
+		if r.Status == BulkItemStatusComplete {
+			continue
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("property %s (%s) was not patched", r.PropertyID, r.PropertyName),
+			Detail:   fmt.Sprintf("status %s: %s", r.Status, r.Error),
+		})
+	}
+
+	return append(diags, resourcePropertyBulkPatchRead(ctx, d, m)...)
+}
+
+func resourcePropertyBulkPatchRead(_ context.Context, _ *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourcePropertyBulkPatchRead")
+	logger.Debug("Bulk patch results are only kept in state")
+	return nil
+}
+
+func resourcePropertyBulkPatchDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourcePropertyBulkPatchDelete")
+	logger.Info("PAPI does not support property version deletion - resource will only be removed from state")
+	d.SetId("")
+	return nil
+}
+
+// validateJSONValue accepts any JSON value, not only objects
+func validateJSONValue(val interface{}, _ cty.Path) diag.Diagnostics {
+	str, ok := val.(string)
+	if !ok {
+		return diag.Errorf("value is not a string: %s", val)
+	}
+	if !json.Valid([]byte(str)) {
+		return diag.Errorf("invalid JSON value: %s", str)
+	}
+	return nil
+}
+
+// getBulkPatchOperations converts the patch blocks into JSON patch operations
+func getBulkPatchOperations(d *schema.ResourceData) ([]BulkPatchOperation, error) {
+	patchList, err := tools.GetListValue("patch", d)
+	if err != nil {
+		return nil, err
+	}
+	patches := make([]BulkPatchOperation, 0, len(patchList))
+	for _, p := range patchList {
+		patch := p.(map[string]interface{})
+		op := BulkPatchOperation{
+			Op:   patch["op"].(string),
+			Path: patch["path"].(string),
+			From: patch["from"].(string),
+		}
+		if value := patch["value"].(string); value != "" {
+			op.Value = json.RawMessage(value)
+		}
+		patches = append(patches, op)
+	}
+	return patches, nil
+}
+
+// getBulkVersionCreations returns the property versions to branch from, either taken from the bulk search or
+// from the property blocks
+func getBulkVersionCreations(ctx context.Context, d *schema.ResourceData, meta akamai.OperationMeta) ([]BulkVersionCreation, error) {
+	bulkSearchID, err := tools.GetIntValue("bulk_search_id", d)
+	if err != nil && !errors.Is(err, tools.ErrNotFound) {
+		return nil, err
+	}
+	if bulkSearchID != 0 {
+		search, err := inst.BulkClient(meta).GetBulkSearch(ctx, GetBulkSearchRequest{BulkSearchID: bulkSearchID})
+		if err != nil {
+			return nil, err
+		}
+		if search.SearchTargetStatus != BulkStatusComplete {
+			return nil, fmt.Errorf("%w: bulk search %d is not complete: %s", ErrBulkPatchFailed, bulkSearchID, search.SearchTargetStatus)
+		}
+		return bulkVersionCreationsFromSearch(search.Results), nil
+	}
+
+	properties, err := tools.GetListValue("property", d)
+	if err != nil {
+		return nil, err
+	}
+	client := inst.Client(meta)
+	creations := make([]BulkVersionCreation, 0, len(properties))
+	for _, p := range properties {
+		property := p.(map[string]interface{})
+		creation := BulkVersionCreation{
+			PropertyID:        tools.AddPrefix(property["property_id"].(string), "prp_"),
+			CreateFromVersion: property["version"].(int),
+		}
+		if creation.CreateFromVersion == 0 {
+			latest, err := client.GetLatestVersion(ctx, papi.GetLatestVersionRequest{PropertyID: creation.PropertyID})
+			if err != nil {
+				return nil, err
+			}
+			creation.CreateFromVersion = latest.Version.PropertyVersion
+			creation.CreateFromVersionEtag = latest.Version.Etag
+		}
+		creations = append(creations, creation)
+	}
+	return creations, nil
+}
+
+// bulkVersionCreationsFromSearch picks the highest matching version of every property found by a bulk search
+func bulkVersionCreationsFromSearch(results []BulkSearchResult) []BulkVersionCreation {
+	highest := make(map[string]int)
+	for _, r := range results {
+		if r.PropertyVersion > highest[r.PropertyID] {
+			highest[r.PropertyID] = r.PropertyVersion
+		}
+	}
+	creations := make([]BulkVersionCreation, 0, len(highest))
+	for propertyID, version := range highest {
+		creations = append(creations, BulkVersionCreation{PropertyID: propertyID, CreateFromVersion: version})
+	}
+	sort.Slice(creations, func(i, j int) bool {
+		return creations[i].PropertyID < creations[j].PropertyID
+	})
+	return creations
+}
+
+func waitForBulkVersions(ctx context.Context, client BulkPAPI, bulkCreateID int) (*BulkVersionsResponse, error) {
+	for {
+		versions, err := client.GetBulkVersions(ctx, GetBulkVersionsRequest{BulkCreateID: bulkCreateID})
+		if err != nil {
+			return nil, err
+		}
+		switch versions.BulkCreateVersionsStatus {
+		case BulkStatusComplete:
+			return versions, nil
+		case BulkStatusError:
+			return nil, fmt.Errorf("%w: bulk version creation %d finished with status %s", ErrBulkPatchFailed, bulkCreateID, versions.BulkCreateVersionsStatus)
+		}
+		select {
+		case <-time.After(BulkPatchPollInterval):
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for bulk version creation %d: %w", bulkCreateID, ctx.Err())
+		}
+	}
+}
+
+func waitForBulkPatch(ctx context.Context, client BulkPAPI, bulkPatchID int) (*BulkPatchResponse, error) {
+	for {
+		patch, err := client.GetBulkPatch(ctx, GetBulkPatchRequest{BulkPatchID: bulkPatchID})
+		if err != nil {
+			return nil, err
+		}
+		switch patch.BulkPatchStatus {
+		case BulkStatusComplete:
+			return patch, nil
+		case BulkStatusError:
+			return nil, fmt.Errorf("%w: bulk patch %d finished with status %s", ErrBulkPatchFailed, bulkPatchID, patch.BulkPatchStatus)
+		}
+		select {
+		case <-time.After(BulkPatchPollInterval):
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for bulk patch %d: %w", bulkPatchID, ctx.Err())
+		}
+	}
+}
+
+func flattenBulkPatchResults(results []*bulkPatchResult) []interface{} {
+	flattened := make([]interface{}, 0, len(results))
+	for _, r := range results {
+		flattened = append(flattened, map[string]interface{}{
+			"property_id":         r.PropertyID,
+			"property_name":       r.PropertyName,
+			"create_from_version": r.CreateFromVersion,
+			"property_version":    r.PropertyVersion,
+			"status":              r.Status,
+			"error":               r.Error,
+		})
+	}
+	return flattened
+}
+
+func bulkPatchVersions(results []*bulkPatchResult) map[string]interface{} {
+	versions := make(map[string]interface{})
+	for _, r := range results {
+		if r.Status == BulkItemStatusComplete {
+			versions[r.PropertyID] = r.PropertyVersion
+		}
+	}
+	return versions
+}
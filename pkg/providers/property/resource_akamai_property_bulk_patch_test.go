@@ -0,0 +1,206 @@
+package property
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestResPropertyBulkPatch(t *testing.T) {
+	BulkPatchPollInterval = time.Millisecond
+
+	replaceOrigin := []BulkPatchOperation{
+		{Op: "replace", Path: "/rules/behaviors/0/options/hostname", Value: json.RawMessage(`"origin2.example.com"`)},
+		{Op: "remove", Path: "/rules/children/1"},
+	}
+
+	tests := map[string]struct {
+		init       func(*mockpapi, *mockbulkpapi)
+		configPath string
+		checks     resource.TestCheckFunc
+		withError  *regexp.Regexp
+	}{
+		"patch listed properties": {
+			init: func(p *mockpapi, b *mockbulkpapi) {
+				p.On("GetLatestVersion", mock.Anything, papi.GetLatestVersionRequest{PropertyID: "prp_3"}).
+					Return(&papi.GetPropertyVersionsResponse{
+						PropertyID: "prp_3",
+						Version:    papi.PropertyVersionGetItem{PropertyVersion: 7, Etag: "e7"},
+					}, nil).Once()
+				b.On("CreateBulkVersions", mock.Anything, CreateBulkVersionsRequest{
+					CreatePropertyVersions: []BulkVersionCreation{
+						{PropertyID: "prp_1", CreateFromVersion: 2},
+						{PropertyID: "prp_3", CreateFromVersion: 7, CreateFromVersionEtag: "e7"},
+					},
+				}).Return(&CreateBulkVersionsResponse{BulkCreateID: 5}, nil).Once()
+				b.On("GetBulkVersions", mock.Anything, GetBulkVersionsRequest{BulkCreateID: 5}).
+					Return(&BulkVersionsResponse{BulkCreateID: 5, BulkCreateVersionsStatus: BulkStatusInProgress}, nil).Once()
+				b.On("GetBulkVersions", mock.Anything, GetBulkVersionsRequest{BulkCreateID: 5}).
+					Return(&BulkVersionsResponse{
+						BulkCreateID:             5,
+						BulkCreateVersionsStatus: BulkStatusComplete,
+						CreatePropertyVersions: []BulkVersionResult{
+							{PropertyID: "prp_1", PropertyName: "one", CreateFromVersion: 2, PropertyVersion: 3, Status: BulkItemStatusComplete},
+							{PropertyID: "prp_3", PropertyName: "three", CreateFromVersion: 7, PropertyVersion: 8, Status: BulkItemStatusComplete},
+						},
+					}, nil).Once()
+				b.On("CreateBulkPatch", mock.Anything, CreateBulkPatchRequest{
+					PatchPropertyVersions: []BulkPatch{
+						{PropertyID: "prp_1", PropertyVersion: 3, Patches: replaceOrigin},
+						{PropertyID: "prp_3", PropertyVersion: 8, Patches: replaceOrigin},
+					},
+				}).Return(&CreateBulkPatchResponse{BulkPatchID: 9}, nil).Once()
+				b.On("GetBulkPatch", mock.Anything, GetBulkPatchRequest{BulkPatchID: 9}).
+					Return(&BulkPatchResponse{
+						BulkPatchID:     9,
+						BulkPatchStatus: BulkStatusComplete,
+						PatchPropertyVersions: []BulkPatchResult{
+							{PropertyID: "prp_1", PropertyVersion: 3, Status: BulkItemStatusComplete},
+							{PropertyID: "prp_3", PropertyVersion: 8, Status: BulkItemStatusFailed, FailureCause: "path not found"},
+						},
+					}, nil).Once()
+			},
+			configPath: "testdata/TestResPropertyBulkPatch/properties.tf",
+			checks: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "id", "5:9"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "results.#", "2"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "results.0.property_version", "3"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "results.0.status", BulkItemStatusComplete),
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "results.1.create_from_version", "7"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "results.1.status", BulkItemStatusFailed),
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "results.1.error", "path not found"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "versions.%", "1"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "versions.prp_1", "3"),
+			),
+		},
+		"patch properties found by bulk search": {
+			init: func(p *mockpapi, b *mockbulkpapi) {
+				b.On("GetBulkSearch", mock.Anything, GetBulkSearchRequest{BulkSearchID: 10}).
+					Return(&BulkSearchResponse{
+						BulkSearchID:       10,
+						SearchTargetStatus: BulkStatusComplete,
+						Results: []BulkSearchResult{
+							{PropertyID: "prp_2", PropertyVersion: 4},
+							{PropertyID: "prp_1", PropertyVersion: 1},
+							{PropertyID: "prp_2", PropertyVersion: 6},
+						},
+					}, nil).Once()
+				b.On("CreateBulkVersions", mock.Anything, CreateBulkVersionsRequest{
+					CreatePropertyVersions: []BulkVersionCreation{
+						{PropertyID: "prp_1", CreateFromVersion: 1},
+						{PropertyID: "prp_2", CreateFromVersion: 6},
+					},
+				}).Return(&CreateBulkVersionsResponse{BulkCreateID: 6}, nil).Once()
+				b.On("GetBulkVersions", mock.Anything, GetBulkVersionsRequest{BulkCreateID: 6}).
+					Return(&BulkVersionsResponse{
+						BulkCreateID:             6,
+						BulkCreateVersionsStatus: BulkStatusComplete,
+						CreatePropertyVersions: []BulkVersionResult{
+							{PropertyID: "prp_1", CreateFromVersion: 1, PropertyVersion: 2, Status: BulkItemStatusComplete},
+							{PropertyID: "prp_2", CreateFromVersion: 6, Status: BulkItemStatusFailed, FailureCause: "property is locked"},
+						},
+					}, nil).Once()
+				b.On("CreateBulkPatch", mock.Anything, CreateBulkPatchRequest{
+					PatchPropertyVersions: []BulkPatch{{
+						PropertyID:      "prp_1",
+						PropertyVersion: 2,
+						Patches: []BulkPatchOperation{{
+							Op:    "add",
+							Path:  "/rules/children/-",
+							Value: json.RawMessage(`{"behaviors":[],"children":[],"criteria":[],"name":"HSTS"}`),
+						}},
+					}},
+				}).Return(&CreateBulkPatchResponse{BulkPatchID: 11}, nil).Once()
+				b.On("GetBulkPatch", mock.Anything, GetBulkPatchRequest{BulkPatchID: 11}).
+					Return(&BulkPatchResponse{
+						BulkPatchID:     11,
+						BulkPatchStatus: BulkStatusComplete,
+						PatchPropertyVersions: []BulkPatchResult{
+							{PropertyID: "prp_1", PropertyVersion: 2, Status: BulkItemStatusComplete},
+						},
+					}, nil).Once()
+			},
+			configPath: "testdata/TestResPropertyBulkPatch/bulk_search.tf",
+			checks: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "bulk_create_id", "6"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "bulk_patch_id", "11"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "results.1.error", "property is locked"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "versions.%", "1"),
+				resource.TestCheckResourceAttr("akamai_property_bulk_patch.test", "versions.prp_1", "2"),
+			),
+		},
+		"bulk search not complete": {
+			init: func(p *mockpapi, b *mockbulkpapi) {
+				b.On("GetBulkSearch", mock.Anything, GetBulkSearchRequest{BulkSearchID: 10}).
+					Return(&BulkSearchResponse{BulkSearchID: 10, SearchTargetStatus: BulkStatusInProgress}, nil).Once()
+			},
+			configPath: "testdata/TestResPropertyBulkPatch/bulk_search.tf",
+			withError:  regexp.MustCompile("bulk search 10 is not complete"),
+		},
+		"bulk version creation failed": {
+			init: func(p *mockpapi, b *mockbulkpapi) {
+				p.On("GetLatestVersion", mock.Anything, papi.GetLatestVersionRequest{PropertyID: "prp_3"}).
+					Return(&papi.GetPropertyVersionsResponse{
+						PropertyID: "prp_3",
+						Version:    papi.PropertyVersionGetItem{PropertyVersion: 7},
+					}, nil).Once()
+				b.On("CreateBulkVersions", mock.Anything, mock.Anything).Return(&CreateBulkVersionsResponse{BulkCreateID: 5}, nil).Once()
+				b.On("GetBulkVersions", mock.Anything, GetBulkVersionsRequest{BulkCreateID: 5}).
+					Return(&BulkVersionsResponse{BulkCreateID: 5, BulkCreateVersionsStatus: BulkStatusError}, nil).Once()
+			},
+			configPath: "testdata/TestResPropertyBulkPatch/properties.tf",
+			withError:  regexp.MustCompile("bulk version creation 5 finished with status ERROR"),
+		},
+		"bulk search and properties": {
+			configPath: "testdata/TestResPropertyBulkPatch/search_and_properties.tf",
+			withError:  regexp.MustCompile(`only one of .bulk_search_id,property. can be specified`),
+		},
+		"invalid patch value": {
+			configPath: "testdata/TestResPropertyBulkPatch/invalid_value.tf",
+			withError:  regexp.MustCompile("invalid JSON value"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &mockpapi{}
+			bulkClient := &mockbulkpapi{}
+			if test.init != nil {
+				test.init(client, bulkClient)
+			}
+			useClient(client, func() {
+				useBulkClient(bulkClient, func() {
+					resource.UnitTest(t, resource.TestCase{
+						Providers: testAccProviders,
+						Steps: []resource.TestStep{{
+							Config:      loadFixtureString(test.configPath),
+							Check:       test.checks,
+							ExpectError: test.withError,
+						}},
+					})
+				})
+			})
+			client.AssertExpectations(t)
+			bulkClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBulkVersionCreationsFromSearch(t *testing.T) {
+	creations := bulkVersionCreationsFromSearch([]BulkSearchResult{
+		{PropertyID: "prp_3", PropertyVersion: 1},
+		{PropertyID: "prp_1", PropertyVersion: 5},
+		{PropertyID: "prp_3", PropertyVersion: 2},
+		{PropertyID: "prp_1", PropertyVersion: 4},
+	})
+	assert.Equal(t, []BulkVersionCreation{
+		{PropertyID: "prp_1", CreateFromVersion: 5},
+		{PropertyID: "prp_3", CreateFromVersion: 2},
+	}, creations)
+}
@@ -0,0 +1,171 @@
+package property
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+)
+
+func resourcePropertyCertificateWait() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePropertyCertificateWaitCreate,
+		ReadContext:   resourcePropertyCertificateWaitRead,
+		DeleteContext: resourcePropertyCertificateWaitDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: &PropertyResourceTimeout,
+		},
+		Schema: map[string]*schema.Schema{
+			"property_id": {
+				Type:      schema.TypeString,
+				Required:  true,
+				ForceNew:  true,
+				StateFunc: addPrefixToState("prp_"),
+			},
+			"version": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"network": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  papi.ActivationNetworkStaging,
+			},
+			"certificates": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Default DV certificates of the property hostnames and their status on the network",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cname_from": {Type: schema.TypeString, Computed: true},
+						"hostname":   {Type: schema.TypeString, Computed: true},
+						"target":     {Type: schema.TypeString, Computed: true},
+						"status":     {Type: schema.TypeString, Computed: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+var (
+	// CertificatePollInterval is the interval for polling the certificate status of the property hostnames
+	CertificatePollInterval = time.Minute
+
+	// ErrCertificatesNotDeployed is returned when Default DV certificates are not deployed before the timeout
+	ErrCertificatesNotDeployed = errors.New("certificates not deployed")
+)
+
+// CertStatusDeployed is the status of a Default DV certificate deployed on a network
+const CertStatusDeployed = "DEPLOYED"
+
+func resourcePropertyCertificateWaitCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourcePropertyCertificateWaitCreate")
+	client := inst.Client(meta)
+
+	logger.Debug("resourcePropertyCertificateWaitCreate call")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	propertyID, version, network, err := certificateWaitAttributes(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// the ID is set before waiting, so a timed out wait is kept in state as tainted and retried on the next apply
+	d.SetId(fmt.Sprintf("%s:%d:%s", propertyID, version, network))
+
+	for {
+		hostnames, err := getActivationHostnames(ctx, client, propertyID, version)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		certificates := flattenCertificateChallenges(hostnames, network)
+		if err := d.Set("certificates", certificates); err != nil {
+			return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+		}
+
+		var pending []string
+		for _, c := range certificates {
+			if c["status"] != CertStatusDeployed {
+				pending = append(pending, fmt.Sprintf("%s (%s): create CNAME %s pointing to %s",
+					c["cname_from"], c["status"], c["hostname"], c["target"]))
+			}
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+		logger.Debugf("waiting for %d certificates to be deployed on %s", len(pending), network)
+
+		select {
+		case <-time.After(CertificatePollInterval):
+		case <-ctx.Done():
+			return diag.FromErr(fmt.Errorf("%w on %s: %s", ErrCertificatesNotDeployed, network, strings.Join(pending, "; ")))
+		}
+	}
+}
+
+func resourcePropertyCertificateWaitRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := akamai.Meta(m)
+	logger := meta.Log("PAPI", "resourcePropertyCertificateWaitRead")
+	client := inst.Client(meta)
+
+	logger.Debug("resourcePropertyCertificateWaitRead call")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+
+	propertyID, version, network, err := certificateWaitAttributes(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	hostnames, err := getActivationHostnames(ctx, client, propertyID, version)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("certificates", flattenCertificateChallenges(hostnames, network)); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tools.ErrValueSet, err.Error()))
+	}
+
+	return nil
+}
+
+func resourcePropertyCertificateWaitDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	// waiting for the certificates changes nothing on the Akamai side, the resource is only removed from state
+	d.SetId("")
+	return nil
+}
+
+func certificateWaitAttributes(d *schema.ResourceData) (string, int, papi.ActivationNetwork, error) {
+	propertyID, err := tools.GetStringValue("property_id", d)
+	if err != nil {
+		return "", 0, "", err
+	}
+	version, err := tools.GetIntValue("version", d)
+	if err != nil {
+		return "", 0, "", err
+	}
+	network, err := networkAlias(d)
+	if err != nil {
+		return "", 0, "", err
+	}
+	return tools.AddPrefix(propertyID, "prp_"), version, network, nil
+}
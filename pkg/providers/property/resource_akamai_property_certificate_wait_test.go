@@ -0,0 +1,67 @@
+package property
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourcePropertyCertificateWait(t *testing.T) {
+	tests := map[string]struct {
+		init  func(*mockpapi)
+		steps []resource.TestStep
+	}{
+		"waits until certificates are deployed": {
+			init: func(m *mockpapi) {
+				expectGetPropertyVersionHostnames(m, "prp_test", 1, "PENDING").Once()
+				expectGetPropertyVersionHostnames(m, "prp_test", 1, CertStatusDeployed)
+			},
+			steps: []resource.TestStep{
+				{
+					Config: loadFixtureString("./testdata/TestResPropertyCertificateWait/ok.tf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("akamai_property_certificate_wait.test", "id", "prp_test:1:PRODUCTION"),
+						resource.TestCheckResourceAttr("akamai_property_certificate_wait.test", "certificates.#", "1"),
+						resource.TestCheckResourceAttr("akamai_property_certificate_wait.test", "certificates.0.cname_from", "www.example.com"),
+						resource.TestCheckResourceAttr("akamai_property_certificate_wait.test", "certificates.0.status", "DEPLOYED"),
+					),
+				},
+			},
+		},
+		"certificates not deployed before timeout": {
+			init: func(m *mockpapi) {
+				expectGetPropertyVersionHostnames(m, "prp_test", 1, "PENDING")
+			},
+			steps: []resource.TestStep{
+				{
+					Config: loadFixtureString("./testdata/TestResPropertyCertificateWait/timeout.tf"),
+					ExpectError: regexp.MustCompile(`certificates not deployed on PRODUCTION: www.example.com \(PENDING\): ` +
+						`create CNAME _acme-challenge.www.example.com pointing to ac.1234.www.example.com.validate-akdv.net`),
+				},
+			},
+		},
+	}
+
+	pollInterval := CertificatePollInterval
+	CertificatePollInterval = time.Millisecond
+	defer func() { CertificatePollInterval = pollInterval }()
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &mockpapi{}
+			if test.init != nil {
+				test.init(client)
+			}
+			useClient(client, func() {
+				resource.UnitTest(t, resource.TestCase{
+					Providers:  testAccProviders,
+					IsUnitTest: true,
+					Steps:      test.steps,
+				})
+			})
+			client.AssertExpectations(t)
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v2/pkg/papi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -196,6 +197,41 @@ func TestResProperty(t *testing.T) {
 		}
 	}
 
+	// Clones the latest version of a property which has the given rules and hostnames
+	CloneProperty := func(PropertyName, PropertyID string, CloneFrom papi.PropertyCloneFrom, rules papi.RulesUpdate, hostnames []papi.Hostname) BehaviorFunc {
+		return func(State *TestState) {
+			State.Client.On("GetLatestVersion", AnyCTX, papi.GetLatestVersionRequest{PropertyID: CloneFrom.PropertyID}).
+				Return(&papi.GetPropertyVersionsResponse{Version: papi.PropertyVersionGetItem{PropertyVersion: CloneFrom.Version}}, nil).Once()
+			req := papi.CreatePropertyRequest{
+				GroupID:    "grp_0",
+				ContractID: "ctr_0",
+				Property: papi.PropertyCreate{
+					CloneFrom:    &CloneFrom,
+					ProductID:    "prd_0",
+					PropertyName: PropertyName,
+				},
+			}
+			State.Client.On("CreateProperty", AnyCTX, req).Return(&papi.CreatePropertyResponse{PropertyID: PropertyID}, nil).Run(func(mock.Arguments) {
+				State.Property = papi.Property{
+					PropertyName:  PropertyName,
+					PropertyID:    PropertyID,
+					GroupID:       "grp_0",
+					ContractID:    "ctr_0",
+					ProductID:     "prd_0",
+					LatestVersion: 1,
+				}
+
+				State.Rules = rules
+				State.RuleFormat = "v2020-01-01"
+				if CloneFrom.CopyHostnames {
+					State.Hostnames = append([]papi.Hostname{}, hostnames...)
+				}
+				GetProperty(PropertyID)(State)
+				GetVersionResources(PropertyID, "ctr_0", "grp_0", 1)(State)
+			}).Once()
+		}
+	}
+
 	PropertyLifecycle := func(PropertyName, PropertyID, GroupID string, rules papi.RulesUpdate) BehaviorFunc {
 		return func(State *TestState) {
 			CreateProperty(PropertyName, PropertyID, rules)(State)
@@ -531,6 +567,93 @@ func TestResProperty(t *testing.T) {
 		},
 	}
 
+	// VersionNotes tests that version notes replace the comments of the rules and are kept out of the rules attribute
+	VersionNotes := LifecycleTestCase{
+		Name: "Version notes are written with the rules",
+		ClientSetup: ComposeBehaviors(
+			PropertyLifecycle("test_property", "prp_0", "grp_0",
+				papi.RulesUpdate{Rules: papi.Rules{Behaviors: []papi.RuleBehavior{{Name: "caching",
+					Options: papi.RuleOptionsMap{"behavior": "MAX_AGE", "mustRevalidate": false, "ttl": "12d"}}},
+					Name: "default"}}),
+			GetPropertyVersions("prp_0", "test_property", "ctr_0", "grp_0"),
+			GetPropertyVersionResources("prp_0", "grp_0", "ctr_0", 1, papi.VersionStatusInactive, papi.VersionStatusInactive),
+			SetHostnames("prp_0", 1, "to.test.domain"),
+			UpdateRuleTree("prp_0", "ctr_0", "grp_0", 1,
+				&papi.RulesUpdate{Rules: papi.Rules{Behaviors: []papi.RuleBehavior{{Name: "caching",
+					Options: papi.RuleOptionsMap{"behavior": "MAX_AGE", "mustRevalidate": false, "ttl": "12d"}}},
+					Name: "default"}, Comments: "initial release"}),
+			UpdateRuleTree("prp_0", "ctr_0", "grp_0", 1,
+				&papi.RulesUpdate{Rules: papi.Rules{Behaviors: []papi.RuleBehavior{{Name: "caching",
+					Options: papi.RuleOptionsMap{"behavior": "MAX_AGE", "mustRevalidate": false, "ttl": "13d"}}},
+					Name: "default"}, Comments: "cache tuning\nUpdated by Terraform: behavior caching modified (ttl) in default"}),
+		),
+		Steps: func(State *TestState, FixturePath string) []resource.TestStep {
+			return []resource.TestStep{
+				{
+					PreConfig: func() {
+						State.VersionItems = papi.PropertyVersionItems{Items: []papi.PropertyVersionGetItem{{PropertyVersion: 1, ProductionStatus: papi.VersionStatusInactive}}}
+					},
+					Config: loadFixtureString("%s/step0.tf", FixturePath),
+					Check: CheckAttrs("prp_0", "to.test.domain", "1", "0", "0", "ehn_123",
+						`{"rules":{"behaviors":[{"name":"caching","options":{"behavior":"MAX_AGE","mustRevalidate":false,"ttl":"12d"}}],"name":"default","options":{}}}`),
+				},
+				{
+					Config: loadFixtureString("%s/step1.tf", FixturePath),
+					Check: CheckAttrs("prp_0", "to.test.domain", "1", "0", "0", "ehn_123",
+						`{"rules":{"behaviors":[{"name":"caching","options":{"behavior":"MAX_AGE","mustRevalidate":false,"ttl":"13d"}}],"name":"default","options":{}}}`),
+				},
+			}
+		},
+	}
+
+	// CloneFrom tests that the copied hostnames are kept until hostnames are declared and clone_from is ignored after creation
+	CloneFrom := LifecycleTestCase{
+		Name: "Property is cloned from the latest version of another property",
+		ClientSetup: ComposeBehaviors(
+			CloneProperty("test_property", "prp_0",
+				papi.PropertyCloneFrom{PropertyID: "prp_1", Version: 3, CopyHostnames: true},
+				papi.RulesUpdate{Rules: papi.Rules{Name: "default", Comments: "golden"}},
+				[]papi.Hostname{{
+					CnameType:            "EDGE_HOSTNAME",
+					CnameFrom:            "golden.test.domain",
+					CnameTo:              "golden.test.domain.edgesuite.net",
+					CertProvisioningType: "CPS_MANAGED",
+					EdgeHostnameID:       "ehn_1",
+				}}),
+			GetVersionResources("prp_0", "ctr_0", "grp_0", 1),
+			DeleteProperty("prp_0"),
+			GetPropertyVersions("prp_0", "test_property", "ctr_0", "grp_0"),
+			GetPropertyVersionResources("prp_0", "grp_0", "ctr_0", 1, papi.VersionStatusInactive, papi.VersionStatusInactive),
+			UpdateRuleTree("prp_0", "ctr_0", "grp_0", 1,
+				&papi.RulesUpdate{Rules: papi.Rules{Behaviors: []papi.RuleBehavior{{Name: "caching",
+					Options: papi.RuleOptionsMap{"behavior": "MAX_AGE", "mustRevalidate": false, "ttl": "12d"}}},
+					Name: "default"}}),
+			SetHostnames("prp_0", 1, "to.test.domain"),
+		),
+		Steps: func(State *TestState, FixturePath string) []resource.TestStep {
+			return []resource.TestStep{
+				{
+					PreConfig: func() {
+						State.VersionItems = papi.PropertyVersionItems{Items: []papi.PropertyVersionGetItem{{PropertyVersion: 1, ProductionStatus: papi.VersionStatusInactive}}}
+					},
+					Config: loadFixtureString("%s/step0.tf", FixturePath),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("akamai_property.test", "id", "prp_0"),
+						resource.TestCheckResourceAttr("akamai_property.test", "clone_from.0.property_id", "prp_1"),
+						resource.TestCheckResourceAttr("akamai_property.test", "hostnames.#", "0"),
+						resource.TestCheckResourceAttr("akamai_property.test", "rules",
+							`{"rules":{"behaviors":[{"name":"caching","options":{"behavior":"MAX_AGE","mustRevalidate":false,"ttl":"12d"}}],"name":"default","options":{}}}`),
+					),
+				},
+				{
+					Config: loadFixtureString("%s/step1.tf", FixturePath),
+					Check: CheckAttrs("prp_0", "to.test.domain", "1", "0", "0", "ehn_123",
+						`{"rules":{"behaviors":[{"name":"caching","options":{"behavior":"MAX_AGE","mustRevalidate":false,"ttl":"12d"}}],"name":"default","options":{}}}`),
+				},
+			}
+		},
+	}
+
 	NoDiffForHostnames := LifecycleTestCase{
 		Name: "No diff found in update",
 		ClientSetup: ComposeBehaviors(
@@ -801,6 +924,8 @@ func TestResProperty(t *testing.T) {
 		t.Run("Lifecycle: no diff (product_id to product)", AssertLifecycle(t, t.Name(), "product_id to product", NoDiff))
 		t.Run("Lifecycle: rules custom diff", AssertLifecycle(t, t.Name(), "rules custom diff", RulesCustomDiff))
 		t.Run("Lifecycle: no diff for hostnames (hostnames)", AssertLifecycle(t, t.Name(), "hostnames", NoDiffForHostnames))
+		t.Run("Lifecycle: version notes", AssertLifecycle(t, t.Name(), "version notes", VersionNotes))
+		t.Run("Lifecycle: clone from", AssertLifecycle(t, t.Name(), "clone from", CloneFrom))
 
 		// Test Import
 
@@ -1200,3 +1325,64 @@ func TestValidatePropertyName(t *testing.T) {
 		})
 	}
 }
+
+func TestSummarizePropertyChanges(t *testing.T) {
+	hostnames := func(cnames ...string) *schema.Set {
+		set := schema.NewSet(resourceProperty().Schema["hostnames"].Set, nil)
+		for _, cname := range cnames {
+			set.Add(map[string]interface{}{
+				"cname_from":             cname,
+				"cname_to":               "to.test.domain",
+				"cert_provisioning_type": "CPS_MANAGED",
+			})
+		}
+		return set
+	}
+	rules := `{"rules":{"name":"default","behaviors":[{"name":"caching","options":{"ttl":"1d"}}],
+		"children":[{"name":"Performance","behaviors":[{"name":"http2","options":{}}]}]}}`
+
+	var behaviors, manyChanges []string
+	for i := 0; i < 12; i++ {
+		behaviors = append(behaviors, fmt.Sprintf(`{"name":"behavior%02d","options":{}}`, i))
+		if i < maxVersionNotesChanges {
+			manyChanges = append(manyChanges, fmt.Sprintf("behavior behavior%02d added in default", i))
+		}
+	}
+	manyBehaviors := fmt.Sprintf(`{"rules":{"name":"default","behaviors":[%s]}}`, strings.Join(behaviors, ","))
+
+	tests := map[string]struct {
+		oldRules, newRules   string
+		oldFormat, newFormat string
+		oldHosts, newHosts   *schema.Set
+		expected             string
+	}{
+		"no changes": {
+			oldRules: rules, newRules: rules,
+			oldFormat: "v2020-03-04", newFormat: "v2020-03-04",
+			oldHosts: hostnames("a.test.domain"), newHosts: hostnames("a.test.domain"),
+			expected: "Updated by Terraform: no rule or hostname changes",
+		},
+		"rules, rule format and hostnames": {
+			oldRules:  `{"rules":{"name":"default","behaviors":[{"name":"caching","options":{"ttl":"2d"}}]}}`,
+			newRules:  rules,
+			oldFormat: "v2020-03-04", newFormat: "v2021-01-01",
+			oldHosts: hostnames("a.test.domain", "c.test.domain"), newHosts: hostnames("a.test.domain", "b.test.domain"),
+			expected: "Updated by Terraform: rule format changed from v2020-03-04 to v2021-01-01; " +
+				"behavior caching modified (ttl) in default; rule Performance added in default; " +
+				"hostnames added: b.test.domain; hostnames removed: c.test.domain",
+		},
+		"too many changes": {
+			oldRules: `{"rules":{"name":"default"}}`,
+			newRules: manyBehaviors,
+			oldHosts: hostnames(), newHosts: hostnames("1.test.domain", "2.test.domain"),
+			expected: "Updated by Terraform: " + strings.Join(manyChanges, "; ") + "; 3 more changes",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			summary := summarizePropertyChanges(test.oldRules, test.newRules, test.oldFormat, test.newFormat, test.oldHosts, test.newHosts)
+			assert.Equal(t, test.expected, summary)
+		})
+	}
+}
@@ -0,0 +1,12 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+data "akamai_edge_hostnames" "test" {
+  contract_id   = "1"
+  group_id      = "2"
+  domain_suffix = "edgekey.net"
+  ip_behavior   = "ipv6_compliance"
+  secure        = true
+  slot_number   = 12
+}
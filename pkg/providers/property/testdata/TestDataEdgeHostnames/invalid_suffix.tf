@@ -0,0 +1,9 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+data "akamai_edge_hostnames" "test" {
+  contract_id   = "ctr_1"
+  group_id      = "grp_2"
+  domain_suffix = "example.net"
+}
@@ -0,0 +1,10 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+data "akamai_edge_hostnames" "test" {
+  contract_id               = "1"
+  group_id                  = "2"
+  slot_number               = 12
+  certificate_enrollment_id = 123
+}
@@ -0,0 +1,12 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+data "akamai_property_activations" "test" {
+  property_id      = "1"
+  contract_id      = "1"
+  group_id         = "2"
+  network          = "prod"
+  submitted_after  = "2022-01-01T00:00:00Z"
+  submitted_before = "2022-02-01T00:00:00Z"
+}
@@ -0,0 +1,10 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+data "akamai_property_bulk_search" "test" {
+  contract_id            = "1"
+  group_id               = "grp_2"
+  match                  = "$..features[?(@.name == 'origin')].options[?(@.hostname == 'origin.example.com')]"
+  bulk_search_qualifiers = ["$.options[?(@.secure=='true')]"]
+}
@@ -0,0 +1,8 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+data "akamai_property_bulk_search" "test" {
+  match    = "$..behaviors[?(@.name == 'caching')]"
+  versions = "ACTIVE_PRODUCTION"
+}
@@ -0,0 +1,9 @@
+provider "akamai" {
+  edgerc        = "~/.edgerc"
+  cache_enabled = false
+}
+
+data "akamai_property_hostnames_inventory" "test" {
+  contract_id = "1"
+  network     = "production"
+}
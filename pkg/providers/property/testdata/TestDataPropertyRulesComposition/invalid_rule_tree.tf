@@ -0,0 +1,11 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+data "akamai_property_rules_composition" "test" {
+  base = jsonencode({ rules = { name = "default" } })
+
+  overlay {
+    patch = jsonencode({ rules = { behaviors = "caching" } })
+  }
+}
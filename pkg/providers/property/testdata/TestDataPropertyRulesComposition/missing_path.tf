@@ -0,0 +1,12 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+data "akamai_property_rules_composition" "test" {
+  base = jsonencode({ rules = { name = "default" } })
+
+  overlay {
+    type  = "json_patch"
+    patch = jsonencode([{ op = "remove", path = "/rules/children/Static" }])
+  }
+}
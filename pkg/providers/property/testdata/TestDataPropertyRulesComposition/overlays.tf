@@ -0,0 +1,54 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+data "akamai_property_rules_composition" "test" {
+  base = jsonencode({
+    rules = {
+      name = "default"
+      behaviors = [
+        {
+          name    = "origin"
+          options = { hostname = "origin.example.com", httpPort = 80 }
+        },
+        {
+          name    = "caching"
+          options = { behavior = "MAX_AGE", ttl = "1d" }
+        }
+      ]
+      children = [
+        {
+          name      = "Performance"
+          behaviors = [{ name = "prefetch", options = { enabled = true } }]
+        },
+        {
+          name = "Static"
+          criteria = [
+            {
+              name    = "fileExtension"
+              options = { values = ["css", "js"] }
+            }
+          ]
+        }
+      ]
+    }
+  })
+
+  overlay {
+    patch = jsonencode({
+      rules = {
+        behaviors = [{ name = "caching", options = { ttl = "1h" } }]
+        children  = [{ name = "Staging", criteria = [{ name = "hostname", options = { values = ["staging.example.com"] } }] }]
+      }
+    })
+  }
+
+  overlay {
+    type = "json_patch"
+    patch = jsonencode([
+      { op = "replace", path = "/rules/behaviors/origin/options/hostname", value = "staging-origin.example.com" },
+      { op = "remove", path = "/rules/children/Static" },
+      { op = "add", path = "/rules/children/Performance/behaviors/-", value = { name = "http2", options = {} } },
+    ])
+  }
+}
@@ -0,0 +1,29 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+data "akamai_property_rules_lint" "test" {
+  rules = jsonencode({
+    rules = {
+      name = "default"
+      behaviors = [
+        { name = "origin", options = { hostname = "origin.example.com", httpPort = 80, httpsPort = 443 } },
+      ]
+      children = [
+        {
+          name     = "Static"
+          criteria = [{ name = "fileExtension", options = { matchOperator = "IS_ONE_OF", values = ["css", "js"] } }]
+          behaviors = [
+            { name = "caching", options = { behavior = "MAX_AGE", ttl = "0s" } },
+          ]
+        },
+      ]
+    }
+  })
+
+  assertion {
+    name   = "static_caching"
+    path   = "$..children[?(@.name == 'Static')].behaviors[?(@.name == 'caching' && @.options.ttl == '0s')]"
+    expect = "absent"
+  }
+}
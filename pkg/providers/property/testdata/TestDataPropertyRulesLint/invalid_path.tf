@@ -0,0 +1,12 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+data "akamai_property_rules_lint" "test" {
+  rules = jsonencode({ rules = { name = "default" } })
+
+  assertion {
+    name = "invalid"
+    path = "rules.behaviors"
+  }
+}
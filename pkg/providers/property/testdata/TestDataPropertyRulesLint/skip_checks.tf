@@ -0,0 +1,16 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+data "akamai_property_rules_lint" "test" {
+  rules = jsonencode({
+    rules = {
+      name = "default"
+      behaviors = [
+        { name = "origin", options = { hostname = "origin.example.com", httpPort = 80 } },
+      ]
+    }
+  })
+
+  skip_checks = ["missing_cp_code", "non_tls_origin"]
+}
@@ -0,0 +1,47 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+data "akamai_property_rules_lint" "test" {
+  rules = jsonencode({
+    rules = {
+      name = "default"
+      behaviors = [
+        { name = "cpCode", options = { value = { id = 12345 } } },
+        { name = "origin", options = { hostname = "origin.example.com", httpPort = 80 } },
+        { name = "caching", options = { behavior = "MAX_AGE", ttl = "1d" } },
+      ]
+      children = [
+        {
+          name     = "Static"
+          criteria = [{ name = "path", options = { matchOperator = "IS_ONE_OF", values = ["/static/*"] } }]
+          children = [
+            {
+              name     = "Images"
+              criteria = [{ name = "path", options = { matchOperator = "IS_ONE_OF", values = ["/images/*"] } }]
+            },
+            {
+              name     = "Scripts"
+              criteria = [{ name = "path", options = { matchOperator = "IS_ONE_OF", values = ["/static/js/*"] } }]
+            },
+          ]
+        },
+        { name = "Offload" },
+        { name = "Offload" },
+      ]
+    }
+  })
+
+  assertion {
+    name     = "hsts"
+    path     = "$..behaviors[?(@.name == 'httpStrictTransportSecurity')]"
+    severity = "warning"
+    message  = "HSTS must be enabled"
+  }
+
+  assertion {
+    name   = "caching_default"
+    path   = "$.rules.behaviors[?(@.name == 'caching')]"
+    expect = "present"
+  }
+}
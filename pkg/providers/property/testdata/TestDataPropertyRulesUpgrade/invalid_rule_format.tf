@@ -0,0 +1,36 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+data "akamai_property_rules_upgrade" "test" {
+  property_id        = "prp_1"
+  source_rule_format = "v2020-03-04"
+  target_rule_format = "2021-01-21"
+
+  rules = jsonencode({
+    rules = {
+      name = "default"
+      behaviors = [
+        {
+          name    = "origin"
+          options = { hostname = "origin.example.com", httpPort = 80 }
+        },
+        {
+          name    = "caching"
+          options = { behavior = "MAX_AGE", ttl = "1d" }
+        }
+      ]
+      children = [
+        {
+          name = "Static"
+          criteria = [
+            {
+              name    = "fileExtension"
+              options = { values = ["css", "js"] }
+            }
+          ]
+        }
+      ]
+    }
+  })
+}
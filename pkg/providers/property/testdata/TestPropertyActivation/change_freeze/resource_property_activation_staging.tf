@@ -0,0 +1,17 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+  change_freeze {
+    window {
+      start       = "2000-01-01T00:00:00Z"
+      end         = "2100-01-01T00:00:00Z"
+      description = "test freeze"
+    }
+  }
+}
+
+resource "akamai_property_activation" "test" {
+  property_id = "test"
+  contact     = ["user@example.com"]
+  version     = 1
+  network     = "STAGING"
+}
@@ -0,0 +1,12 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+resource "akamai_cp_code" "test" {
+  name        = "test cpcode"
+  contract_id = "ctr_1"
+  group_id    = "grp_1"
+  product_id  = "prd_1"
+  time_zone   = "2"
+  purgeable   = false
+}
@@ -0,0 +1,25 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+resource "akamai_property_activation_batch" "test" {
+  network = "PRODUCTION"
+  contact = ["user@example.com"]
+
+  group {
+    property {
+      property_id = "prp_1"
+      version     = 2
+    }
+  }
+  group {
+    property {
+      property_id = "prp_2"
+      version     = 3
+    }
+    property {
+      property_id = "3"
+      version     = 1
+    }
+  }
+}
@@ -0,0 +1,20 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+resource "akamai_property_activation_batch" "test" {
+  contact = ["user@example.com"]
+
+  group {
+    property {
+      property_id = "prp_1"
+      version     = 2
+    }
+  }
+  group {
+    property {
+      property_id = "1"
+      version     = 3
+    }
+  }
+}
@@ -0,0 +1,13 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+resource "akamai_property_bulk_patch" "test" {
+  bulk_search_id = 10
+
+  patch {
+    op    = "add"
+    path  = "/rules/children/-"
+    value = jsonencode({ name = "HSTS", children = [], behaviors = [], criteria = [] })
+  }
+}
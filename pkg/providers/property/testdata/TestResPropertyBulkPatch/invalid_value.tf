@@ -0,0 +1,15 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+resource "akamai_property_bulk_patch" "test" {
+  property {
+    property_id = "prp_1"
+  }
+
+  patch {
+    op    = "replace"
+    path  = "/rules/behaviors/0/options/hostname"
+    value = "origin2.example.com"
+  }
+}
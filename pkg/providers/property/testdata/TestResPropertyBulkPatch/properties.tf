@@ -0,0 +1,23 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+resource "akamai_property_bulk_patch" "test" {
+  property {
+    property_id = "prp_1"
+    version     = 2
+  }
+  property {
+    property_id = "3"
+  }
+
+  patch {
+    op    = "replace"
+    path  = "/rules/behaviors/0/options/hostname"
+    value = jsonencode("origin2.example.com")
+  }
+  patch {
+    op   = "remove"
+    path = "/rules/children/1"
+  }
+}
@@ -0,0 +1,15 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+resource "akamai_property_bulk_patch" "test" {
+  bulk_search_id = 10
+  property {
+    property_id = "prp_1"
+  }
+
+  patch {
+    op   = "remove"
+    path = "/rules/children/1"
+  }
+}
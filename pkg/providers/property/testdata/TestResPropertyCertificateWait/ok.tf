@@ -0,0 +1,9 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+resource "akamai_property_certificate_wait" "test" {
+  property_id = "test"
+  version     = 1
+  network     = "PRODUCTION"
+}
@@ -0,0 +1,14 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+}
+
+resource "akamai_edge_hostname" "edgehostname" {
+  contract            = "ctr_2"
+  group               = "grp_2"
+  product             = "prd_3"
+  edge_hostname       = "test.edgesuite.net"
+  ip_behavior         = "IPV4"
+  ttl                 = 300
+  status_update_email = ["admin@example.com"]
+  delete_on_destroy   = true
+}